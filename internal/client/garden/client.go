@@ -16,6 +16,7 @@ import (
 	"fmt"
 	"os/exec"
 	"path"
+	"strings"
 
 	openstackinstall "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/install"
 	openstackv1alpha1 "github.com/gardener/gardener-extension-provider-openstack/pkg/apis/openstack/v1alpha1"
@@ -39,10 +40,15 @@ import (
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardenctl-v2/internal/debug"
 )
 
 var decoder runtime.Decoder
 
+// ErrNoShootFound is returned by FindShoot if no shoot matches the given list options.
+var ErrNoShootFound = errors.New("no shoot found")
+
 func init() {
 	extensionsScheme := runtime.NewScheme()
 	utilruntime.Must(openstackinstall.AddToScheme(extensionsScheme))
@@ -117,7 +123,12 @@ type clientImpl struct {
 }
 
 // NewClient returns a new garden Client.
+// If the debug.EnvUnsafe environment variable is set, the fetched resources are logged.
 func NewClient(config clientcmd.ClientConfig, client client.Client, name string) Client {
+	if debug.UnsafeEnabled() {
+		client = &debugClient{Client: client}
+	}
+
 	return &clientImpl{
 		config: config,
 		c:      client,
@@ -201,7 +212,7 @@ func (g *clientImpl) FindShoot(ctx context.Context, opts ...client.ListOption) (
 	}
 
 	if len(shootList.Items) == 0 {
-		return nil, fmt.Errorf("no shoot found matching the given list options %q", opts)
+		return nil, fmt.Errorf("%w matching the given list options %q", ErrNoShootFound, opts)
 	}
 
 	var remainingItemCount int64
@@ -210,7 +221,16 @@ func (g *clientImpl) FindShoot(ctx context.Context, opts ...client.ListOption) (
 	}
 
 	if len(shootList.Items) > 1 || remainingItemCount > 0 {
-		return nil, fmt.Errorf("multiple shoots found matching the given list options %q, please target a project or seed to make your choice unambiguous", opts)
+		namespaces := make([]string, 0, len(shootList.Items))
+		for _, shoot := range shootList.Items {
+			namespaces = append(namespaces, shoot.Namespace)
+		}
+
+		if remainingItemCount > 0 {
+			namespaces = append(namespaces, fmt.Sprintf("%d more", remainingItemCount))
+		}
+
+		return nil, fmt.Errorf("multiple shoots found matching the given list options %q in the namespaces %s, please target a project or seed to make your choice unambiguous", opts, strings.Join(namespaces, ", "))
 	}
 
 	return &shootList.Items[0], nil
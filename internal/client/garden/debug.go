@@ -0,0 +1,67 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package garden
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// debugClient is a client.Client which logs the resources read from the garden cluster.
+type debugClient struct {
+	client.Client
+}
+
+func (c *debugClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := c.Client.Get(ctx, key, obj, opts...)
+
+	klog.FromContext(ctx).Info("Garden client fetched resource", "kind", c.kind(obj), "object", klog.KRef(key.Namespace, key.Name), "error", errorString(err))
+
+	return err
+}
+
+func (c *debugClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	err := c.Client.List(ctx, list, opts...)
+
+	var objects []klog.ObjectRef
+
+	if err == nil {
+		_ = meta.EachListItem(list, func(o runtime.Object) error {
+			if accessor, err := meta.Accessor(o); err == nil {
+				objects = append(objects, klog.KRef(accessor.GetNamespace(), accessor.GetName()))
+			}
+
+			return nil
+		})
+	}
+
+	klog.FromContext(ctx).Info("Garden client listed resources", "kind", c.kind(list), "objects", objects, "error", errorString(err))
+
+	return err
+}
+
+func (c *debugClient) kind(obj runtime.Object) string {
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		return gvk.Kind
+	}
+
+	return fmt.Sprintf("%T", obj)
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,102 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package garden_test
+
+import (
+	"context"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	clientgarden "github.com/gardener/gardenctl-v2/internal/client/garden"
+	"github.com/gardener/gardenctl-v2/internal/debug"
+	"github.com/gardener/gardenctl-v2/internal/fake"
+	"github.com/gardener/gardenctl-v2/internal/util"
+)
+
+var _ = Describe("Unsafe debug logging", func() {
+	var (
+		ctx    context.Context
+		logs   *util.SafeBytesBuffer
+		secret *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		logs = &util.SafeBytesBuffer{}
+		klog.SetOutput(logs)
+		klog.LogToStderr(false)
+		DeferCleanup(func() {
+			klog.SetOutput(os.Stderr)
+			klog.LogToStderr(true)
+		})
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cloudprovider",
+				Namespace: "garden-prod1",
+			},
+			Data: map[string][]byte{
+				"serviceaccount.json": []byte("top-secret-value"),
+			},
+			StringData: map[string]string{
+				"password": "another-secret-value",
+			},
+		}
+	})
+
+	Context("when the debug mode is enabled", func() {
+		BeforeEach(func() {
+			Expect(os.Setenv(debug.EnvUnsafe, "true")).To(Succeed())
+			DeferCleanup(os.Unsetenv, debug.EnvUnsafe)
+		})
+
+		It("should log the fetched secret without its data", func() {
+			gardenClient := clientgarden.NewClient(nil, fake.NewClientWithObjects(secret), "my-garden")
+
+			s, err := gardenClient.GetSecret(ctx, secret.Namespace, secret.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.Data).To(HaveKey("serviceaccount.json"))
+
+			klog.Flush()
+			Expect(logs.String()).To(ContainSubstring("Garden client fetched resource"))
+			Expect(logs.String()).To(ContainSubstring(`kind="Secret"`))
+			Expect(logs.String()).To(ContainSubstring("garden-prod1/cloudprovider"))
+			Expect(logs.String()).NotTo(ContainSubstring("top-secret-value"))
+			Expect(logs.String()).NotTo(ContainSubstring("another-secret-value"))
+		})
+
+		It("should log the names of listed resources without their data", func() {
+			gardenClient := clientgarden.NewClient(nil, fake.NewClientWithObjects(secret), "my-garden")
+
+			Expect(gardenClient.RuntimeClient().List(ctx, &corev1.SecretList{})).To(Succeed())
+
+			klog.Flush()
+			Expect(logs.String()).To(ContainSubstring("Garden client listed resources"))
+			Expect(logs.String()).To(ContainSubstring(`{"name":"cloudprovider","namespace":"garden-prod1"}`))
+			Expect(logs.String()).NotTo(ContainSubstring("top-secret-value"))
+			Expect(logs.String()).NotTo(ContainSubstring("another-secret-value"))
+		})
+	})
+
+	Context("when the debug mode is disabled", func() {
+		It("should not log the fetched resources", func() {
+			gardenClient := clientgarden.NewClient(nil, fake.NewClientWithObjects(secret), "my-garden")
+
+			_, err := gardenClient.GetSecret(ctx, secret.Namespace, secret.Name)
+			Expect(err).NotTo(HaveOccurred())
+
+			klog.Flush()
+			Expect(logs.String()).To(BeEmpty())
+		})
+	})
+})
@@ -6,7 +6,11 @@ SPDX-License-Identifier: Apache-2.0
 
 package garden
 
-import "k8s.io/apimachinery/pkg/runtime"
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
 
 type ExecPluginConfig struct {
 	execPluginConfig
@@ -15,3 +19,7 @@ type ExecPluginConfig struct {
 func (e *ExecPluginConfig) ToRuntimeObject() runtime.Object {
 	return &e.execPluginConfig
 }
+
+func SetFindShootRetryInterval(d time.Duration) {
+	findShootRetryInterval = d
+}
@@ -0,0 +1,67 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package garden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// findShootRetryInterval is the time in-between the attempts to find a shoot that does not exist yet.
+var findShootRetryInterval = 2 * time.Second
+
+// FindShootWithRetry calls FindShoot of the given client until the shoot is found or the given timeout expires,
+// e.g. to give a newly created shoot time to appear. Only the errors of a shoot that does not exist are retried.
+// If the timeout is not positive, FindShoot is called exactly once.
+func FindShootWithRetry(ctx context.Context, c Client, timeout time.Duration, opts ...client.ListOption) (*gardencorev1beta1.Shoot, error) {
+	if timeout <= 0 {
+		return c.FindShoot(ctx, opts...)
+	}
+
+	var (
+		shoot   *gardencorev1beta1.Shoot
+		lastErr error
+	)
+
+	logger := klog.FromContext(ctx)
+
+	err := wait.PollUntilContextTimeout(ctx, findShootRetryInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		var err error
+
+		shoot, err = c.FindShoot(ctx, opts...)
+		if err == nil {
+			return true, nil
+		}
+
+		if !errors.Is(err, ErrNoShootFound) && !apierrors.IsNotFound(err) {
+			return false, err
+		}
+
+		lastErr = err
+		logger.V(1).Info("Waiting for shoot to appear", "error", err.Error())
+
+		return false, nil
+	})
+
+	if wait.Interrupted(err) && lastErr != nil {
+		return nil, fmt.Errorf("timed out waiting for the shoot to appear: %w", lastErr)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return shoot, nil
+}
@@ -0,0 +1,93 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package garden_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clientgarden "github.com/gardener/gardenctl-v2/internal/client/garden"
+	gardenclientmocks "github.com/gardener/gardenctl-v2/internal/client/garden/mocks"
+)
+
+var _ = Describe("FindShootWithRetry", func() {
+	var (
+		ctx        context.Context
+		ctrl       *gomock.Controller
+		mockClient *gardenclientmocks.MockClient
+		shoot      *gardencorev1beta1.Shoot
+		listOption client.ListOption
+		notFound   error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl = gomock.NewController(GinkgoT())
+		mockClient = gardenclientmocks.NewMockClient(ctrl)
+		shoot = &gardencorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-prod1"}}
+		listOption = client.InNamespace("garden-prod1")
+		notFound = fmt.Errorf("%w matching the given list options", clientgarden.ErrNoShootFound)
+
+		clientgarden.SetFindShootRetryInterval(10 * time.Millisecond)
+		DeferCleanup(clientgarden.SetFindShootRetryInterval, 2*time.Second)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should find the shoot once it appeared", func() {
+		gomock.InOrder(
+			mockClient.EXPECT().FindShoot(gomock.Any(), listOption).Return(nil, notFound),
+			mockClient.EXPECT().FindShoot(gomock.Any(), listOption).Return(shoot, nil),
+		)
+
+		Expect(clientgarden.FindShootWithRetry(ctx, mockClient, time.Second, listOption)).To(Equal(shoot))
+	})
+
+	It("should retry if the API server responds with not found", func() {
+		gomock.InOrder(
+			mockClient.EXPECT().FindShoot(gomock.Any(), listOption).Return(nil, apierrors.NewNotFound(gardencorev1beta1.Resource("projects"), "prod1")),
+			mockClient.EXPECT().FindShoot(gomock.Any(), listOption).Return(shoot, nil),
+		)
+
+		Expect(clientgarden.FindShootWithRetry(ctx, mockClient, time.Second, listOption)).To(Equal(shoot))
+	})
+
+	It("should call FindShoot only once without timeout", func() {
+		mockClient.EXPECT().FindShoot(gomock.Any(), listOption).Return(nil, notFound)
+
+		_, err := clientgarden.FindShootWithRetry(ctx, mockClient, 0, listOption)
+		Expect(err).To(BeIdenticalTo(notFound))
+	})
+
+	It("should not retry other errors", func() {
+		err := errors.New("multiple shoots found")
+		mockClient.EXPECT().FindShoot(gomock.Any(), listOption).Return(nil, err)
+
+		_, findErr := clientgarden.FindShootWithRetry(ctx, mockClient, time.Second, listOption)
+		Expect(findErr).To(BeIdenticalTo(err))
+	})
+
+	It("should fail if the shoot does not appear in time", func() {
+		mockClient.EXPECT().FindShoot(gomock.Any(), listOption).Return(nil, notFound).MinTimes(1)
+
+		_, err := clientgarden.FindShootWithRetry(ctx, mockClient, 50*time.Millisecond, listOption)
+		Expect(err).To(MatchError(ContainSubstring("timed out waiting for the shoot to appear: no shoot found")))
+		Expect(err).To(MatchError(clientgarden.ErrNoShootFound))
+	})
+})
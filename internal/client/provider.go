@@ -7,12 +7,26 @@ SPDX-License-Identifier: Apache-2.0
 package client
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"strings"
 
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// execPluginInstallDocs maps the names of well-known kubectl auth plugins to their installation instructions.
+var execPluginInstallDocs = map[string]string{
+	"kubectl-gardenlogin": "https://github.com/gardener/gardenlogin#installation",
+	"gardenlogin":         "https://github.com/gardener/gardenlogin#installation",
+	"kubectl-oidc_login":  "https://github.com/int128/kubelogin#setup",
+	"kubelogin":           "https://azure.github.io/kubelogin/install.html",
+}
+
 //go:generate mockgen -destination=./mocks/mock_provider.go -package=mocks github.com/gardener/gardenctl-v2/internal/client Provider
 
 // Provider is able to take a kubeconfig either directly or
@@ -38,5 +52,35 @@ func (p *provider) FromClientConfig(clientConfig clientcmd.ClientConfig) (client
 		return nil, fmt.Errorf("failed to create restclient config: %w", err)
 	}
 
+	if err := checkExecPlugin(config.ExecProvider); err != nil {
+		return nil, err
+	}
+
 	return client.New(config, client.Options{})
 }
+
+// checkExecPlugin returns an error naming the missing binary if the exec plugin of the kubeconfig is not installed.
+// Otherwise, the request would fail later on with an opaque exec error.
+func checkExecPlugin(execConfig *clientcmdapi.ExecConfig) error {
+	if execConfig == nil || execConfig.Command == "" {
+		return nil
+	}
+
+	_, err := exec.LookPath(execConfig.Command)
+	if err == nil || (!errors.Is(err, exec.ErrNotFound) && !errors.Is(err, fs.ErrNotExist)) {
+		return nil
+	}
+
+	name := strings.TrimSuffix(filepath.Base(execConfig.Command), filepath.Ext(execConfig.Command))
+
+	hint := execPluginInstallDocs[name]
+	if hint != "" {
+		hint = "see " + hint + " for installation instructions"
+	} else if execConfig.InstallHint != "" {
+		hint = execConfig.InstallHint
+	} else {
+		hint = "install it and make sure it can be found in your PATH"
+	}
+
+	return fmt.Errorf("the kubeconfig requires the auth plugin %q, which is not installed or not in your PATH: %s", execConfig.Command, hint)
+}
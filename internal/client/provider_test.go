@@ -0,0 +1,75 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	internalclient "github.com/gardener/gardenctl-v2/internal/client"
+)
+
+var _ = Describe("Provider", func() {
+	var config *clientcmdapi.Config
+
+	BeforeEach(func() {
+		config = clientcmdapi.NewConfig()
+		config.Clusters["garden"] = &clientcmdapi.Cluster{Server: "https://api.garden.example.org"}
+		config.AuthInfos["user"] = &clientcmdapi.AuthInfo{
+			Exec: &clientcmdapi.ExecConfig{
+				APIVersion:      "client.authentication.k8s.io/v1",
+				Command:         "kubectl-oidc_login",
+				InstallHint:     "install the plugin",
+				InteractiveMode: clientcmdapi.IfAvailableExecInteractiveMode,
+			},
+		}
+		config.Contexts["garden"] = &clientcmdapi.Context{Cluster: "garden", AuthInfo: "user"}
+		config.CurrentContext = "garden"
+
+		// make sure that none of the exec plugins used in the tests can be found
+		GinkgoT().Setenv("PATH", GinkgoT().TempDir())
+	})
+
+	fromClientConfig := func() error {
+		_, err := internalclient.NewProvider().FromClientConfig(clientcmd.NewDefaultClientConfig(*config, nil))
+		return err
+	}
+
+	It("should name the missing exec plugin and link to its installation instructions", func() {
+		Expect(fromClientConfig()).To(MatchError(`the kubeconfig requires the auth plugin "kubectl-oidc_login", which is not installed or not in your PATH: see https://github.com/int128/kubelogin#setup for installation instructions`))
+	})
+
+	It("should use the install hint of the kubeconfig for unknown exec plugins", func() {
+		config.AuthInfos["user"].Exec.Command = "kubectl-custom_login"
+
+		Expect(fromClientConfig()).To(MatchError(`the kubeconfig requires the auth plugin "kubectl-custom_login", which is not installed or not in your PATH: install the plugin`))
+	})
+
+	It("should fail if the exec plugin is given by a path that does not exist", func() {
+		config.AuthInfos["user"].Exec.Command = "/does/not/exist/kubectl-gardenlogin"
+
+		Expect(fromClientConfig()).To(MatchError(ContainSubstring("see https://github.com/gardener/gardenlogin#installation")))
+	})
+
+	It("should create the client if the exec plugin is installed", func() {
+		executable, err := os.Executable()
+		Expect(err).NotTo(HaveOccurred())
+		config.AuthInfos["user"].Exec.Command = executable
+
+		Expect(fromClientConfig()).To(Succeed())
+	})
+
+	It("should create the client if no exec plugin is used", func() {
+		config.AuthInfos["user"] = &clientcmdapi.AuthInfo{Token: "token"}
+
+		Expect(fromClientConfig()).To(Succeed())
+	})
+})
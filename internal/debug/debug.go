@@ -0,0 +1,25 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package debug provides the switch for the unsafe debug output of gardenctl. It has no dependencies on other
+// gardenctl packages, so that it can be used by the configuration as well as by the clients.
+package debug
+
+import (
+	"os"
+	"strconv"
+)
+
+// EnvUnsafe is the name of the environment variable that enables the unsafe debug output, e.g. the logging of
+// the resources fetched by the garden client or the configured patterns in the error of a failed pattern match.
+// Only names are included in the output, never the contents of the resources.
+const EnvUnsafe = "GCTL_UNSAFE_DEBUG"
+
+// UnsafeEnabled returns true if the EnvUnsafe environment variable is set to a true value.
+func UnsafeEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(EnvUnsafe))
+	return err == nil && enabled
+}
@@ -44,6 +44,10 @@ type Factory struct {
 
 	// GardenTempDirectory is the base directory for temporary data.
 	GardenTempDirectory string
+
+	// SessionDirectory is the session directory of the created manager.
+	// Will use the temporary directory of the OS if not set.
+	SessionDirectory string
 }
 
 var _ util.Factory = &Factory{}
@@ -79,7 +83,10 @@ func (f *Factory) Manager() (target.Manager, error) {
 		return f.ManagerImpl, nil
 	}
 
-	sessionDir := os.TempDir()
+	sessionDir := f.SessionDirectory
+	if sessionDir == "" {
+		sessionDir = os.TempDir()
+	}
 
 	return target.NewManager(f.Config, f.TargetProviderImpl, f.ClientProviderImpl, sessionDir)
 }
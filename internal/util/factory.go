@@ -168,7 +168,18 @@ func (f *FactoryImpl) TargetFlags() target.TargetFlags {
 }
 
 func callIPify(ctx context.Context, domain string) (*net.IP, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://%s/", domain), nil)
+	ip, err := FetchPublicIP(ctx, fmt.Sprintf("https://%s/", domain))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ip, nil
+}
+
+// FetchPublicIP calls the given URL of an IP detection service, which is expected
+// to respond with the public IP address of the caller as plain text.
+func FetchPublicIP(ctx context.Context, url string) (net.IP, error) {
+	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -193,7 +204,7 @@ func callIPify(ctx context.Context, domain string) (*net.IP, error) {
 		return nil, fmt.Errorf("API returned an invalid IP (%q)", ipAddress)
 	}
 
-	return &netIP, nil
+	return netIP, nil
 }
 
 func getSessionID() (string, error) {
@@ -0,0 +1,39 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// WritePrivateFile writes data to the named file, which can only be read and written by the current user.
+// The data is written to a temporary file in the same directory, which is then renamed. Unlike os.WriteFile,
+// the data is therefore never readable by others, even if the file existed before with broader permissions.
+func WritePrivateFile(filename string, data []byte) error {
+	// os.CreateTemp creates the file with permissions 0600
+	file, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".*")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// the temporary file is already gone if it has been renamed
+		_ = os.Remove(file.Name())
+	}()
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), filename)
+}
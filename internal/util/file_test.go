@@ -0,0 +1,62 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+)
+
+var _ = Describe("File Utilities", func() {
+	Describe("writing private files", func() {
+		var (
+			dir      string
+			filename string
+		)
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+			filename = filepath.Join(dir, "secret")
+		})
+
+		It("should write the file readable only by the current user", func() {
+			Expect(util.WritePrivateFile(filename, []byte("secret"))).To(Succeed())
+
+			Expect(os.ReadFile(filename)).To(Equal([]byte("secret")))
+
+			if runtime.GOOS != "windows" {
+				info, err := os.Stat(filename)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+			}
+		})
+
+		It("should replace an existing file with broader permissions", func() {
+			Expect(os.WriteFile(filename, []byte("old"), 0o644)).To(Succeed())
+
+			Expect(util.WritePrivateFile(filename, []byte("secret"))).To(Succeed())
+
+			Expect(os.ReadFile(filename)).To(Equal([]byte("secret")))
+
+			if runtime.GOOS != "windows" {
+				info, err := os.Stat(filename)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+			}
+
+			entries, err := os.ReadDir(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+		})
+	})
+})
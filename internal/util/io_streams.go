@@ -11,6 +11,8 @@ import (
 	"io"
 	"os"
 	"sync"
+
+	"golang.org/x/term"
 )
 
 // IOStreams provides the standard names for iostreams. This is useful for embedding and for unit testing.
@@ -33,6 +35,16 @@ func NewIOStreams() IOStreams {
 	}
 }
 
+// IsTerminal checks if the given stream, e.g. an io.Reader or io.Writer, is connected to a terminal.
+func IsTerminal(stream interface{}) bool {
+	file, ok := stream.(*os.File)
+	if !ok {
+		return false
+	}
+
+	return term.IsTerminal(int(file.Fd()))
+}
+
 // NewTestIOStreams returns a valid IOStreams and in, out, errout buffers for unit tests.
 func NewTestIOStreams() (IOStreams, *SafeBytesBuffer, *SafeBytesBuffer, *SafeBytesBuffer) {
 	in := &SafeBytesBuffer{}
@@ -98,33 +98,37 @@ func (m *AccessRestrictionMessage) messageWidth() int {
 	return width
 }
 
-func (ar *AccessRestriction) checkAccessRestriction(all []gardencorev1beta1.AccessRestrictionWithOptions) *AccessRestrictionMessage {
-	matches := func(m map[string]string, key string, val bool) bool {
-		rawVal, ok := m[key]
-		if !ok {
-			return false
-		}
-
-		boolVal, err := strconv.ParseBool(rawVal)
-		if err != nil {
-			// If parsing fails, skip it
-			return false
-		}
+// optionMatches returns the raw value of the option of a shoot access restriction and whether it equals notifyIf.
+func optionMatches(options map[string]string, key string, notifyIf bool) (string, bool) {
+	rawVal, ok := options[key]
+	if !ok {
+		return "", false
+	}
 
-		return boolVal == val
+	boolVal, err := strconv.ParseBool(rawVal)
+	if err != nil {
+		// If parsing fails, skip it
+		return "", false
 	}
 
-	effectiveKey := mapLegacyKey(ar.Key)
+	return rawVal, boolVal == notifyIf
+}
 
-	var match *gardencorev1beta1.AccessRestrictionWithOptions
+// findShootAccessRestriction returns the access restriction of the shoot matching the key of ar, or nil.
+func (ar *AccessRestriction) findShootAccessRestriction(all []gardencorev1beta1.AccessRestrictionWithOptions) *gardencorev1beta1.AccessRestrictionWithOptions {
+	effectiveKey := mapLegacyKey(ar.Key)
 
 	for _, item := range all {
 		if item.Name == effectiveKey {
-			match = &item
-			break // only one match is possible, so we can break early
+			return &item // only one match is possible, so we can return early
 		}
 	}
 
+	return nil
+}
+
+func (ar *AccessRestriction) checkAccessRestriction(all []gardencorev1beta1.AccessRestrictionWithOptions) *AccessRestrictionMessage {
+	match := ar.findShootAccessRestriction(all)
 	if match == nil {
 		return nil
 	}
@@ -134,7 +138,7 @@ func (ar *AccessRestriction) checkAccessRestriction(all []gardencorev1beta1.Acce
 	}
 
 	for _, option := range ar.Options {
-		if matches(match.Options, option.Key, option.NotifyIf) {
+		if _, ok := optionMatches(match.Options, option.Key, option.NotifyIf); ok {
 			message.Items = append(message.Items, option.Msg)
 		}
 	}
@@ -166,6 +170,71 @@ func CheckAccessRestrictions(accessRestrictions []AccessRestriction, shoot *gard
 	return messages
 }
 
+// AccessRestrictionExplanation explains why a configured access restriction matches a shoot.
+type AccessRestrictionExplanation struct {
+	// Key is the key of the configured access restriction.
+	Key string `json:"key"`
+	// Message is the notification text of the configured access restriction.
+	Message string `json:"message"`
+	// Field is the path of the shoot field that triggered the access restriction.
+	Field string `json:"field"`
+	// Value is the value of the shoot field that triggered the access restriction.
+	Value string `json:"value"`
+	// Options explain the matching options of the access restriction.
+	Options []AccessRestrictionExplanation `json:"options,omitempty"`
+}
+
+// AccessRestrictionExplanations is a list of access restriction explanations.
+type AccessRestrictionExplanations []AccessRestrictionExplanation
+
+// ExplainAccessRestrictions returns an explanation for each access restriction that matches the given shoot cluster,
+// i.e. for each message returned by CheckAccessRestrictions.
+func ExplainAccessRestrictions(accessRestrictions []AccessRestriction, shoot *gardencorev1beta1.Shoot) AccessRestrictionExplanations {
+	var explanations AccessRestrictionExplanations
+
+	for _, accessRestriction := range accessRestrictions {
+		match := accessRestriction.findShootAccessRestriction(shoot.Spec.AccessRestrictions)
+		if match == nil {
+			continue
+		}
+
+		explanation := AccessRestrictionExplanation{
+			Key:     accessRestriction.Key,
+			Message: accessRestriction.Msg,
+			Field:   "spec.accessRestrictions[].name",
+			Value:   match.Name,
+		}
+
+		for _, option := range accessRestriction.Options {
+			if value, ok := optionMatches(match.Options, option.Key, option.NotifyIf); ok {
+				explanation.Options = append(explanation.Options, AccessRestrictionExplanation{
+					Key:     option.Key,
+					Message: option.Msg,
+					Field:   fmt.Sprintf("spec.accessRestrictions[name=%s].options[%s]", match.Name, option.Key),
+					Value:   value,
+				})
+			}
+		}
+
+		explanations = append(explanations, explanation)
+	}
+
+	return explanations
+}
+
+// Render displays the access restriction explanations.
+func (explanations AccessRestrictionExplanations) Render(w io.Writer) {
+	for _, e := range explanations {
+		fmt.Fprintf(w, "Access restriction %q: %s\n", e.Key, e.Message)
+		fmt.Fprintf(w, "  triggered by %s=%s\n", e.Field, e.Value)
+
+		for _, option := range e.Options {
+			fmt.Fprintf(w, "  Option %q: %s\n", option.Key, option.Message)
+			fmt.Fprintf(w, "    triggered by %s=%s\n", option.Field, option.Value)
+		}
+	}
+}
+
 // AccessRestrictionMessage collects all messages for an access restriction in order to display them to the user.
 type AccessRestrictionMessage struct {
 	Header string
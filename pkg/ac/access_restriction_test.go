@@ -132,6 +132,81 @@ var _ = Describe("AccessRestriction", func() {
 		})
 	})
 
+	Describe("Explaining access restrictions", func() {
+		var (
+			accessRestrictions []ac.AccessRestriction
+			shoot              *gardencorev1beta1.Shoot
+		)
+
+		BeforeEach(func() {
+			accessRestrictions = []ac.AccessRestriction{
+				{
+					Key: "seed.gardener.cloud/eu-access",
+					Msg: "EU access only",
+					Options: []ac.AccessRestrictionOption{
+						{Key: "addons", NotifyIf: false, Msg: "Addons are not restricted"},
+						{Key: "nodes", NotifyIf: false, Msg: "Nodes are not restricted"},
+					},
+				},
+				{
+					Key: "b",
+					Msg: "B",
+				},
+				{
+					Key: "c",
+					Msg: "C",
+				},
+			}
+			shoot = &gardencorev1beta1.Shoot{
+				Spec: gardencorev1beta1.ShootSpec{
+					AccessRestrictions: []gardencorev1beta1.AccessRestrictionWithOptions{
+						{
+							AccessRestriction: gardencorev1beta1.AccessRestriction{
+								Name: "eu-access-only",
+							},
+							Options: map[string]string{
+								"addons": "false",
+								"nodes":  "true",
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("should explain the matching access restriction and options", func() {
+			explanations := ac.ExplainAccessRestrictions(accessRestrictions, shoot)
+			Expect(explanations).To(Equal(ac.AccessRestrictionExplanations{{
+				Key:     "seed.gardener.cloud/eu-access",
+				Message: "EU access only",
+				Field:   "spec.accessRestrictions[].name",
+				Value:   "eu-access-only",
+				Options: []ac.AccessRestrictionExplanation{{
+					Key:     "addons",
+					Message: "Addons are not restricted",
+					Field:   "spec.accessRestrictions[name=eu-access-only].options[addons]",
+					Value:   "false",
+				}},
+			}}))
+			Expect(explanations).To(HaveLen(len(ac.CheckAccessRestrictions(accessRestrictions, shoot))))
+		})
+
+		It("should render the explanations", func() {
+			out := &bytes.Buffer{}
+			ac.ExplainAccessRestrictions(accessRestrictions, shoot).Render(out)
+			Expect(out.String()).To(Equal(`Access restriction "seed.gardener.cloud/eu-access": EU access only
+  triggered by spec.accessRestrictions[].name=eu-access-only
+  Option "addons": Addons are not restricted
+    triggered by spec.accessRestrictions[name=eu-access-only].options[addons]=false
+`))
+		})
+
+		It("should not explain anything if no access restriction matches", func() {
+			shoot.Spec.AccessRestrictions = nil
+			Expect(ac.ExplainAccessRestrictions(accessRestrictions, shoot)).To(BeEmpty())
+		})
+	})
+
 	Describe("Handling an access restriction message", func() {
 		It("should add and get a handler function from the context", func() {
 			message := &ac.AccessRestrictionMessage{}
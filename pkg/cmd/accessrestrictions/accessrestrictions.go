@@ -0,0 +1,45 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package accessrestrictions
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
+	"github.com/gardener/gardenctl-v2/pkg/flags"
+)
+
+// NewCmdAccessRestrictions returns a new access-restrictions command.
+func NewCmdAccessRestrictions(f util.Factory, ioStreams util.IOStreams) *cobra.Command {
+	o := newOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:   "access-restrictions",
+		Short: "Print the access restrictions of the targeted shoot",
+		Long: `Print the access restrictions of the targeted shoot.
+The access restrictions configured for the garden in the gardenctl configuration are evaluated against the targeted shoot.
+The matching access restrictions are printed without asking for confirmation, which makes this command useful for automation, e.g. before running gardenctl ssh.
+A garden and a shoot must be specified, either from a previously saved target or directly via target flags.`,
+		Example: `# Print the access restrictions of the targeted shoot
+gardenctl access-restrictions
+
+# Print the access restrictions of a shoot in json format
+gardenctl access-restrictions --garden mygarden --project myproject --shoot myshoot -ojson`,
+		Args: cobra.NoArgs,
+		RunE: base.WrapRunE(o, f),
+	}
+
+	o.AddFlags(cmd.Flags())
+	o.RegisterCompletionsForOutputFlag(cmd)
+
+	f.TargetFlags().AddGardenFlag(cmd.Flags())
+	f.TargetFlags().AddProjectFlag(cmd.Flags())
+	f.TargetFlags().AddShootFlag(cmd.Flags())
+	flags.RegisterCompletionFuncsForTargetFlags(cmd, f, ioStreams, cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,203 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package accessrestrictions_test
+
+import (
+	"context"
+	"encoding/json"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	clientgarden "github.com/gardener/gardenctl-v2/internal/client/garden"
+	"github.com/gardener/gardenctl-v2/internal/fake"
+	"github.com/gardener/gardenctl-v2/internal/util"
+	utilmocks "github.com/gardener/gardenctl-v2/internal/util/mocks"
+	"github.com/gardener/gardenctl-v2/pkg/ac"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/accessrestrictions"
+	"github.com/gardener/gardenctl-v2/pkg/config"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+	targetmocks "github.com/gardener/gardenctl-v2/pkg/target/mocks"
+)
+
+var _ = Describe("AccessRestrictions Command", func() {
+	var (
+		ctrl    *gomock.Controller
+		factory *utilmocks.MockFactory
+		manager *targetmocks.MockManager
+		cmd     *cobra.Command
+		streams util.IOStreams
+		out     *util.SafeBytesBuffer
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		factory = utilmocks.NewMockFactory(ctrl)
+		manager = targetmocks.NewMockManager(ctrl)
+		factory.EXPECT().Manager().Return(manager, nil).AnyTimes()
+
+		targetFlags := target.NewTargetFlags("", "", "", "", false)
+		factory.EXPECT().TargetFlags().Return(targetFlags).AnyTimes()
+
+		streams, _, out, _ = util.NewTestIOStreams()
+		cmd = accessrestrictions.NewCmdAccessRestrictions(factory, streams)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Context("when no shoot is targeted", func() {
+		It("should fail", func() {
+			manager.EXPECT().CurrentTarget().Return(target.NewTarget("test", "project", "", ""), nil)
+
+			cmd.SetArgs(nil)
+			Expect(cmd.Execute()).To(MatchError(target.ErrNoShootTargeted))
+		})
+	})
+
+	Context("when a shoot is targeted", func() {
+		var (
+			t       target.Target
+			project *gardencorev1beta1.Project
+			shoot   *gardencorev1beta1.Shoot
+		)
+
+		BeforeEach(func() {
+			factory.EXPECT().Context().Return(context.Background())
+
+			t = target.NewTarget("test", "project", "", "shoot")
+			manager.EXPECT().CurrentTarget().Return(t, nil)
+
+			cfg := &config.Config{
+				Gardens: []config.Garden{
+					{
+						Name: t.GardenName(),
+						AccessRestrictions: []ac.AccessRestriction{
+							{
+								Key: "eu-access-only",
+								Msg: "Do not access from outside the EU",
+								Options: []ac.AccessRestrictionOption{
+									{
+										Key:      "support.gardener.cloud/eu-access-for-cluster-addons",
+										NotifyIf: false,
+										Msg:      "Cluster addons are not restricted",
+									},
+								},
+							},
+							{
+								Key: "other",
+								Msg: "Other restriction",
+							},
+						},
+					},
+				},
+			}
+			manager.EXPECT().Configuration().Return(cfg)
+
+			project = &gardencorev1beta1.Project{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: t.ProjectName(),
+				},
+				Spec: gardencorev1beta1.ProjectSpec{
+					Namespace: ptr.To("garden-" + t.ProjectName()),
+				},
+			}
+
+			shoot = &gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      t.ShootName(),
+					Namespace: *project.Spec.Namespace,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			client := clientgarden.NewClient(
+				nil,
+				fake.NewClientWithObjects(project, shoot),
+				t.GardenName(),
+			)
+			manager.EXPECT().GardenClient(t.GardenName()).Return(client, nil)
+		})
+
+		Context("and the shoot is not restricted", func() {
+			It("should print that no access restriction applies", func() {
+				cmd.SetArgs(nil)
+				Expect(cmd.Execute()).To(Succeed())
+				Expect(out.String()).To(Equal(`garden: test
+restricted: false
+shoot:
+  name: shoot
+  namespace: garden-project
+`))
+			})
+		})
+
+		Context("and the shoot is restricted", func() {
+			BeforeEach(func() {
+				shoot.Spec.AccessRestrictions = []gardencorev1beta1.AccessRestrictionWithOptions{
+					{
+						AccessRestriction: gardencorev1beta1.AccessRestriction{
+							Name: "eu-access-only",
+						},
+						Options: map[string]string{
+							"support.gardener.cloud/eu-access-for-cluster-addons": "false",
+						},
+					},
+				}
+			})
+
+			It("should print the matching access restrictions", func() {
+				cmd.SetArgs([]string{"--output", "json"})
+				Expect(cmd.Execute()).To(Succeed())
+				Expect(out.String()).To(MatchJSON(`{
+  "garden": "test",
+  "shoot": {
+    "name": "shoot",
+    "namespace": "garden-project"
+  },
+  "restricted": true,
+  "accessRestrictions": [
+    {
+      "key": "eu-access-only",
+      "message": "Do not access from outside the EU",
+      "options": [
+        "Cluster addons are not restricted"
+      ]
+    }
+  ]
+}`))
+			})
+
+			It("should explain the matching access restrictions", func() {
+				cmd.SetArgs([]string{"--output", "json", "--explain-access-restrictions"})
+				Expect(cmd.Execute()).To(Succeed())
+
+				var result accessrestrictions.Result
+				Expect(json.Unmarshal([]byte(out.String()), &result)).To(Succeed())
+				Expect(result.Explanations).To(Equal(ac.AccessRestrictionExplanations{{
+					Key:     "eu-access-only",
+					Message: "Do not access from outside the EU",
+					Field:   "spec.accessRestrictions[].name",
+					Value:   "eu-access-only",
+					Options: []ac.AccessRestrictionExplanation{{
+						Key:     "support.gardener.cloud/eu-access-for-cluster-addons",
+						Message: "Cluster addons are not restricted",
+						Field:   "spec.accessRestrictions[name=eu-access-only].options[support.gardener.cloud/eu-access-for-cluster-addons]",
+						Value:   "false",
+					}},
+				}}))
+			})
+		})
+	})
+})
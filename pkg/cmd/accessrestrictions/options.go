@@ -0,0 +1,177 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package accessrestrictions
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	clientgarden "github.com/gardener/gardenctl-v2/internal/client/garden"
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/ac"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
+	"github.com/gardener/gardenctl-v2/pkg/config"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+// options is a struct to support the access-restrictions command.
+type options struct {
+	base.Options
+
+	// CurrentTarget holds the current target configuration
+	CurrentTarget target.Target
+
+	// Garden is the garden config, depending on the current target
+	Garden *config.Garden
+
+	// GardenClient is the client for the garden cluster
+	GardenClient clientgarden.Client
+
+	// ExplainAccessRestrictions adds the shoot fields and values that triggered the matching access restrictions to the result
+	ExplainAccessRestrictions bool
+}
+
+// Shoot represents a shoot cluster.
+type Shoot struct {
+	// Name is the name of the shoot cluster.
+	Name string `json:"name"`
+
+	// Namespace is the namespace within which the shoot exists.
+	Namespace string `json:"namespace"`
+}
+
+// AccessRestriction is an access restriction of the garden configuration that matches the shoot.
+type AccessRestriction struct {
+	// Key is the identifier of the access restriction.
+	Key string `json:"key"`
+
+	// Message is the notification text of the access restriction.
+	Message string `json:"message"`
+
+	// Options are the notification texts of the matching access restriction options.
+	Options []string `json:"options,omitempty"`
+}
+
+// Result is the outcome of evaluating the access restrictions for a shoot.
+type Result struct {
+	// Garden is the name of the garden the shoot belongs to.
+	Garden string `json:"garden"`
+
+	// Shoot is the evaluated shoot cluster.
+	Shoot Shoot `json:"shoot"`
+
+	// Restricted is true if at least one access restriction matches the shoot.
+	Restricted bool `json:"restricted"`
+
+	// AccessRestrictions are the matching access restrictions.
+	AccessRestrictions []AccessRestriction `json:"accessRestrictions,omitempty"`
+
+	// Explanations explain why the access restrictions match, i.e. which shoot fields and values triggered them.
+	// Only set if requested.
+	Explanations ac.AccessRestrictionExplanations `json:"explanations,omitempty"`
+}
+
+// newOptions returns initialized options.
+func newOptions(ioStreams util.IOStreams) *options {
+	return &options{
+		Options: base.Options{
+			IOStreams: ioStreams,
+			Output:    "yaml",
+		},
+	}
+}
+
+// Complete adapts from the command line args to the data required.
+func (o *options) Complete(f util.Factory, _ *cobra.Command, _ []string) error {
+	manager, err := f.Manager()
+	if err != nil {
+		return err
+	}
+
+	currentTarget, err := manager.CurrentTarget()
+	if err != nil {
+		return err
+	}
+
+	if currentTarget.GardenName() == "" {
+		return target.ErrNoGardenTargeted
+	}
+
+	if currentTarget.ShootName() == "" {
+		return target.ErrNoShootTargeted
+	}
+
+	o.CurrentTarget = currentTarget
+
+	garden, err := manager.Configuration().Garden(currentTarget.GardenName())
+	if err != nil {
+		return err
+	}
+
+	o.Garden = garden
+
+	gardenClient, err := manager.GardenClient(currentTarget.GardenName())
+	if err != nil {
+		return err
+	}
+
+	o.GardenClient = gardenClient
+
+	return nil
+}
+
+// AddFlags binds the command options to a given flagset.
+func (o *options) AddFlags(flags *pflag.FlagSet) {
+	o.Options.AddFlags(flags)
+	flags.BoolVar(&o.ExplainAccessRestrictions, "explain-access-restrictions", o.ExplainAccessRestrictions, "Add the shoot field and value that triggered each matching access restriction and option to the output.")
+}
+
+// Validate validates the provided command options.
+func (o *options) Validate() error {
+	if o.Options.Output == "" {
+		return errors.New("output must be 'yaml' or 'json'")
+	}
+
+	return o.Options.Validate()
+}
+
+// Run does the actual work of the command.
+func (o *options) Run(f util.Factory) error {
+	shoot, err := o.GardenClient.FindShoot(f.Context(), o.CurrentTarget.AsListOption())
+	if err != nil {
+		return err
+	}
+
+	result := Result{
+		Garden: o.Garden.Name,
+		Shoot: Shoot{
+			Name:      shoot.Name,
+			Namespace: shoot.Namespace,
+		},
+	}
+
+	// evaluate the access restrictions one by one to keep track of the matching keys
+	for _, accessRestriction := range o.Garden.AccessRestrictions {
+		for _, message := range ac.CheckAccessRestrictions([]ac.AccessRestriction{accessRestriction}, shoot) {
+			result.AccessRestrictions = append(result.AccessRestrictions, AccessRestriction{
+				Key:     accessRestriction.Key,
+				Message: message.Header,
+				Options: message.Items,
+			})
+		}
+	}
+
+	result.Restricted = len(result.AccessRestrictions) > 0
+
+	if o.ExplainAccessRestrictions {
+		result.Explanations = ac.ExplainAccessRestrictions(o.Garden.AccessRestrictions, shoot)
+	}
+
+	return o.PrintObject(result)
+}
@@ -31,6 +31,12 @@ type Runnable interface {
 	Run(util.Factory) error
 }
 
+// ErrorPrinter is implemented by command options that print errors in the selected output format.
+type ErrorPrinter interface {
+	// PrintError prints the error in the selected output format.
+	PrintError(error) error
+}
+
 // Options contains all settings that are used across all commands in gardenctl.
 type Options struct {
 	// IOStreams provides the standard names for iostreams
@@ -38,23 +44,45 @@ type Options struct {
 
 	// Output defines the output format of the version information. Either 'yaml' or 'json'
 	Output string
+
+	// printed is true if an object has already been printed to IOStreams.Out
+	printed bool
 }
 
-var _ Runnable = &Options{}
+var (
+	_ Runnable     = &Options{}
+	_ ErrorPrinter = &Options{}
+)
 
 // WrapRunE creates a cobra RunE function that has access to the factory.
+// If the options implement ErrorPrinter, errors are additionally printed in the selected output format.
 func WrapRunE(o Runnable, f util.Factory) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
-		if err := o.Complete(f, cmd, args); err != nil {
-			return fmt.Errorf("failed to complete command options: %w", err)
+		err := runE(o, f, cmd, args)
+		if err == nil {
+			return nil
 		}
 
-		if err := o.Validate(); err != nil {
-			return err
+		if p, ok := o.(ErrorPrinter); ok {
+			if printErr := p.PrintError(err); printErr != nil {
+				return errors.Join(err, fmt.Errorf("failed to print error: %w", printErr))
+			}
 		}
 
-		return o.Run(f)
+		return err
+	}
+}
+
+func runE(o Runnable, f util.Factory, cmd *cobra.Command, args []string) error {
+	if err := o.Complete(f, cmd, args); err != nil {
+		return fmt.Errorf("failed to complete command options: %w", err)
 	}
+
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	return o.Run(f)
 }
 
 // NewOptions returns initialized Options.
@@ -83,6 +111,8 @@ func (o *Options) AllowedOutputFormats() []string {
 
 // PrintObject prints an object to IOStreams.out, using o.Output to print in the selected output format.
 func (o *Options) PrintObject(obj interface{}) error {
+	o.printed = true
+
 	switch o.Output {
 	case "":
 		if _, ok := obj.(fmt.Stringer); ok {
@@ -118,6 +148,21 @@ func (o *Options) PrintObject(obj interface{}) error {
 	}
 }
 
+// PrintError prints the error as a JSON object to IOStreams.out if the output format is json, so that machine
+// consumers always receive valid JSON. Nothing is printed if an object has already been printed, e.g. a health
+// report describing the failure, as the output would no longer be a single JSON document.
+func (o *Options) PrintError(err error) error {
+	if o.Output != "json" || o.printed {
+		return nil
+	}
+
+	return o.PrintObject(struct {
+		Error string `json:"error"`
+	}{
+		Error: err.Error(),
+	})
+}
+
 // Validate validates the provided options.
 func (o *Options) Validate() error {
 	if o.Output != "" && o.Output != "yaml" && o.Output != "json" {
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package base_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -87,6 +88,43 @@ var _ = Describe("Base Options", func() {
 			})
 		})
 
+		Context("when an error is printed", func() {
+			var err error
+
+			BeforeEach(func() {
+				err = errors.New("something \"quoted\" went wrong")
+			})
+
+			It("should print the error as valid json", func() {
+				options.Output = "json"
+				Expect(options.PrintError(err)).To(Succeed())
+
+				var result map[string]interface{}
+				Expect(json.Unmarshal([]byte(buf.String()), &result)).To(Succeed())
+				Expect(result).To(Equal(map[string]interface{}{"error": err.Error()}))
+			})
+
+			It("should not print the error if an object has already been printed", func() {
+				options.Output = "json"
+				Expect(options.PrintObject(foo)).To(Succeed())
+				Expect(options.PrintError(err)).To(Succeed())
+
+				var result fooType
+				Expect(json.Unmarshal([]byte(buf.String()), &result)).To(Succeed())
+				Expect(result).To(Equal(*foo))
+			})
+
+			DescribeTable("should not print the error for other output formats",
+				func(output string) {
+					options.Output = output
+					Expect(options.PrintError(err)).To(Succeed())
+					Expect(buf.String()).To(BeEmpty())
+				},
+				Entry("without format", ""),
+				Entry("with yaml format", "yaml"),
+			)
+		})
+
 		Context("when the output is yaml", func() {
 			BeforeEach(func() {
 				options.Output = "yaml"
@@ -181,4 +219,51 @@ foo: %s
 			})
 		})
 	})
+
+	Describe("wrapping the run function of options printing errors", func() {
+		var (
+			options *failingOptions
+			out     *util.SafeBytesBuffer
+			errOut  *util.SafeBytesBuffer
+			runE    func(cmd *cobra.Command, args []string) error
+		)
+
+		BeforeEach(func() {
+			var streams util.IOStreams
+			streams, _, out, errOut = util.NewTestIOStreams()
+			options = &failingOptions{
+				Options: *base.NewOptions(streams),
+				err:     errors.New("failed to find shoot"),
+			}
+		})
+
+		JustBeforeEach(func() {
+			runE = base.WrapRunE(options, nil)
+		})
+
+		It("should print the error as json to stdout", func() {
+			options.Output = "json"
+			Expect(runE(&cobra.Command{}, nil)).To(BeIdenticalTo(options.err))
+
+			var result map[string]interface{}
+			Expect(json.Unmarshal([]byte(out.String()), &result)).To(Succeed())
+			Expect(result).To(HaveKeyWithValue("error", "failed to find shoot"))
+			Expect(errOut.String()).To(BeEmpty())
+		})
+
+		It("should not print the error without json output", func() {
+			Expect(runE(&cobra.Command{}, nil)).To(BeIdenticalTo(options.err))
+			Expect(out.String()).To(BeEmpty())
+		})
+	})
 })
+
+// failingOptions are command options whose Run always fails.
+type failingOptions struct {
+	base.Options
+	err error
+}
+
+func (o *failingOptions) Run(util.Factory) error {
+	return o.err
+}
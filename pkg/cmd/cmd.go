@@ -22,7 +22,9 @@ import (
 	controllerruntime "sigs.k8s.io/controller-runtime"
 
 	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/accessrestrictions"
 	cmdconfig "github.com/gardener/gardenctl-v2/pkg/cmd/config"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/dashboard"
 	"github.com/gardener/gardenctl-v2/pkg/cmd/kubeconfig"
 	cmdkubectl "github.com/gardener/gardenctl-v2/pkg/cmd/kubectlenv"
 	cmdprovider "github.com/gardener/gardenctl-v2/pkg/cmd/providerenv"
@@ -89,11 +91,20 @@ the following code to your terminal profile ~/.profile, ~/.bashrc or comparable
 
       if ( !(Test-Path Env:GCTL_SESSION_ID) -and !(Test-Path Env:TERM_SESSION_ID) ) { $Env:GCTL_SESSION_ID = [guid]::NewGuid().ToString() }
 
+The current target can also be defined by the environment variables GCTL_TARGET_GARDEN, GCTL_TARGET_PROJECT,
+GCTL_TARGET_SEED and GCTL_TARGET_SHOOT, e.g. to run commands in CI without persisting a target. Target flags
+take precedence over these environment variables, which in turn take precedence over the target of the session.
+
 Find more information at: https://github.com/gardener/gardenctl-v2/blob/master/README.md
 `,
 		SilenceUsage: true,
 	}
 
+	p := &profiler{}
+	cmd.PersistentPreRunE = func(_ *cobra.Command, _ []string) error {
+		return p.Start()
+	}
+
 	cmd.SetIn(ioStreams.In)
 	cmd.SetOut(ioStreams.Out)
 	cmd.SetErr(ioStreams.ErrOut)
@@ -103,6 +114,13 @@ Find more information at: https://github.com/gardener/gardenctl-v2/blob/master/R
 		initConfig(f)
 	})
 
+	// finalizers also run if the command fails, which ensures that the profiling files are flushed
+	cobra.OnFinalize(func() {
+		if err := p.Stop(); err != nil {
+			fmt.Fprintf(ioStreams.ErrOut, "Error: %v\n", err)
+		}
+	})
+
 	//nolint:staticcheck // TODO use textlogger instead
 	controllerruntime.SetLogger(klogr.NewWithOptions(klogr.WithFormat(klogr.FormatKlog)))
 
@@ -119,6 +137,8 @@ Find more information at: https://github.com/gardener/gardenctl-v2/blob/master/R
 	// the reason the user chose to specify an explicit config file).
 	flags.StringVar(&f.ConfigFile, "config", "", fmt.Sprintf("config file (default is %s)", filepath.Join("~", gardenHomeFolder, configName+"."+configExtension)))
 
+	p.AddFlags(flags)
+
 	// add subcommands
 	cmd.AddCommand(cmdssh.NewCmdSSH(f, cmdssh.NewSSHOptions(ioStreams)))
 	cmd.AddCommand(cmdsshpatch.NewCmdSSHPatch(f, ioStreams))
@@ -126,10 +146,13 @@ Find more information at: https://github.com/gardener/gardenctl-v2/blob/master/R
 	cmd.AddCommand(cmdversion.NewCmdVersion(f, cmdversion.NewVersionOptions(ioStreams)))
 	cmd.AddCommand(cmdconfig.NewCmdConfig(f, ioStreams))
 	cmd.AddCommand(cmdprovider.NewCmdProviderEnv(f, ioStreams))
+	cmd.AddCommand(cmdprovider.NewCmdProviderCredentials(f, ioStreams))
 	cmd.AddCommand(cmdkubectl.NewCmdKubectlEnv(f, ioStreams))
 	cmd.AddCommand(cmdrc.NewCmdRC(f, ioStreams))
 	cmd.AddCommand(kubeconfig.NewCmdKubeconfig(f, ioStreams))
 	cmd.AddCommand(resolve.NewCmdResolve(f, ioStreams))
+	cmd.AddCommand(accessrestrictions.NewCmdAccessRestrictions(f, ioStreams))
+	cmd.AddCommand(dashboard.NewCmdDashboard(f, ioStreams))
 
 	return cmd
 }
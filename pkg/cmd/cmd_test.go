@@ -76,6 +76,35 @@ var _ = Describe("Gardenctl command", func() {
 				Expect(current.ShootName()).To(Equal(shootName))
 			})
 		})
+
+		Context("when profiling the command execution", func() {
+			var cpuProfile, traceFile string
+
+			BeforeEach(func() {
+				dir := GinkgoT().TempDir()
+				cpuProfile = filepath.Join(dir, "cpu.pprof")
+				traceFile = filepath.Join(dir, "trace.out")
+			})
+
+			It("should write the CPU profile and the trace", func() {
+				cmd := cmd.NewGardenctlCommand(factory, streams)
+				cmd.SetArgs([]string{"version", "--cpu-profile", cpuProfile, "--trace", traceFile})
+				Expect(cmd.Execute()).To(Succeed())
+
+				Expect(cpuProfile).To(BeARegularFile())
+				Expect(os.Stat(cpuProfile)).To(HaveField("Size()", BeNumerically(">", 0)))
+				Expect(os.Stat(traceFile)).To(HaveField("Size()", BeNumerically(">", 0)))
+			})
+
+			It("should flush the CPU profile if the command fails", func() {
+				cmd := cmd.NewGardenctlCommand(factory, streams)
+				cmd.SetArgs([]string{"version", "--output", "invalid", "--cpu-profile", cpuProfile})
+				cmd.SetErr(out)
+				Expect(cmd.Execute()).NotTo(Succeed())
+
+				Expect(os.Stat(cpuProfile)).To(HaveField("Size()", BeNumerically(">", 0)))
+			})
+		})
 	})
 
 	Context("when running the help command", func() {
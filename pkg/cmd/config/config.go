@@ -30,8 +30,13 @@ The loading order follows these rules:
 	}
 
 	cmd.AddCommand(NewCmdConfigView(f, ioStreams))
+	cmd.AddCommand(NewCmdConfigCurrent(f, ioStreams))
 	cmd.AddCommand(NewCmdConfigSetGarden(f, ioStreams))
 	cmd.AddCommand(NewCmdConfigDeleteGarden(f, ioStreams))
+	cmd.AddCommand(NewCmdConfigSetAccessRestriction(f, ioStreams))
+	cmd.AddCommand(NewCmdConfigDeleteAccessRestriction(f, ioStreams))
+	cmd.AddCommand(NewCmdConfigSetDefaultShell(f, ioStreams))
+	cmd.AddCommand(NewCmdConfigMigrate(f, ioStreams))
 
 	return cmd
 }
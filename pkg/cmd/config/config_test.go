@@ -26,13 +26,13 @@ var _ = Describe("Config Command", func() {
 			cmd = cmdconfig.NewCmdConfig(factory, streams)
 		})
 
-		It("should have 3 subcommands", func() {
+		It("should have 8 subcommands", func() {
 			Expect(cmd.Use).To(Equal("config"))
 			subCommands := []string{}
 			for _, c := range cmd.Commands() {
 				subCommands = append(subCommands, c.Name())
 			}
-			Expect(subCommands).To(Equal([]string{"delete-garden", "set-garden", "view"}))
+			Expect(subCommands).To(Equal([]string{"current", "delete-access-restriction", "delete-garden", "migrate", "set-access-restriction", "set-default-shell", "set-garden", "view"}))
 		})
 
 		Describe("Execute Subcommands", func() {
@@ -0,0 +1,115 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+// NewCmdConfigCurrent returns a new (config) current command.
+func NewCmdConfigCurrent(f util.Factory, ioStreams util.IOStreams) *cobra.Command {
+	o := &currentOptions{
+		Options: base.Options{
+			IOStreams: ioStreams,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:   "current",
+		Short: "Print the configuration of the targeted garden",
+		Long:  "Print the name and the kubeconfig path of the targeted garden and whether the kubeconfig of the current target is linked to a session stable symlink",
+		Example: `# print the configuration of the targeted garden
+gardenctl config current
+
+# print the configuration of the targeted garden in json format
+gardenctl config current --output json`,
+		Args: cobra.NoArgs,
+		RunE: base.WrapRunE(o, f),
+	}
+
+	o.AddFlags(cmd.Flags())
+	o.RegisterCompletionsForOutputFlag(cmd)
+
+	return cmd
+}
+
+// CurrentGarden is the configuration of the targeted garden.
+type CurrentGarden struct {
+	// Garden is the name of the targeted garden
+	Garden string `json:"garden"`
+	// Kubeconfig is the path of the kubeconfig of the garden cluster
+	Kubeconfig string `json:"kubeconfig"`
+	// Context overrides the current-context of the garden cluster kubeconfig
+	Context string `json:"context,omitempty"`
+	// LinkKubeconfig is true if the kubeconfig of the current target is linked to a session stable symlink
+	LinkKubeconfig bool `json:"linkKubeconfig"`
+}
+
+type currentOptions struct {
+	base.Options
+	// Current is the configuration of the targeted garden
+	Current *CurrentGarden
+}
+
+// Complete adapts from the command line args to the data required.
+func (o *currentOptions) Complete(f util.Factory, _ *cobra.Command, _ []string) error {
+	config, err := getConfiguration(f)
+	if err != nil {
+		return err
+	}
+
+	manager, err := f.Manager()
+	if err != nil {
+		return err
+	}
+
+	currentTarget, err := manager.CurrentTarget()
+	if err != nil {
+		return err
+	}
+
+	if currentTarget.GardenName() == "" {
+		return target.ErrNoGardenTargeted
+	}
+
+	garden, err := config.Garden(currentTarget.GardenName())
+	if err != nil {
+		return err
+	}
+
+	o.Current = &CurrentGarden{
+		Garden:         garden.Name,
+		Kubeconfig:     garden.Kubeconfig,
+		Context:        garden.Context,
+		LinkKubeconfig: config.SymlinkTargetKubeconfig(),
+	}
+
+	return nil
+}
+
+// Run executes the command.
+func (o *currentOptions) Run(_ util.Factory) error {
+	if o.Output != "" {
+		return o.PrintObject(o.Current)
+	}
+
+	fmt.Fprintf(o.IOStreams.Out, "Garden: %s\n", o.Current.Garden)
+	fmt.Fprintf(o.IOStreams.Out, "Kubeconfig: %s\n", o.Current.Kubeconfig)
+
+	if o.Current.Context != "" {
+		fmt.Fprintf(o.IOStreams.Out, "Context: %s\n", o.Current.Context)
+	}
+
+	fmt.Fprintf(o.IOStreams.Out, "Link kubeconfig: %t\n", o.Current.LinkKubeconfig)
+
+	return nil
+}
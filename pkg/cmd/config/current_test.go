@@ -0,0 +1,67 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"k8s.io/utils/ptr"
+
+	cmdconfig "github.com/gardener/gardenctl-v2/pkg/cmd/config"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+var _ = Describe("Config Subcommand Current", func() {
+	var cmd *cobra.Command
+
+	BeforeEach(func() {
+		cmd = cmdconfig.NewCmdConfigCurrent(factory, streams)
+		cmd.SetOut(errOut)
+		cmd.SetErr(errOut)
+
+		factory.EXPECT().Manager().Return(manager, nil).AnyTimes()
+		manager.EXPECT().Configuration().Return(cfg).AnyTimes()
+	})
+
+	It("should have Use and Flags", func() {
+		Expect(cmd.Use).To(Equal("current"))
+		assertAllFlagNames(cmd.Flags(), "output")
+	})
+
+	It("should print the targeted garden", func() {
+		manager.EXPECT().CurrentTarget().Return(target.NewTarget(gardenIdentity1, "", "", ""), nil)
+
+		cmd.SetArgs([]string{})
+		Expect(cmd.Execute()).To(Succeed())
+		Expect(out.String()).To(Equal(`Garden: fooGarden
+Kubeconfig: not/a/file
+Context: my-context
+Link kubeconfig: false
+`))
+	})
+
+	It("should print the targeted garden in json format", func() {
+		cfg.LinkKubeconfig = ptr.To(true)
+		manager.EXPECT().CurrentTarget().Return(target.NewTarget(gardenIdentity2, "project", "", "shoot"), nil)
+
+		cmd.SetArgs([]string{"--output", "json"})
+		Expect(cmd.Execute()).To(Succeed())
+		Expect(out.String()).To(MatchJSON(`{
+  "garden": "barGarden",
+  "kubeconfig": "not/a/file",
+  "linkKubeconfig": true
+}`))
+	})
+
+	It("should fail if no garden is targeted", func() {
+		manager.EXPECT().CurrentTarget().Return(target.NewTarget("", "", "", ""), nil)
+
+		cmd.SetArgs([]string{})
+		Expect(cmd.Execute()).To(MatchError(ContainSubstring(target.ErrNoGardenTargeted.Error())))
+	})
+})
@@ -0,0 +1,116 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
+	"github.com/gardener/gardenctl-v2/pkg/config"
+)
+
+// NewCmdConfigDeleteAccessRestriction returns a new (config) delete-access-restriction command.
+func NewCmdConfigDeleteAccessRestriction(f util.Factory, ioStreams util.IOStreams) *cobra.Command {
+	o := &deleteAccessRestrictionOptions{
+		Options: base.Options{
+			IOStreams: ioStreams,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:   "delete-access-restriction",
+		Short: "Delete an access restriction of a Garden from the gardenctl configuration",
+		Example: `# delete the access restriction eu-access-only of my-garden
+gardenctl config delete-access-restriction --garden my-garden --key eu-access-only`,
+		Args: cobra.NoArgs,
+		RunE: base.WrapRunE(o, f),
+	}
+
+	o.AddFlags(cmd.Flags())
+	registerGardenFlagCompletionFunc(cmd, f, ioStreams)
+
+	return cmd
+}
+
+type deleteAccessRestrictionOptions struct {
+	base.Options
+	// Configuration is the gardenctl configuration
+	Configuration *config.Config
+	// Garden is the name or alias of the Garden
+	Garden string
+	// Key is the identifier of the access restriction
+	Key string
+}
+
+// Complete adapts from the command line args to the data required.
+func (o *deleteAccessRestrictionOptions) Complete(f util.Factory, _ *cobra.Command, _ []string) error {
+	config, err := getConfiguration(f)
+	if err != nil {
+		return err
+	}
+
+	o.Configuration = config
+	o.Garden = strings.TrimSpace(o.Garden)
+	o.Key = strings.TrimSpace(o.Key)
+
+	return nil
+}
+
+// Validate validates the provided options.
+func (o *deleteAccessRestrictionOptions) Validate() error {
+	if o.Garden == "" {
+		return errors.New("garden identity is required")
+	}
+
+	if o.Key == "" {
+		return errors.New("access restriction key is required")
+	}
+
+	return nil
+}
+
+// AddFlags adds flags to adjust the output to a cobra command.
+func (o *deleteAccessRestrictionOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.Garden, "garden", "", "name or alias of the Garden")
+	flags.StringVar(&o.Key, "key", "", "key of the access restriction to delete")
+}
+
+// Run executes the command.
+func (o *deleteAccessRestrictionOptions) Run(_ util.Factory) error {
+	garden, err := o.Configuration.Garden(o.Garden)
+	if err != nil {
+		return err
+	}
+
+	i := -1
+
+	for j, accessRestriction := range garden.AccessRestrictions {
+		if accessRestriction.Key == o.Key {
+			i = j
+			break
+		}
+	}
+
+	if i < 0 {
+		return fmt.Errorf("access restriction %q is not defined for garden %q", o.Key, garden.Name)
+	}
+
+	garden.AccessRestrictions = append(garden.AccessRestrictions[:i], garden.AccessRestrictions[i+1:]...)
+
+	if err := o.Configuration.Save(); err != nil {
+		return fmt.Errorf("failed to delete access restriction from configuration: %w", err)
+	}
+
+	fmt.Fprintf(o.IOStreams.Out, "Successfully deleted access restriction %q of garden %q\n", o.Key, garden.Name)
+
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	"github.com/spf13/cobra"
+
+	"github.com/gardener/gardenctl-v2/pkg/ac"
+	cmdconfig "github.com/gardener/gardenctl-v2/pkg/cmd/config"
+)
+
+var _ = Describe("Config Subcommand DeleteAccessRestriction", func() {
+	Describe("Instance", func() {
+		var cmd *cobra.Command
+
+		BeforeEach(func() {
+			cmd = cmdconfig.NewCmdConfigDeleteAccessRestriction(factory, streams)
+		})
+
+		It("should have Use and Flags", func() {
+			Expect(cmd.Use).To(Equal("delete-access-restriction"))
+			Expect(cmd.ValidArgsFunction).To(BeNil())
+			assertAllFlagNames(cmd.Flags(), "garden", "key")
+		})
+	})
+
+	Describe("Options", func() {
+		var options *cmdconfig.DeleteAccessRestrictionOptions
+
+		BeforeEach(func() {
+			options = cmdconfig.NewDeleteAccessRestrictionOptions()
+			options.IOStreams = streams
+		})
+
+		Describe("Complete", func() {
+			Context("when getting configuration fails", func() {
+				It("should fail", func() {
+					factory.EXPECT().Manager().Return(manager, nil)
+					manager.EXPECT().Configuration().Return(nil)
+					Expect(options.Complete(factory, nil, nil)).To(MatchError("failed to get configuration"))
+				})
+			})
+
+			Context("when getting configuration succeeds", func() {
+				It("should succeed", func() {
+					factory.EXPECT().Manager().Return(manager, nil)
+					manager.EXPECT().Configuration().Return(cfg)
+					options.Garden = " garden "
+					options.Key = " key "
+					Expect(options.Complete(factory, nil, nil)).To(Succeed())
+					Expect(options.Configuration).To(BeIdenticalTo(cfg))
+					Expect(options.Garden).To(Equal("garden"))
+					Expect(options.Key).To(Equal("key"))
+				})
+			})
+		})
+
+		Describe("Validate", func() {
+			DescribeTable("Validating Flags",
+				func(garden, key string, matcher types.GomegaMatcher) {
+					o := cmdconfig.NewDeleteAccessRestrictionOptions()
+					o.Garden = garden
+					o.Key = key
+					Expect(o.Validate()).To(matcher)
+				},
+				Entry("when all flags are set", "foo", "key", Succeed()),
+				Entry("when garden is empty", "", "key", MatchError("garden identity is required")),
+				Entry("when key is empty", "foo", "", MatchError("access restriction key is required")),
+			)
+		})
+
+		Describe("Run", func() {
+			BeforeEach(func() {
+				cfg.Gardens[0].AccessRestrictions = []ac.AccessRestriction{
+					{Key: "foo", Msg: "foo"},
+					{Key: "bar", Msg: "bar"},
+				}
+				options.Configuration = cfg
+				options.Garden = gardenIdentity1
+			})
+
+			It("should delete the access restriction from configuration", func() {
+				options.Key = "foo"
+				Expect(options.Run(nil)).To(Succeed())
+
+				garden, err := cfg.Garden(gardenIdentity1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(garden.AccessRestrictions).To(Equal([]ac.AccessRestriction{
+					{Key: "bar", Msg: "bar"},
+				}))
+				assertConfigHasBeenSaved(cfg)
+				Expect(out.String()).To(MatchRegexp("^Successfully deleted access restriction"))
+			})
+
+			It("should fail when the access restriction does not exist", func() {
+				options.Key = "baz"
+				Expect(options.Run(nil)).To(MatchError(`access restriction "baz" is not defined for garden "fooGarden"`))
+			})
+
+			It("should fail when the garden does not exist", func() {
+				options.Garden = gardenIdentity3
+				options.Key = "foo"
+				Expect(options.Run(nil)).To(MatchError(MatchRegexp(`^garden ".*" is not defined`)))
+			})
+
+			It("should fail when the filename is invalid", func() {
+				options.Key = "foo"
+				options.Configuration.Filename = string([]byte{0})
+				Expect(options.Run(nil)).To(MatchError(MatchRegexp("^failed to delete access restriction")))
+			})
+		})
+	})
+})
@@ -0,0 +1,189 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/config"
+)
+
+// editGardenHeader is prepended to the garden configuration opened in the editor.
+const editGardenHeader = `# Please edit the configuration of the garden below. Lines beginning with a '#' will be ignored,
+# and an empty file will abort the edit. If an error occurs while saving this file will be
+# reopened with the relevant failures.
+#
+`
+
+// launchEditor opens the given file in the editor of the user and waits until the editor is closed.
+var launchEditor = func(filename string, ioStreams util.IOStreams) error {
+	args := strings.Fields(editorCommand())
+	args = append(args, filename)
+
+	cmd := exec.Command(args[0], args[1:]...) // #nosec G204 -- The editor is configured by the user
+	cmd.Stdin = ioStreams.In
+	cmd.Stdout = ioStreams.Out
+	cmd.Stderr = ioStreams.ErrOut
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to launch editor %q: %w", args[0], err)
+	}
+
+	return nil
+}
+
+// editorCommand returns the editor configured by the EDITOR environment variable or the default editor of the platform.
+func editorCommand() string {
+	if editor := strings.TrimSpace(os.Getenv("EDITOR")); editor != "" {
+		return editor
+	}
+
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+
+	return "vi"
+}
+
+// editGarden opens the configuration of the garden in the editor and replaces it with the edited configuration.
+// An invalid configuration is reopened in the editor together with the error. The edit is aborted with this error
+// if the file is saved without changes.
+func (o *setGardenOptions) editGarden() error {
+	i, ok := o.Configuration.IndexOfGarden(o.Name)
+	if !ok {
+		return fmt.Errorf("garden %q is not defined in gardenctl configuration", o.Name)
+	}
+
+	original, err := yaml.Marshal(o.Configuration.Gardens[i])
+	if err != nil {
+		return fmt.Errorf("failed to encode garden %q: %w", o.Name, err)
+	}
+
+	file, err := os.CreateTemp("", "gardenctl-garden-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+
+	filename := file.Name()
+	defer os.Remove(filename)
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+
+	content := append([]byte(editGardenHeader), original...)
+
+	var editErr error
+
+	for {
+		if err := os.WriteFile(filename, content, 0o600); err != nil {
+			return fmt.Errorf("failed to write temporary file: %w", err)
+		}
+
+		if err := launchEditor(filename, o.IOStreams); err != nil {
+			return err
+		}
+
+		edited, err := os.ReadFile(filename) // #nosec G304 -- The file has been created by gardenctl
+		if err != nil {
+			return fmt.Errorf("failed to read temporary file: %w", err)
+		}
+
+		if bytes.Equal(edited, content) && editErr != nil {
+			return editErr
+		}
+
+		edited = stripComments(edited)
+		if len(bytes.TrimSpace(edited)) == 0 || bytes.Equal(edited, original) {
+			fmt.Fprintln(o.IOStreams.Out, "Edit cancelled, no changes made.")
+			return nil
+		}
+
+		garden, err := o.parseEditedGarden(edited)
+		if err == nil {
+			o.Configuration.Gardens[i] = *garden
+			break
+		}
+
+		editErr = fmt.Errorf("the edited configuration of garden %q is invalid: %w", o.Name, err)
+		content = append([]byte(editGardenHeader+commentLines(editErr.Error())+"#\n"), edited...)
+	}
+
+	if err := o.Configuration.Save(); err != nil {
+		return fmt.Errorf("failed to configure garden: %w", err)
+	}
+
+	fmt.Fprintf(o.IOStreams.Out, "Successfully configured garden %q\n", o.Name)
+
+	return nil
+}
+
+// parseEditedGarden decodes and validates the edited configuration of the garden.
+func (o *setGardenOptions) parseEditedGarden(data []byte) (*config.Garden, error) {
+	garden := &config.Garden{}
+	if err := yaml.UnmarshalStrict(data, garden); err != nil {
+		return nil, err
+	}
+
+	if garden.Name != o.Name {
+		return nil, fmt.Errorf("the identity must not be changed from %q to %q", o.Name, garden.Name)
+	}
+
+	if garden.Alias != "" {
+		for _, g := range o.Configuration.Gardens {
+			if g.Name != o.Name && (g.Name == garden.Alias || g.Alias == garden.Alias) {
+				return nil, fmt.Errorf("the alias %q is already used by garden %q", garden.Alias, g.Name)
+			}
+		}
+	}
+
+	if err := validatePatterns(garden.Patterns); err != nil {
+		return nil, err
+	}
+
+	if len(garden.Patterns) == 0 {
+		garden.Patterns = nil
+	}
+
+	if len(garden.AccessRestrictions) == 0 {
+		garden.AccessRestrictions = nil
+	}
+
+	return garden, nil
+}
+
+// stripComments removes the lines beginning with a '#' from the given data.
+func stripComments(data []byte) []byte {
+	var buf bytes.Buffer
+
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			buf.WriteString(line)
+		}
+	}
+
+	return buf.Bytes()
+}
+
+// commentLines prefixes each line of the given message with a '#'.
+func commentLines(message string) string {
+	var sb strings.Builder
+
+	for _, line := range strings.Split(message, "\n") {
+		sb.WriteString("# " + line + "\n")
+	}
+
+	return sb.String()
+}
@@ -7,16 +7,22 @@ SPDX-License-Identifier: Apache-2.0
 package config
 
 import (
+	"github.com/gardener/gardenctl-v2/internal/util"
 	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
 )
 
 var (
 	ValidGardenArgsFunctionWrapper = validGardenArgsFunctionWrapper
 	ValidatePatterns               = validatePatterns
+	LineDiff                       = lineDiff
 )
 
 type CobraValidArgsFunction cobraValidArgsFunction
 
+func SetLaunchEditor(f func(filename string, ioStreams util.IOStreams) error) {
+	launchEditor = f
+}
+
 type ViewOptions struct {
 	viewOptions
 }
@@ -52,3 +58,51 @@ func NewDeleteGardenOptions() *DeleteGardenOptions {
 		},
 	}
 }
+
+type SetAccessRestrictionOptions struct {
+	setAccessRestrictionOptions
+}
+
+func NewSetAccessRestrictionOptions() *SetAccessRestrictionOptions {
+	return &SetAccessRestrictionOptions{
+		setAccessRestrictionOptions: setAccessRestrictionOptions{
+			Options: base.Options{},
+		},
+	}
+}
+
+type DeleteAccessRestrictionOptions struct {
+	deleteAccessRestrictionOptions
+}
+
+func NewDeleteAccessRestrictionOptions() *DeleteAccessRestrictionOptions {
+	return &DeleteAccessRestrictionOptions{
+		deleteAccessRestrictionOptions: deleteAccessRestrictionOptions{
+			Options: base.Options{},
+		},
+	}
+}
+
+type SetDefaultShellOptions struct {
+	setDefaultShellOptions
+}
+
+func NewSetDefaultShellOptions() *SetDefaultShellOptions {
+	return &SetDefaultShellOptions{
+		setDefaultShellOptions: setDefaultShellOptions{
+			Options: base.Options{},
+		},
+	}
+}
+
+type MigrateOptions struct {
+	migrateOptions
+}
+
+func NewMigrateOptions() *MigrateOptions {
+	return &MigrateOptions{
+		migrateOptions: migrateOptions{
+			Options: base.Options{},
+		},
+	}
+}
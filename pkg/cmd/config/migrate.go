@@ -0,0 +1,147 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
+	"github.com/gardener/gardenctl-v2/pkg/config"
+)
+
+// NewCmdConfigMigrate returns a new (config) migrate command.
+func NewCmdConfigMigrate(f util.Factory, ioStreams util.IOStreams) *cobra.Command {
+	o := &migrateOptions{
+		Options: base.Options{
+			IOStreams: ioStreams,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the gardenctl configuration file to the current format",
+		Long: `Migrate the gardenctl configuration file to the current format.
+Legacy fields are renamed, unknown fields are removed and the configuration is validated before it is written.
+The changes are printed as a diff. Comments are not preserved, so the original file is backed up next to it
+with the suffix .bak.`,
+		Example: `# migrate the current configuration file
+gardenctl config migrate`,
+		Args: cobra.NoArgs,
+		RunE: base.WrapRunE(o, f),
+	}
+
+	return cmd
+}
+
+type migrateOptions struct {
+	base.Options
+	// Filename is the name of the gardenctl configuration file
+	Filename string
+}
+
+// Complete adapts from the command line args to the data required.
+func (o *migrateOptions) Complete(f util.Factory, _ *cobra.Command, _ []string) error {
+	config, err := getConfiguration(f)
+	if err != nil {
+		return err
+	}
+
+	o.Filename = config.Filename
+
+	return nil
+}
+
+// Run executes the command.
+func (o *migrateOptions) Run(_ util.Factory) error {
+	data, err := os.ReadFile(o.Filename)
+	if err != nil {
+		return fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	migrated, dropped, err := config.Migrate(data)
+	if err != nil {
+		return fmt.Errorf("failed to migrate configuration: %w", err)
+	}
+
+	if bytes.Equal(data, migrated) {
+		fmt.Fprintf(o.IOStreams.Out, "Configuration file %q is up to date\n", o.Filename)
+		return nil
+	}
+
+	fmt.Fprint(o.IOStreams.Out, lineDiff(string(data), string(migrated)))
+
+	for _, field := range dropped {
+		fmt.Fprintf(o.IOStreams.ErrOut, "WARNING: the unknown field %q is removed\n", field)
+	}
+
+	if bytes.Contains(data, []byte("#")) {
+		fmt.Fprintln(o.IOStreams.ErrOut, "WARNING: comments are not preserved")
+	}
+
+	backupFilename := o.Filename + ".bak"
+	if err := util.WritePrivateFile(backupFilename, data); err != nil {
+		return fmt.Errorf("failed to back up configuration file: %w", err)
+	}
+
+	fmt.Fprintf(o.IOStreams.Out, "Backed up the original configuration file to %q\n", backupFilename)
+
+	if err := util.WritePrivateFile(o.Filename, migrated); err != nil {
+		return fmt.Errorf("failed to write configuration file: %w", err)
+	}
+
+	fmt.Fprintf(o.IOStreams.Out, "Successfully migrated configuration file %q\n", o.Filename)
+
+	return nil
+}
+
+// lineDiff returns the lines of a and b prefixed with "-" if they have been removed,
+// with "+" if they have been added and with " " if they are unchanged.
+func lineDiff(a, b string) string {
+	x := strings.Split(strings.TrimSuffix(a, "\n"), "\n")
+	y := strings.Split(strings.TrimSuffix(b, "\n"), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var buf strings.Builder
+
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			fmt.Fprintf(&buf, " %s\n", x[i])
+			i++
+			j++
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(&buf, "-%s\n", x[i])
+			i++
+		default:
+			fmt.Fprintf(&buf, "+%s\n", y[j])
+			j++
+		}
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,118 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	cmdconfig "github.com/gardener/gardenctl-v2/pkg/cmd/config"
+	"github.com/gardener/gardenctl-v2/pkg/config"
+)
+
+var _ = Describe("Config Subcommand Migrate", func() {
+	Describe("Instance", func() {
+		var cmd *cobra.Command
+
+		BeforeEach(func() {
+			cmd = cmdconfig.NewCmdConfigMigrate(factory, streams)
+		})
+
+		It("should have Use and no Flags", func() {
+			Expect(cmd.Use).To(Equal("migrate"))
+			assertAllFlagNames(cmd.Flags())
+		})
+	})
+
+	Describe("Options", func() {
+		var options *cmdconfig.MigrateOptions
+
+		BeforeEach(func() {
+			options = cmdconfig.NewMigrateOptions()
+			options.IOStreams = streams
+		})
+
+		Describe("Complete", func() {
+			It("should use the filename of the configuration", func() {
+				factory.EXPECT().Manager().Return(manager, nil)
+				manager.EXPECT().Configuration().Return(cfg)
+				Expect(options.Complete(factory, nil, nil)).To(Succeed())
+				Expect(options.Filename).To(Equal(cfg.Filename))
+			})
+		})
+
+		Describe("Run", func() {
+			BeforeEach(func() {
+				options.Filename = cfg.Filename
+				DeferCleanup(os.Remove, cfg.Filename)
+				DeferCleanup(os.RemoveAll, cfg.Filename+".bak")
+			})
+
+			It("should migrate a minimal old-format configuration", func() {
+				Expect(os.WriteFile(cfg.Filename, []byte("gardenClusters:\n- name: fooGarden\n  kubeConfig: not/a/file\n"), 0o600)).To(Succeed())
+
+				Expect(options.Run(nil)).To(Succeed())
+
+				Expect(out.String()).To(Equal(`-gardenClusters:
+-- name: fooGarden
+-  kubeConfig: not/a/file
++gardens:
++- identity: fooGarden
++  kubeconfig: not/a/file
+Backed up the original configuration file to "` + cfg.Filename + `.bak"
+Successfully migrated configuration file "` + cfg.Filename + `"
+`))
+
+				migrated, err := config.LoadFromFile(cfg.Filename)
+				Expect(err).NotTo(HaveOccurred())
+				assertGardenNames(migrated, gardenIdentity1)
+				assertGarden(migrated, &config.Garden{Name: gardenIdentity1, Kubeconfig: kubeconfig})
+			})
+
+			It("should warn about removed fields and comments and back up the original", func() {
+				data := []byte("# my gardens\ngardens:\n- identity: fooGarden\n  kubeconfig: not/a/file\n  foo: bar\n")
+				Expect(os.WriteFile(cfg.Filename, data, 0o600)).To(Succeed())
+
+				Expect(options.Run(nil)).To(Succeed())
+
+				Expect(errOut.String()).To(Equal(`WARNING: the unknown field "gardens[0].foo" is removed
+WARNING: comments are not preserved
+`))
+				Expect(os.ReadFile(cfg.Filename + ".bak")).To(Equal(data))
+				Expect(os.ReadFile(cfg.Filename)).To(Equal([]byte("gardens:\n- identity: fooGarden\n  kubeconfig: not/a/file\n")))
+			})
+
+			It("should not rewrite a configuration that is up to date", func() {
+				Expect(cfg.Save()).To(Succeed())
+
+				Expect(options.Run(nil)).To(Succeed())
+
+				Expect(out.String()).To(Equal("Configuration file \"" + cfg.Filename + "\" is up to date\n"))
+				assertConfigHasBeenSaved(cfg)
+				Expect(cfg.Filename + ".bak").NotTo(BeAnExistingFile())
+			})
+
+			It("should not write an invalid configuration", func() {
+				data := []byte("gardens:\n- identity: fooGarden\n")
+				Expect(os.WriteFile(cfg.Filename, data, 0o600)).To(Succeed())
+
+				Expect(options.Run(nil)).To(MatchError(`failed to migrate configuration: garden "fooGarden": kubeconfig is required`))
+
+				Expect(os.ReadFile(cfg.Filename)).To(Equal(data))
+			})
+		})
+	})
+
+	Describe("LineDiff", func() {
+		It("should mark removed, added and unchanged lines", func() {
+			Expect(cmdconfig.LineDiff("a\nb\nc\n", "a\nc\nd\n")).To(Equal(" a\n-b\n c\n+d\n"))
+		})
+	})
+})
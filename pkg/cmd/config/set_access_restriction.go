@@ -0,0 +1,143 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/ac"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
+	"github.com/gardener/gardenctl-v2/pkg/config"
+)
+
+// NewCmdConfigSetAccessRestriction returns a new (config) set-access-restriction command.
+func NewCmdConfigSetAccessRestriction(f util.Factory, ioStreams util.IOStreams) *cobra.Command {
+	o := &setAccessRestrictionOptions{
+		Options: base.Options{
+			IOStreams: ioStreams,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:   "set-access-restriction",
+		Short: "Modify or add an access restriction of a Garden in the gardenctl configuration",
+		Long: `Modify or add an access restriction of a Garden in the gardenctl configuration.
+The message of an access restriction is displayed when targeting a shoot with the access restriction of the same key.
+If an access restriction with the given key already exists, its message is updated.`,
+		Example: `# add an access restriction to my-garden
+gardenctl config set-access-restriction --garden my-garden --key eu-access-only --message "Do not access this shoot from outside the EU"`,
+		Args: cobra.NoArgs,
+		RunE: base.WrapRunE(o, f),
+	}
+
+	o.AddFlags(cmd.Flags())
+	registerGardenFlagCompletionFunc(cmd, f, ioStreams)
+
+	return cmd
+}
+
+type setAccessRestrictionOptions struct {
+	base.Options
+	// Configuration is the gardenctl configuration
+	Configuration *config.Config
+	// Garden is the name or alias of the Garden
+	Garden string
+	// Key is the identifier of the access restriction
+	Key string
+	// Message is the notification text of the access restriction
+	Message string
+}
+
+// Complete adapts from the command line args to the data required.
+func (o *setAccessRestrictionOptions) Complete(f util.Factory, _ *cobra.Command, _ []string) error {
+	config, err := getConfiguration(f)
+	if err != nil {
+		return err
+	}
+
+	o.Configuration = config
+	o.Garden = strings.TrimSpace(o.Garden)
+	o.Key = strings.TrimSpace(o.Key)
+
+	return nil
+}
+
+// Validate validates the provided options.
+func (o *setAccessRestrictionOptions) Validate() error {
+	if o.Garden == "" {
+		return errors.New("garden identity is required")
+	}
+
+	if o.Key == "" {
+		return errors.New("access restriction key is required")
+	}
+
+	if strings.TrimSpace(o.Message) == "" {
+		return errors.New("access restriction message is required")
+	}
+
+	return nil
+}
+
+// AddFlags adds flags to adjust the output to a cobra command.
+func (o *setAccessRestrictionOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.Garden, "garden", "", "name or alias of the Garden")
+	flags.StringVar(&o.Key, "key", "", "key of the access restriction, matching the name of the access restriction of a shoot")
+	flags.StringVar(&o.Message, "message", "", "message that is displayed when targeting a shoot with this access restriction")
+}
+
+// Run executes the command.
+func (o *setAccessRestrictionOptions) Run(_ util.Factory) error {
+	garden, err := o.Configuration.Garden(o.Garden)
+	if err != nil {
+		return err
+	}
+
+	updated := false
+
+	for i := range garden.AccessRestrictions {
+		if garden.AccessRestrictions[i].Key == o.Key {
+			garden.AccessRestrictions[i].Msg = o.Message
+			updated = true
+
+			break
+		}
+	}
+
+	if !updated {
+		garden.AccessRestrictions = append(garden.AccessRestrictions, ac.AccessRestriction{
+			Key: o.Key,
+			Msg: o.Message,
+		})
+	}
+
+	if err := o.Configuration.Save(); err != nil {
+		return fmt.Errorf("failed to configure access restriction: %w", err)
+	}
+
+	fmt.Fprintf(o.IOStreams.Out, "Successfully configured access restriction %q of garden %q\n", o.Key, garden.Name)
+
+	return nil
+}
+
+func registerGardenFlagCompletionFunc(cmd *cobra.Command, f util.Factory, ioStreams util.IOStreams) {
+	utilruntime.Must(cmd.RegisterFlagCompletionFunc("garden", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		config, err := getConfiguration(f)
+		if err != nil {
+			fmt.Fprintln(ioStreams.ErrOut, err.Error())
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return util.FilterStringsByPrefix(toComplete, config.GardenNames()), cobra.ShellCompDirectiveNoFileComp
+	}))
+}
@@ -0,0 +1,142 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	"github.com/spf13/cobra"
+
+	"github.com/gardener/gardenctl-v2/pkg/ac"
+	cmdconfig "github.com/gardener/gardenctl-v2/pkg/cmd/config"
+)
+
+var _ = Describe("Config Subcommand SetAccessRestriction", func() {
+	Describe("Instance", func() {
+		var cmd *cobra.Command
+
+		BeforeEach(func() {
+			cmd = cmdconfig.NewCmdConfigSetAccessRestriction(factory, streams)
+		})
+
+		It("should have Use and Flags", func() {
+			Expect(cmd.Use).To(Equal("set-access-restriction"))
+			Expect(cmd.ValidArgsFunction).To(BeNil())
+			assertAllFlagNames(cmd.Flags(), "garden", "key", "message")
+		})
+	})
+
+	Describe("Options", func() {
+		var options *cmdconfig.SetAccessRestrictionOptions
+
+		BeforeEach(func() {
+			options = cmdconfig.NewSetAccessRestrictionOptions()
+			options.IOStreams = streams
+		})
+
+		Describe("Complete", func() {
+			Context("when getting configuration fails", func() {
+				It("should fail", func() {
+					factory.EXPECT().Manager().Return(manager, nil)
+					manager.EXPECT().Configuration().Return(nil)
+					Expect(options.Complete(factory, nil, nil)).To(MatchError("failed to get configuration"))
+				})
+			})
+
+			Context("when getting configuration succeeds", func() {
+				It("should succeed", func() {
+					factory.EXPECT().Manager().Return(manager, nil)
+					manager.EXPECT().Configuration().Return(cfg)
+					options.Garden = " garden "
+					options.Key = " key "
+					Expect(options.Complete(factory, nil, nil)).To(Succeed())
+					Expect(options.Configuration).To(BeIdenticalTo(cfg))
+					Expect(options.Garden).To(Equal("garden"))
+					Expect(options.Key).To(Equal("key"))
+				})
+			})
+		})
+
+		Describe("Validate", func() {
+			DescribeTable("Validating Flags",
+				func(garden, key, message string, matcher types.GomegaMatcher) {
+					o := cmdconfig.NewSetAccessRestrictionOptions()
+					o.Garden = garden
+					o.Key = key
+					o.Message = message
+					Expect(o.Validate()).To(matcher)
+				},
+				Entry("when all flags are set", "foo", "key", "message", Succeed()),
+				Entry("when garden is empty", "", "key", "message", MatchError("garden identity is required")),
+				Entry("when key is empty", "foo", "", "message", MatchError("access restriction key is required")),
+				Entry("when message is empty", "foo", "key", " ", MatchError("access restriction message is required")),
+			)
+		})
+
+		Describe("Run", func() {
+			BeforeEach(func() {
+				options.Configuration = cfg
+				options.Garden = gardenIdentity1
+			})
+
+			It("should add an access restriction", func() {
+				options.Key = "eu-access-only"
+				options.Message = "Do not access from outside the EU"
+				Expect(options.Run(nil)).To(Succeed())
+
+				garden, err := cfg.Garden(gardenIdentity1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(garden.AccessRestrictions).To(Equal([]ac.AccessRestriction{
+					{Key: "eu-access-only", Msg: "Do not access from outside the EU"},
+				}))
+				assertConfigHasBeenSaved(cfg)
+				Expect(out.String()).To(MatchRegexp("^Successfully configured access restriction"))
+			})
+
+			It("should update the message of an existing access restriction", func() {
+				cfg.Gardens[0].AccessRestrictions = []ac.AccessRestriction{
+					{Key: "foo", Msg: "foo"},
+					{
+						Key:     "eu-access-only",
+						Msg:     "old message",
+						Options: []ac.AccessRestrictionOption{{Key: "opt", Msg: "option"}},
+					},
+				}
+				options.Key = "eu-access-only"
+				options.Message = "new message"
+				Expect(options.Run(nil)).To(Succeed())
+
+				garden, err := cfg.Garden(gardenIdentity1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(garden.AccessRestrictions).To(Equal([]ac.AccessRestriction{
+					{Key: "foo", Msg: "foo"},
+					{
+						Key:     "eu-access-only",
+						Msg:     "new message",
+						Options: []ac.AccessRestrictionOption{{Key: "opt", Msg: "option"}},
+					},
+				}))
+				assertConfigHasBeenSaved(cfg)
+			})
+
+			It("should fail when the garden does not exist", func() {
+				options.Garden = gardenIdentity3
+				options.Key = "key"
+				options.Message = "message"
+				Expect(options.Run(nil)).To(MatchError(MatchRegexp(`^garden ".*" is not defined`)))
+			})
+
+			It("should fail when the filename is invalid", func() {
+				options.Key = "key"
+				options.Message = "message"
+				options.Configuration.Filename = string([]byte{0})
+				Expect(options.Run(nil)).To(MatchError(MatchRegexp("^failed to configure access restriction")))
+			})
+		})
+	})
+})
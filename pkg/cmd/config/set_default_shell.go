@@ -0,0 +1,97 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
+	"github.com/gardener/gardenctl-v2/pkg/config"
+	"github.com/gardener/gardenctl-v2/pkg/env"
+)
+
+// NewCmdConfigSetDefaultShell returns a new (config) set-default-shell command.
+func NewCmdConfigSetDefaultShell(f util.Factory, ioStreams util.IOStreams) *cobra.Command {
+	o := &setDefaultShellOptions{
+		Options: base.Options{
+			IOStreams: ioStreams,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:   "set-default-shell",
+		Short: "Set the shell that is used by kubectl-env and provider-env if no shell is given",
+		Long: `Set the shell that is used by the kubectl-env and provider-env commands if they are called without a shell sub-command.
+An explicitly given shell sub-command always takes precedence.`,
+		Example: `# use the bash shell by default
+gardenctl config set-default-shell bash
+
+# print the provider-env script for bash
+gardenctl provider-env`,
+		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			var shells []string
+			for _, s := range env.ValidShells() {
+				shells = append(shells, string(s))
+			}
+
+			return util.FilterStringsByPrefix(toComplete, shells), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: base.WrapRunE(o, f),
+	}
+
+	return cmd
+}
+
+type setDefaultShellOptions struct {
+	base.Options
+	// Configuration is the gardenctl configuration
+	Configuration *config.Config
+	// Shell is the default shell
+	Shell string
+}
+
+// Complete adapts from the command line args to the data required.
+func (o *setDefaultShellOptions) Complete(f util.Factory, _ *cobra.Command, args []string) error {
+	config, err := getConfiguration(f)
+	if err != nil {
+		return err
+	}
+
+	o.Configuration = config
+
+	if len(args) > 0 {
+		o.Shell = strings.TrimSpace(args[0])
+	}
+
+	return nil
+}
+
+// Validate validates the provided options.
+func (o *setDefaultShellOptions) Validate() error {
+	return env.Shell(o.Shell).Validate()
+}
+
+// Run executes the command.
+func (o *setDefaultShellOptions) Run(_ util.Factory) error {
+	o.Configuration.DefaultShell = o.Shell
+
+	if err := o.Configuration.Save(); err != nil {
+		return fmt.Errorf("failed to configure default shell: %w", err)
+	}
+
+	fmt.Fprintf(o.IOStreams.Out, "Successfully configured default shell %q\n", o.Shell)
+
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	cmdconfig "github.com/gardener/gardenctl-v2/pkg/cmd/config"
+)
+
+var _ = Describe("Config Subcommand SetDefaultShell", func() {
+	Describe("Instance", func() {
+		var cmd *cobra.Command
+
+		BeforeEach(func() {
+			cmd = cmdconfig.NewCmdConfigSetDefaultShell(factory, streams)
+		})
+
+		It("should have Use, ValidArgsFunction and no Flags", func() {
+			Expect(cmd.Use).To(Equal("set-default-shell"))
+			Expect(cmd.ValidArgsFunction).NotTo(BeNil())
+			assertAllFlagNames(cmd.Flags())
+		})
+
+		It("should complete the valid shells", func() {
+			values, directive := cmd.ValidArgsFunction(cmd, nil, "p")
+			Expect(values).To(Equal([]string{"powershell"}))
+			Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
+		})
+	})
+
+	Describe("Options", func() {
+		var options *cmdconfig.SetDefaultShellOptions
+
+		BeforeEach(func() {
+			options = cmdconfig.NewSetDefaultShellOptions()
+			options.IOStreams = streams
+		})
+
+		Describe("Complete", func() {
+			It("should complete the shell from the args", func() {
+				factory.EXPECT().Manager().Return(manager, nil)
+				manager.EXPECT().Configuration().Return(cfg)
+				Expect(options.Complete(factory, nil, []string{" zsh "})).To(Succeed())
+				Expect(options.Configuration).To(BeIdenticalTo(cfg))
+				Expect(options.Shell).To(Equal("zsh"))
+			})
+
+			It("should fail when getting the configuration fails", func() {
+				factory.EXPECT().Manager().Return(manager, nil)
+				manager.EXPECT().Configuration().Return(nil)
+				Expect(options.Complete(factory, nil, []string{"zsh"})).To(MatchError("failed to get configuration"))
+			})
+		})
+
+		Describe("Validate", func() {
+			It("should succeed for a valid shell", func() {
+				options.Shell = "fish"
+				Expect(options.Validate()).To(Succeed())
+			})
+
+			It("should fail for an invalid shell", func() {
+				options.Shell = "cmd"
+				Expect(options.Validate()).To(MatchError(MatchRegexp("^invalid shell given, must be one of")))
+			})
+		})
+
+		Describe("Run", func() {
+			BeforeEach(func() {
+				options.Configuration = cfg
+				options.Shell = "zsh"
+			})
+
+			It("should configure the default shell", func() {
+				Expect(options.Run(nil)).To(Succeed())
+				Expect(cfg.DefaultShell).To(Equal("zsh"))
+				assertConfigHasBeenSaved(cfg)
+				Expect(out.String()).To(Equal("Successfully configured default shell \"zsh\"\n"))
+			})
+
+			It("should fail when the filename is invalid", func() {
+				options.Configuration.Filename = string([]byte{0})
+				Expect(options.Run(nil)).To(MatchError(MatchRegexp("^failed to configure default shell")))
+			})
+		})
+	})
+})
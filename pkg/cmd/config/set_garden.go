@@ -9,6 +9,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
 
@@ -42,6 +43,9 @@ export KUBECONFIG=~/path/to/garden-cluster/kubeconfig.yaml
 CLUSTER_IDENTITY=$(kubectl -n kube-system get configmap cluster-identity -ojsonpath={.data.cluster-identity})
 gardenctl config set-garden $CLUSTER_IDENTITY --kubeconfig $KUBECONFIG
 
+# edit the configuration of my-garden in the editor set by the EDITOR environment variable
+gardenctl config set-garden my-garden --edit
+
 # configure my-garden with a context and patterns
 gardenctl config set-garden my-garden --context garden-context --pattern "^(?:landscape-dev/)?shoot--(?P<project>.+)--(?P<shoot>.+)$" --pattern "https://dashboard\.gardener\.cloud/namespace/(?P<namespace>[^/]+)/shoots/(?P<shoot>[^/]+)`,
 		ValidArgsFunction: validGardenArgsFunctionWrapper(f, ioStreams),
@@ -67,11 +71,17 @@ type setGardenOptions struct {
 	// ContextFlag Overrides the current-context of the garden cluster kubeconfig
 	// +optional
 	ContextFlag flag.StringFlag
+	// DashboardURL is the base URL of the Gardener dashboard of this Garden
+	// +optional
+	DashboardURL flag.StringFlag
 	// Patterns is a list of regex patterns that can be defined to use custom input formats for targeting
 	// Use named capturing groups to match target values.
 	// Supported capturing groups: project, namespace, shoot
 	// +optional
 	Patterns []string
+	// Edit opens the configuration of an existing Garden in the editor of the user
+	// +optional
+	Edit bool
 }
 
 // Complete adapts from the command line args to the data required.
@@ -96,6 +106,16 @@ func (o *setGardenOptions) Validate() error {
 		return errors.New("garden identity is required")
 	}
 
+	if o.Edit && (o.KubeconfigFlag.Provided() || o.ContextFlag.Provided() || o.Alias.Provided() || o.DashboardURL.Provided() || o.Patterns != nil) {
+		return errors.New("--edit cannot be combined with --kubeconfig, --context, --alias, --dashboard-url or --pattern")
+	}
+
+	if o.DashboardURL.Value() != "" {
+		if u, err := url.Parse(o.DashboardURL.Value()); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid dashboard URL %q, must be an absolute URL", o.DashboardURL.Value())
+		}
+	}
+
 	return validatePatterns(o.Patterns)
 }
 
@@ -104,15 +124,22 @@ func (o *setGardenOptions) AddFlags(flags *pflag.FlagSet) {
 	flags.Var(&o.KubeconfigFlag, "kubeconfig", "path to kubeconfig file for this Garden cluster")
 	flags.Var(&o.ContextFlag, "context", "override the current-context of the garden cluster kubeconfig")
 	flags.Var(&o.Alias, "alias", "unique alias of this Garden that can be used instead of the name to target this Garden")
+	flags.Var(&o.DashboardURL, "dashboard-url", "base URL of the Gardener dashboard of this Garden, used by the dashboard command")
 	flags.StringArrayVar(&o.Patterns, "pattern", nil, `define regex match patterns for this garden for custom input formats for targeting.
 Use named capturing groups to match target values.
 Supported capturing groups: project, namespace, shoot.
 Note that if you set this flag it will overwrite the pattern list in the config file.
 You may specify any number of extra patterns.`)
+	flags.BoolVar(&o.Edit, "edit", false, `open the configuration of an existing Garden in the editor set by the EDITOR environment variable.
+The edited configuration is validated when the editor is closed and reopened if it is invalid.`)
 }
 
 // Run executes the command.
 func (o *setGardenOptions) Run(_ util.Factory) error {
+	if o.Edit {
+		return o.editGarden()
+	}
+
 	garden, err := o.Configuration.Garden(o.Name)
 	if err == nil {
 		if o.KubeconfigFlag.Provided() {
@@ -127,6 +154,10 @@ func (o *setGardenOptions) Run(_ util.Factory) error {
 			garden.Alias = o.Alias.Value()
 		}
 
+		if o.DashboardURL.Provided() {
+			garden.DashboardURL = o.DashboardURL.Value()
+		}
+
 		if o.Patterns != nil {
 			firstPattern := o.Patterns[0]
 			if len(firstPattern) > 0 {
@@ -137,11 +168,12 @@ func (o *setGardenOptions) Run(_ util.Factory) error {
 		}
 	} else {
 		o.Configuration.Gardens = append(o.Configuration.Gardens, config.Garden{
-			Name:       o.Name,
-			Kubeconfig: o.KubeconfigFlag.Value(),
-			Context:    o.ContextFlag.Value(),
-			Alias:      o.Alias.Value(),
-			Patterns:   o.Patterns,
+			Name:         o.Name,
+			Kubeconfig:   o.KubeconfigFlag.Value(),
+			Context:      o.ContextFlag.Value(),
+			Alias:        o.Alias.Value(),
+			Patterns:     o.Patterns,
+			DashboardURL: o.DashboardURL.Value(),
 		})
 	}
 
@@ -160,11 +192,19 @@ func validatePatterns(patterns []string) error {
 		return nil
 	}
 
+	seen := make(map[string]int, len(patterns))
+
 	for i, p := range patterns {
 		if p == "" {
 			return fmt.Errorf("pattern[%d] must not be empty", i)
 		}
 
+		if j, ok := seen[p]; ok {
+			return fmt.Errorf("pattern[%d] is a duplicate of pattern[%d]", i, j)
+		}
+
+		seen[p] = i
+
 		re, err := regexp.Compile(p)
 		if err != nil {
 			return fmt.Errorf("pattern[%d] is not a valid regular expression: %w", i, err)
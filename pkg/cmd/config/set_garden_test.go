@@ -7,11 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package config_test
 
 import (
+	"fmt"
+	"os"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
 	"github.com/spf13/cobra"
 
+	"github.com/gardener/gardenctl-v2/internal/util"
 	cmdconfig "github.com/gardener/gardenctl-v2/pkg/cmd/config"
 	"github.com/gardener/gardenctl-v2/pkg/config"
 )
@@ -28,7 +32,7 @@ var _ = Describe("Config Subcommand SetGarden", func() {
 			Expect(cmd.Use).To(Equal("set-garden"))
 			Expect(cmd.ValidArgsFunction).NotTo(BeNil())
 			Expect(cmd.ValidArgs).To(BeNil())
-			assertAllFlagNames(cmd.Flags(), "alias", "context", "kubeconfig", "pattern")
+			assertAllFlagNames(cmd.Flags(), "alias", "context", "dashboard-url", "edit", "kubeconfig", "pattern")
 		})
 	})
 
@@ -71,6 +75,26 @@ var _ = Describe("Config Subcommand SetGarden", func() {
 				Entry("when garden empty", "", MatchError("garden identity is required")),
 			)
 
+			It("should fail when edit is combined with a value flag", func() {
+				o := cmdconfig.NewSetGardenOptions()
+				o.Name = "foo"
+				o.Edit = true
+				Expect(o.Validate()).To(Succeed())
+
+				Expect(o.ContextFlag.Set("bar")).To(Succeed())
+				Expect(o.Validate()).To(MatchError("--edit cannot be combined with --kubeconfig, --context, --alias, --dashboard-url or --pattern"))
+			})
+
+			It("should fail when the dashboard URL is not absolute", func() {
+				o := cmdconfig.NewSetGardenOptions()
+				o.Name = "foo"
+				Expect(o.DashboardURL.Set("dashboard.example.com")).To(Succeed())
+				Expect(o.Validate()).To(MatchError(`invalid dashboard URL "dashboard.example.com", must be an absolute URL`))
+
+				Expect(o.DashboardURL.Set("https://dashboard.example.com")).To(Succeed())
+				Expect(o.Validate()).To(Succeed())
+			})
+
 			DescribeTable("Validating Pattern Flag",
 				func(patterns []string, matcher types.GomegaMatcher) {
 					o := cmdconfig.NewSetGardenOptions()
@@ -84,6 +108,7 @@ var _ = Describe("Config Subcommand SetGarden", func() {
 				Entry("when all patterns are valid", []string{"^shoot--(?P<project>.+)--(?P<shoot>.+)$`"}, Succeed()),
 				Entry("when a pattern is not a valid regular expression", []string{"("}, MatchError(MatchRegexp(`^pattern\[0\] is not a valid regular expression`))),
 				Entry("when a pattern has an invalid subexpression name", []string{"^shoot--(?P<cluster>.+)$`"}, MatchError("pattern[0] contains an invalid subexpression \"cluster\"")),
+				Entry("when a pattern is defined twice", []string{"^shoot--(?P<shoot>.+)$", "^namespace:(?P<namespace>.+)$", "^shoot--(?P<shoot>.+)$"}, MatchError("pattern[2] is a duplicate of pattern[0]")),
 			)
 		})
 
@@ -127,6 +152,20 @@ var _ = Describe("Config Subcommand SetGarden", func() {
 				Expect(out.String()).To(MatchRegexp("^Successfully configured garden"))
 			})
 
+			It("should set the dashboard URL of an existing garden configuration", func() {
+				options.Name = gardenIdentity1
+				Expect(options.DashboardURL.Set("https://dashboard.example.com")).To(Succeed())
+				Expect(options.Run(nil)).To(Succeed())
+
+				assertGarden(cfg, &config.Garden{
+					Name:         gardenIdentity1,
+					Kubeconfig:   kubeconfig,
+					Context:      gardenContext1,
+					DashboardURL: "https://dashboard.example.com",
+				})
+				assertConfigHasBeenSaved(cfg)
+			})
+
 			It("should remove all patterns from an existing configuration", func() {
 				options.Name = gardenIdentity2
 				Expect(options.KubeconfigFlag.Set(pathToKubeconfig)).To(Succeed())
@@ -147,5 +186,109 @@ var _ = Describe("Config Subcommand SetGarden", func() {
 				Expect(options.Run(nil)).To(MatchError(MatchRegexp("^failed to configure garden")))
 			})
 		})
+
+		Describe("Run with edit", func() {
+			var (
+				edits   []string
+				opened  []string
+				current *config.Config
+			)
+
+			BeforeEach(func() {
+				options.Configuration = cfg
+				options.Name = gardenIdentity1
+				options.Edit = true
+
+				edits = nil
+				opened = nil
+
+				var err error
+				Expect(cfg.Save()).To(Succeed())
+				current, err = config.LoadFromFile(cfg.Filename)
+				Expect(err).NotTo(HaveOccurred())
+
+				cmdconfig.SetLaunchEditor(func(filename string, _ util.IOStreams) error {
+					content, err := os.ReadFile(filename)
+					Expect(err).NotTo(HaveOccurred())
+					opened = append(opened, string(content))
+
+					Expect(edits).NotTo(BeEmpty(), "editor opened too often")
+					edit := edits[0]
+					edits = edits[1:]
+
+					if edit == "" {
+						return nil
+					}
+
+					return os.WriteFile(filename, []byte(edit), 0o600)
+				})
+			})
+
+			It("should save the edited garden", func() {
+				edits = []string{"identity: " + gardenIdentity1 + "\nkubeconfig: /path/to/kubeconfig\nname: my-alias\npatterns:\n- ^(?P<shoot>.+)$\n"}
+
+				Expect(options.Run(nil)).To(Succeed())
+
+				Expect(opened).To(HaveLen(1))
+				Expect(opened[0]).To(ContainSubstring("context: " + gardenContext1))
+				assertGarden(cfg, &config.Garden{
+					Name:       gardenIdentity1,
+					Alias:      "my-alias",
+					Kubeconfig: "/path/to/kubeconfig",
+					Patterns:   []string{"^(?P<shoot>.+)$"},
+				})
+				assertConfigHasBeenSaved(cfg)
+				Expect(out.String()).To(Equal(fmt.Sprintf("Successfully configured garden %q\n", gardenIdentity1)))
+			})
+
+			It("should reopen an invalid edit with the error", func() {
+				edits = []string{
+					"identity: " + gardenIdentity1 + "\nkubeconfig: [\n",
+					"identity: " + gardenIdentity1 + "\nkubeconfig: /path/to/kubeconfig\n",
+				}
+
+				Expect(options.Run(nil)).To(Succeed())
+
+				Expect(opened).To(HaveLen(2))
+				Expect(opened[1]).To(ContainSubstring(fmt.Sprintf("# the edited configuration of garden %q is invalid", gardenIdentity1)))
+				Expect(opened[1]).To(ContainSubstring("kubeconfig: [\n"))
+				assertGarden(cfg, &config.Garden{
+					Name:       gardenIdentity1,
+					Kubeconfig: "/path/to/kubeconfig",
+				})
+				assertConfigHasBeenSaved(cfg)
+			})
+
+			It("should abort with the error if an invalid edit is not changed", func() {
+				edits = []string{"identity: other\nkubeconfig: /path/to/kubeconfig\n", ""}
+
+				Expect(options.Run(nil)).To(MatchError(fmt.Sprintf("the edited configuration of garden %q is invalid: the identity must not be changed from %q to \"other\"", gardenIdentity1, gardenIdentity1)))
+
+				Expect(opened).To(HaveLen(2))
+				Expect(config.LoadFromFile(cfg.Filename)).To(Equal(current))
+			})
+
+			It("should reject patterns with invalid subexpressions", func() {
+				edits = []string{"identity: " + gardenIdentity1 + "\nkubeconfig: " + kubeconfig + "\npatterns:\n- ^(?P<foo>.+)$\n", ""}
+
+				Expect(options.Run(nil)).To(MatchError(ContainSubstring(`pattern[0] contains an invalid subexpression "foo"`)))
+			})
+
+			It("should cancel the edit if the file is empty", func() {
+				edits = []string{"# nothing\n"}
+
+				Expect(options.Run(nil)).To(Succeed())
+
+				Expect(out.String()).To(Equal("Edit cancelled, no changes made.\n"))
+				Expect(config.LoadFromFile(cfg.Filename)).To(Equal(current))
+			})
+
+			It("should fail if the garden does not exist", func() {
+				options.Name = gardenIdentity3
+
+				Expect(options.Run(nil)).To(MatchError(fmt.Sprintf("garden %q is not defined in gardenctl configuration", gardenIdentity3)))
+				Expect(opened).To(BeEmpty())
+			})
+		})
 	})
 })
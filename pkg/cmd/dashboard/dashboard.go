@@ -0,0 +1,46 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dashboard
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
+	"github.com/gardener/gardenctl-v2/pkg/flags"
+)
+
+// NewCmdDashboard returns a new dashboard command.
+func NewCmdDashboard(f util.Factory, ioStreams util.IOStreams) *cobra.Command {
+	o := newOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Open the Gardener dashboard for the current target",
+		Long: `Open the Gardener dashboard for the current target in the browser.
+The URL of the shoot, or of the shoot list of the project, is constructed from the dashboard URL configured for the garden in the gardenctl configuration.
+A garden must be specified, either from a previously saved target or directly via target flags.`,
+		Example: `# Open the dashboard page of the targeted shoot
+gardenctl dashboard
+
+# Print the dashboard URL of a shoot instead of opening it
+gardenctl dashboard --garden mygarden --project myproject --shoot myshoot --print-only
+
+# Configure the dashboard URL of a garden
+gardenctl config set-garden mygarden --dashboard-url https://dashboard.gardener.cloud`,
+		Args: cobra.NoArgs,
+		RunE: base.WrapRunE(o, f),
+	}
+
+	cmd.Flags().BoolVar(&o.PrintOnly, "print-only", o.PrintOnly, "Print the dashboard URL instead of opening it in the browser.")
+
+	f.TargetFlags().AddGardenFlag(cmd.Flags())
+	f.TargetFlags().AddProjectFlag(cmd.Flags())
+	f.TargetFlags().AddShootFlag(cmd.Flags())
+	flags.RegisterCompletionFuncsForTargetFlags(cmd, f, ioStreams, cmd.Flags())
+
+	return cmd
+}
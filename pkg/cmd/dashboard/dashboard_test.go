@@ -0,0 +1,187 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dashboard_test
+
+import (
+	"context"
+	"errors"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	clientgarden "github.com/gardener/gardenctl-v2/internal/client/garden"
+	"github.com/gardener/gardenctl-v2/internal/fake"
+	"github.com/gardener/gardenctl-v2/internal/util"
+	utilmocks "github.com/gardener/gardenctl-v2/internal/util/mocks"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/dashboard"
+	"github.com/gardener/gardenctl-v2/pkg/config"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+	targetmocks "github.com/gardener/gardenctl-v2/pkg/target/mocks"
+)
+
+var _ = Describe("Dashboard Command", func() {
+	var (
+		ctrl       *gomock.Controller
+		factory    *utilmocks.MockFactory
+		manager    *targetmocks.MockManager
+		cmd        *cobra.Command
+		streams    util.IOStreams
+		out        *util.SafeBytesBuffer
+		cfg        *config.Config
+		openedURLs []string
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		factory = utilmocks.NewMockFactory(ctrl)
+		manager = targetmocks.NewMockManager(ctrl)
+		factory.EXPECT().Manager().Return(manager, nil).AnyTimes()
+		factory.EXPECT().Context().Return(context.Background()).AnyTimes()
+
+		targetFlags := target.NewTargetFlags("", "", "", "", false)
+		factory.EXPECT().TargetFlags().Return(targetFlags).AnyTimes()
+
+		cfg = &config.Config{
+			Gardens: []config.Garden{{
+				Name:         "test",
+				DashboardURL: "https://dashboard.example.com",
+			}},
+		}
+		manager.EXPECT().Configuration().Return(cfg).AnyTimes()
+
+		openedURLs = nil
+		dashboard.SetOpenBrowser(func(rawURL string) error {
+			openedURLs = append(openedURLs, rawURL)
+			return nil
+		})
+
+		streams, _, out, _ = util.NewTestIOStreams()
+		cmd = dashboard.NewCmdDashboard(factory, streams)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Context("when no garden is targeted", func() {
+		It("should fail", func() {
+			manager.EXPECT().CurrentTarget().Return(target.NewTarget("", "", "", ""), nil)
+
+			cmd.SetArgs(nil)
+			Expect(cmd.Execute()).To(MatchError(target.ErrNoGardenTargeted))
+		})
+	})
+
+	Context("when no dashboard URL is configured", func() {
+		It("should fail", func() {
+			cfg.Gardens[0].DashboardURL = ""
+			manager.EXPECT().CurrentTarget().Return(target.NewTarget("test", "", "", ""), nil)
+
+			cmd.SetArgs(nil)
+			Expect(cmd.Execute()).To(MatchError(ContainSubstring(`no dashboard URL configured for garden "test"`)))
+		})
+	})
+
+	Context("when a garden is targeted", func() {
+		BeforeEach(func() {
+			manager.EXPECT().CurrentTarget().Return(target.NewTarget("test", "", "", ""), nil)
+		})
+
+		It("should print the base URL", func() {
+			cmd.SetArgs([]string{"--print-only"})
+			Expect(cmd.Execute()).To(Succeed())
+			Expect(out.String()).To(Equal("https://dashboard.example.com\n"))
+			Expect(openedURLs).To(BeEmpty())
+		})
+	})
+
+	Context("when a project or shoot is targeted", func() {
+		var (
+			project *gardencorev1beta1.Project
+			shoot   *gardencorev1beta1.Shoot
+		)
+
+		BeforeEach(func() {
+			project = &gardencorev1beta1.Project{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "project",
+				},
+				Spec: gardencorev1beta1.ProjectSpec{
+					Namespace: ptr.To("garden-project"),
+				},
+			}
+
+			shoot = &gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "shoot",
+					Namespace: "garden-project",
+				},
+			}
+
+			client := clientgarden.NewClient(nil, fake.NewClientWithObjects(project, shoot), "test")
+			manager.EXPECT().GardenClient("test").Return(client, nil)
+		})
+
+		It("should print the URL of the shoot list of the project", func() {
+			manager.EXPECT().CurrentTarget().Return(target.NewTarget("test", "project", "", ""), nil)
+
+			cmd.SetArgs([]string{"--print-only"})
+			Expect(cmd.Execute()).To(Succeed())
+			Expect(out.String()).To(Equal("https://dashboard.example.com/namespace/garden-project/shoots\n"))
+		})
+
+		It("should print the URL of the shoot", func() {
+			manager.EXPECT().CurrentTarget().Return(target.NewTarget("test", "project", "", "shoot"), nil)
+
+			cmd.SetArgs([]string{"--print-only"})
+			Expect(cmd.Execute()).To(Succeed())
+			Expect(out.String()).To(Equal("https://dashboard.example.com/namespace/garden-project/shoots/shoot\n"))
+			Expect(openedURLs).To(BeEmpty())
+		})
+
+		It("should open the URL of the shoot in the browser", func() {
+			manager.EXPECT().CurrentTarget().Return(target.NewTarget("test", "project", "", "shoot"), nil)
+
+			cmd.SetArgs(nil)
+			Expect(cmd.Execute()).To(Succeed())
+			Expect(openedURLs).To(Equal([]string{"https://dashboard.example.com/namespace/garden-project/shoots/shoot"}))
+			Expect(out.String()).To(BeEmpty())
+		})
+
+		It("should fail if the browser cannot be opened", func() {
+			manager.EXPECT().CurrentTarget().Return(target.NewTarget("test", "project", "", "shoot"), nil)
+			dashboard.SetOpenBrowser(func(_ string) error {
+				return errors.New("no browser")
+			})
+
+			cmd.SetArgs(nil)
+			Expect(cmd.Execute()).To(MatchError(ContainSubstring("no browser")))
+		})
+	})
+})
+
+var _ = Describe("ShootDashboardURL", func() {
+	DescribeTable("constructing the dashboard URL",
+		func(baseURL, namespace, shootName, expected string) {
+			Expect(dashboard.ShootDashboardURL(baseURL, namespace, shootName)).To(Equal(expected))
+		},
+		Entry("garden", "https://dashboard.example.com", "", "", "https://dashboard.example.com"),
+		Entry("project", "https://dashboard.example.com", "garden-project", "", "https://dashboard.example.com/namespace/garden-project/shoots"),
+		Entry("shoot", "https://dashboard.example.com", "garden-project", "shoot", "https://dashboard.example.com/namespace/garden-project/shoots/shoot"),
+		Entry("base URL with path and trailing slash", "https://example.com/dashboard/", "garden-project", "shoot", "https://example.com/dashboard/namespace/garden-project/shoots/shoot"),
+	)
+
+	It("should fail for a relative base URL", func() {
+		_, err := dashboard.ShootDashboardURL("dashboard.example.com", "garden-project", "shoot")
+		Expect(err).To(MatchError(`invalid dashboard URL "dashboard.example.com", must be an absolute URL`))
+	})
+})
@@ -0,0 +1,13 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dashboard
+
+var ShootDashboardURL = shootDashboardURL
+
+func SetOpenBrowser(f func(rawURL string) error) {
+	openBrowser = f
+}
@@ -0,0 +1,184 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+var (
+	// openBrowser opens the given URL in the default browser of the user.
+	openBrowser = func(rawURL string) error {
+		var cmd *exec.Cmd
+
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", rawURL) // #nosec G204 -- The URL is constructed from the gardenctl configuration
+		case "windows":
+			cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", rawURL) // #nosec G204 -- The URL is constructed from the gardenctl configuration
+		default:
+			cmd = exec.Command("xdg-open", rawURL) // #nosec G204 -- The URL is constructed from the gardenctl configuration
+		}
+
+		return cmd.Start()
+	}
+)
+
+// options is a struct to support the dashboard command.
+type options struct {
+	base.Options
+
+	// PrintOnly prints the dashboard URL instead of opening it in the browser
+	PrintOnly bool
+
+	// CurrentTarget holds the current target configuration
+	CurrentTarget target.Target
+
+	// DashboardURL is the base URL of the dashboard of the targeted garden
+	DashboardURL string
+}
+
+// newOptions returns initialized options.
+func newOptions(ioStreams util.IOStreams) *options {
+	return &options{
+		Options: base.Options{
+			IOStreams: ioStreams,
+		},
+	}
+}
+
+// Complete adapts from the command line args to the data required.
+func (o *options) Complete(f util.Factory, _ *cobra.Command, _ []string) error {
+	manager, err := f.Manager()
+	if err != nil {
+		return err
+	}
+
+	currentTarget, err := manager.CurrentTarget()
+	if err != nil {
+		return err
+	}
+
+	if currentTarget.GardenName() == "" {
+		return target.ErrNoGardenTargeted
+	}
+
+	o.CurrentTarget = currentTarget
+
+	garden, err := manager.Configuration().Garden(currentTarget.GardenName())
+	if err != nil {
+		return err
+	}
+
+	if garden.DashboardURL == "" {
+		return fmt.Errorf("no dashboard URL configured for garden %q, run `gardenctl config set-garden %s --dashboard-url <url>` to configure it", garden.Name, garden.Name)
+	}
+
+	o.DashboardURL = garden.DashboardURL
+
+	return nil
+}
+
+// Validate validates the provided command options.
+func (o *options) Validate() error {
+	return nil
+}
+
+// Run does the actual work of the command.
+func (o *options) Run(f util.Factory) error {
+	manager, err := f.Manager()
+	if err != nil {
+		return err
+	}
+
+	namespace, err := targetNamespace(f.Context(), manager, o.CurrentTarget)
+	if err != nil {
+		return err
+	}
+
+	dashboardURL, err := shootDashboardURL(o.DashboardURL, namespace, o.CurrentTarget.ShootName())
+	if err != nil {
+		return err
+	}
+
+	if o.PrintOnly {
+		fmt.Fprintln(o.IOStreams.Out, dashboardURL)
+		return nil
+	}
+
+	if err := openBrowser(dashboardURL); err != nil {
+		return fmt.Errorf("failed to open %s in the browser: %w", dashboardURL, err)
+	}
+
+	fmt.Fprintf(o.IOStreams.ErrOut, "Opened %s in the browser\n", dashboardURL)
+
+	return nil
+}
+
+// targetNamespace returns the namespace of the targeted shoot or project. An empty string is returned
+// if neither a shoot nor a project is targeted.
+func targetNamespace(ctx context.Context, manager target.Manager, t target.Target) (string, error) {
+	if t.ShootName() == "" && t.ProjectName() == "" {
+		return "", nil
+	}
+
+	gardenClient, err := manager.GardenClient(t.GardenName())
+	if err != nil {
+		return "", fmt.Errorf("failed to create garden cluster client: %w", err)
+	}
+
+	if t.ShootName() != "" {
+		shoot, err := gardenClient.FindShoot(ctx, t.AsListOption())
+		if err != nil {
+			return "", err
+		}
+
+		return shoot.Namespace, nil
+	}
+
+	project, err := gardenClient.GetProject(ctx, t.ProjectName())
+	if err != nil {
+		return "", err
+	}
+
+	if project.Spec.Namespace == nil || *project.Spec.Namespace == "" {
+		return "", fmt.Errorf("project %q has not yet been assigned to a namespace", project.Name)
+	}
+
+	return *project.Spec.Namespace, nil
+}
+
+// shootDashboardURL returns the dashboard URL of the shoot in the given namespace. If the shoot name is
+// empty, the URL of the shoot list of the namespace is returned. If the namespace is empty as well,
+// the base URL is returned.
+func shootDashboardURL(baseURL, namespace, shootName string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid dashboard URL %q, must be an absolute URL", baseURL)
+	}
+
+	if namespace == "" {
+		return u.String(), nil
+	}
+
+	elems := []string{"namespace", namespace, "shoots"}
+	if shootName != "" {
+		elems = append(elems, shootName)
+	}
+
+	return u.JoinPath(elems...).String(), nil
+}
@@ -40,7 +40,10 @@ gardenctl kubeconfig --output json
 gardenctl kubeconfig --garden my-garden --project my-project --shoot my-shoot
 
 # Print the Garden cluster kubeconfig of my-garden. The namespace of the project my-project is set as default
-gardenctl kubeconfig --garden my-garden --project my-project`,
+gardenctl kubeconfig --garden my-garden --project my-project
+
+# Print the kubeconfig for the current target with the current context named my-shoot
+gardenctl kubeconfig --context-name my-shoot`,
 		RunE: base.WrapRunE(o, f),
 	}
 
@@ -76,6 +79,8 @@ type options struct {
 
 	// Context holds the name of the kubeconfig context to use
 	Context string
+	// ContextName is the name the current context is renamed to. If empty, the generated name is kept
+	ContextName string
 
 	// RawConfig holds the information needed to build connect to remote kubernetes clusters as a given user
 	RawConfig *clientcmdapi.Config
@@ -97,6 +102,7 @@ func (o *options) AddFlags(flags *pflag.FlagSet) {
 	flags.BoolVar(&o.Flatten, "flatten", o.Flatten, "Flatten the resulting kubeconfig file into self-contained output (useful for creating portable kubeconfig files)")
 	flags.BoolVar(&o.Minify, "minify", o.Minify, "Remove all information not used by current-context from the output")
 	flags.StringVar(&o.Context, "context", o.Context, "The name of the kubeconfig context to use")
+	flags.StringVar(&o.ContextName, "context-name", o.ContextName, "Rename the current context of the printed kubeconfig. If not set, the generated name is kept, e.g. <namespace>--<shoot>-external for a shoot")
 }
 
 // Complete adapts from the command line args to the data required.
@@ -145,11 +151,23 @@ func (o *options) Validate() error {
 		return errors.New("raw Config is required")
 	}
 
+	if o.ContextName != "" {
+		if err := target.ValidateContextName(o.ContextName); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 // Run does the actual work of the command.
 func (o *options) Run(_ util.Factory) error {
+	if o.ContextName != "" {
+		if err := target.RenameCurrentContext(o.RawConfig, o.ContextName); err != nil {
+			return err
+		}
+	}
+
 	if o.Minify {
 		if len(o.Context) > 0 {
 			o.RawConfig.CurrentContext = o.Context
@@ -135,6 +135,12 @@ users: null
 				options.RawConfig = nil
 				Expect(options.Validate()).To(MatchError("raw Config is required"))
 			})
+
+			It("should return an error when the context name is invalid", func() {
+				options.RawConfig = &rawConfig
+				options.ContextName = "my shoot"
+				Expect(options.Validate()).To(MatchError(ContainSubstring("invalid context name \"my shoot\"")))
+			})
 		})
 
 		Describe("running the kubeconfig command with the given options", func() {
@@ -212,6 +218,37 @@ users:
 `))
 				})
 
+				It("should rename the current context", func() {
+					options.RawByteData = true
+					options.ContextName = "my-shoot"
+
+					config = clientcmd.NewDefaultClientConfig(*createTestKubeconfig(), nil)
+					rawConfig, err = config.RawConfig()
+					Expect(err).To(Succeed())
+					options.RawConfig = &rawConfig
+
+					Expect(options.Run(nil)).To(Succeed())
+					Expect(options.String()).To(ContainSubstring(`- context:
+    cluster: cluster
+    namespace: default
+    user: user
+  name: my-shoot
+current-context: my-shoot
+`))
+					Expect(options.String()).To(ContainSubstring("name: context2\n"))
+				})
+
+				It("should fail to rename the current context to an existing context", func() {
+					options.ContextName = "context2"
+
+					config = clientcmd.NewDefaultClientConfig(*createTestKubeconfig(), nil)
+					rawConfig, err = config.RawConfig()
+					Expect(err).To(Succeed())
+					options.RawConfig = &rawConfig
+
+					Expect(options.Run(nil)).To(MatchError(ContainSubstring("a context with this name already exists")))
+				})
+
 				Context("when an error occurs during PrintObject", func() {
 					err := errors.New("error")
 
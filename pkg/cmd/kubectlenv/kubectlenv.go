@@ -11,6 +11,7 @@ import (
 	"runtime"
 
 	"github.com/spf13/cobra"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 
 	"github.com/gardener/gardenctl-v2/internal/util"
 	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
@@ -33,9 +34,16 @@ func NewCmdKubectlEnv(f util.Factory, ioStreams util.IOStreams) *cobra.Command {
 Each sub-command produces a shell-specific script.
 For details on how to use the printed shell script, such as applying it temporarily to your current session or permanently through your shell's startup file, refer to the corresponding sub-command's help.
 `,
+		Example: `# Print the KUBECONFIG environment variable for env files, without shell-specific syntax
+gardenctl kubectl-env --output env`,
 		Aliases: []string{"k-env", "cluster-env"},
+		Args:    cobra.NoArgs,
+		RunE:    runE,
 	}
 	o.AddFlags(cmd.PersistentFlags())
+	utilruntime.Must(cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{outputEnv}, cobra.ShellCompDirectiveNoFileComp
+	}))
 
 	for _, s := range env.ValidShells() {
 		cmd.AddCommand(&cobra.Command{
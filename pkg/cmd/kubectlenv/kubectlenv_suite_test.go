@@ -17,6 +17,8 @@ import (
 	. "github.com/onsi/gomega"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/gardener/gardenctl-v2/pkg/env/testdata"
 )
 
 var (
@@ -51,7 +53,23 @@ func makeTempGardenHomeDir() string {
 	return dir
 }
 
+// The separator in the filename must be a forward slash, even on Windows systems.
+// see https://pkg.go.dev/embed#hdr-Directives
+func readTestFile(filename string) string {
+	data, err := testdata.FS.ReadFile(filename)
+	Expect(err).NotTo(HaveOccurred())
+
+	return string(data)
+}
+
 func writeTempFile(filename string, content string) {
 	err := os.WriteFile(filepath.Join(gardenHomeDir, filename), []byte(content), 0o777)
 	Expect(err).NotTo(HaveOccurred())
 }
+
+func removeTempFile(filename string) {
+	err := os.Remove(filepath.Join(gardenHomeDir, filename))
+	if !os.IsNotExist(err) {
+		Expect(err).NotTo(HaveOccurred())
+	}
+}
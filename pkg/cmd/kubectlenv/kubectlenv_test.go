@@ -54,7 +54,7 @@ var _ = Describe("Env Commands", func() {
 			Expect(cmd.Use).To(Equal("kubectl-env"))
 			Expect(cmd.Aliases).To(HaveLen(2))
 			Expect(cmd.Aliases).To(Equal([]string{"k-env", "cluster-env"}))
-			Expect(cmd.Flag("output")).To(BeNil())
+			Expect(cmd.Flag("output")).NotTo(BeNil())
 			flag := cmd.Flag("unset")
 			Expect(flag).NotTo(BeNil())
 			Expect(flag.Shorthand).To(Equal("u"))
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package kubectlenv
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/gardener/gardenctl-v2/internal/util"
 	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
@@ -21,6 +23,10 @@ import (
 	"github.com/gardener/gardenctl-v2/pkg/target"
 )
 
+// outputEnv is the output format which prints the KUBECONFIG environment variable as a single
+// KUBECONFIG=<path> line without shell-specific syntax, e.g. for env files.
+const outputEnv = "env"
+
 type options struct {
 	base.Options
 
@@ -40,12 +46,23 @@ type options struct {
 	Template env.Template
 	// Symlink indicates if KUBECONFIG environment variable should point to the session stable symlink
 	Symlink bool
+	// LinkKubeconfig is the value of the link-kubeconfig flag. If the flag is set, it takes precedence over
+	// the linkKubeconfig setting of the gardenctl configuration when determining Symlink
+	LinkKubeconfig bool
+	// ContextName is the name the current context of the written kubeconfig is renamed to. If empty, the generated name is kept
+	ContextName string
 }
 
 // Complete adapts from the command line args to the data required.
 func (o *options) Complete(f util.Factory, cmd *cobra.Command, _ []string) error {
-	o.Shell = cmd.Name()
-	o.CmdPath = cmd.Parent().CommandPath()
+	if cmd.HasSubCommands() {
+		// the output format env does not require a shell sub-command
+		o.CmdPath = cmd.CommandPath()
+	} else {
+		o.Shell = cmd.Name()
+		o.CmdPath = cmd.Parent().CommandPath()
+	}
+
 	o.GardenDir = f.GardenHomeDir()
 	o.Template = env.NewTemplate("helpers")
 
@@ -59,7 +76,19 @@ func (o *options) Complete(f util.Factory, cmd *cobra.Command, _ []string) error
 		return err
 	}
 
+	if o.Shell == "" && o.Output == "" {
+		// the configured default shell is used if kubectl-env is called without a shell sub-command
+		o.Shell = manager.Configuration().DefaultShell
+	}
+
 	o.Symlink = manager.Configuration().SymlinkTargetKubeconfig()
+	if flag := cmd.Flag("link-kubeconfig"); flag != nil && flag.Changed {
+		o.Symlink = o.LinkKubeconfig
+	} else if o.ContextName != "" {
+		// the context of the session stable symlink cannot be renamed, a kubeconfig file is written instead
+		o.Symlink = false
+	}
+
 	o.SessionDir = manager.SessionDir()
 
 	return nil
@@ -67,18 +96,43 @@ func (o *options) Complete(f util.Factory, cmd *cobra.Command, _ []string) error
 
 // Validate validates the provided command options.
 func (o *options) Validate() error {
-	if o.Shell == "" {
-		return pflag.ErrHelp
+	switch o.Output {
+	case outputEnv:
+		if o.Unset {
+			return fmt.Errorf("--unset cannot be combined with --output %s", outputEnv)
+		}
+	case "":
+		if o.Shell == "" {
+			return pflag.ErrHelp
+		}
+
+		s := env.Shell(o.Shell)
+		if err := s.Validate(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("--output must be %q", outputEnv)
 	}
 
-	s := env.Shell(o.Shell)
+	if o.ContextName != "" {
+		if o.Symlink {
+			return errors.New("--context-name cannot be combined with --link-kubeconfig")
+		}
 
-	return s.Validate()
+		if err := target.ValidateContextName(o.ContextName); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // AddFlags binds the command options to a given flagset.
 func (o *options) AddFlags(flags *pflag.FlagSet) {
 	flags.BoolVarP(&o.Unset, "unset", "u", o.Unset, fmt.Sprintf("Generate the script to unset the KUBECONFIG environment variable for %s", o.Shell))
+	flags.StringVar(&o.ContextName, "context-name", o.ContextName, "Rename the current context of the kubeconfig file the KUBECONFIG environment variable points to. Implies --link-kubeconfig=false.")
+	flags.StringVarP(&o.Output, "output", "o", o.Output, fmt.Sprintf("One of '%s'. Print a single KUBECONFIG=<path> line without shell-specific syntax instead of a script, e.g. for env files. A shell sub-command is not required.", outputEnv))
+	flags.BoolVar(&o.LinkKubeconfig, "link-kubeconfig", o.LinkKubeconfig, "Point the KUBECONFIG environment variable to the session stable symlink of the current target. Overrides the linkKubeconfig setting of the gardenctl configuration for this invocation. Use --link-kubeconfig=false to point to a kubeconfig file of the current target instead.")
 }
 
 // Run does the actual work of the command.
@@ -95,7 +149,8 @@ func (o *options) Run(f util.Factory) error {
 		return err
 	}
 
-	if !o.Symlink && o.Target.GardenName() == "" {
+	// resetting the KUBECONFIG environment variable does not require a target
+	if !o.Unset && !o.Symlink && o.Target.GardenName() == "" {
 		return target.ErrNoGardenTargeted
 	}
 
@@ -121,18 +176,46 @@ func (o *options) Run(f util.Factory) error {
 				return err
 			}
 
+			if o.ContextName != "" {
+				config, err = clientConfigWithContextName(config, o.ContextName)
+				if err != nil {
+					return err
+				}
+			}
+
 			filename, err = manager.WriteClientConfig(config)
 			if err != nil {
 				return err
 			}
 		}
 
+		if o.Output == outputEnv {
+			_, err := fmt.Fprintf(o.IOStreams.Out, "KUBECONFIG=%s\n", filename)
+			return err
+		}
+
 		data["filename"] = filename
 	}
 
 	return o.Template.ExecuteTemplate(o.IOStreams.Out, o.Shell, data)
 }
 
+// clientConfigWithContextName returns a copy of the given client configuration whose current context is renamed to the given name.
+func clientConfigWithContextName(config clientcmd.ClientConfig, name string) (clientcmd.ClientConfig, error) {
+	rawConfig, err := config.RawConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raw client configuration: %w", err)
+	}
+
+	// the raw configuration shares its maps with the given client configuration
+	renamed := rawConfig.DeepCopy()
+	if err := target.RenameCurrentContext(renamed, name); err != nil {
+		return nil, err
+	}
+
+	return clientcmd.NewDefaultClientConfig(*renamed, &clientcmd.ConfigOverrides{}), nil
+}
+
 func generateMetadata(o *options) map[string]interface{} {
 	metadata := make(map[string]interface{})
 	metadata["unset"] = o.Unset
@@ -20,6 +20,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/utils/ptr"
 
 	utilmocks "github.com/gardener/gardenctl-v2/internal/util/mocks"
@@ -100,8 +101,74 @@ var _ = Describe("Env Commands - Options", func() {
 				Expect(t.Delegate().Lookup("bash")).NotTo(BeNil())
 			})
 
+			DescribeTable("determining if the kubeconfig should be symlinked",
+				func(linkKubeconfig *bool, flagValue *bool, expected bool) {
+					cfg.LinkKubeconfig = linkKubeconfig
+					options.AddFlags(child.Flags())
+
+					if flagValue != nil {
+						Expect(child.Flags().Set("link-kubeconfig", fmt.Sprintf("%t", *flagValue))).To(Succeed())
+					}
+
+					factory.EXPECT().Manager().Return(manager, nil)
+					manager.EXPECT().SessionDir().Return(sessionDir)
+					manager.EXPECT().Configuration().Return(cfg)
+					Expect(options.Complete(factory, child, nil)).To(Succeed())
+					Expect(options.Symlink).To(Equal(expected))
+				},
+				Entry("should default to true", nil, nil, true),
+				Entry("should use the config value", ptr.To(false), nil, false),
+				Entry("should prefer the flag over the config value (enable)", ptr.To(false), ptr.To(true), true),
+				Entry("should prefer the flag over the config value (disable)", ptr.To(true), ptr.To(false), false),
+				Entry("should prefer the flag over the default", nil, ptr.To(false), false),
+			)
+
+			It("should not symlink the kubeconfig if a context name is given", func() {
+				options.AddFlags(child.Flags())
+				Expect(child.Flags().Set("context-name", "my-shoot")).To(Succeed())
+
+				factory.EXPECT().Manager().Return(manager, nil)
+				manager.EXPECT().SessionDir().Return(sessionDir)
+				manager.EXPECT().Configuration().Return(cfg)
+				Expect(options.Complete(factory, child, nil)).To(Succeed())
+				Expect(options.Symlink).To(BeFalse())
+			})
+
+			It("should use the configured default shell without a shell sub-command", func() {
+				cfg.DefaultShell = "fish"
+				options.Shell = ""
+				factory.EXPECT().Manager().Return(manager, nil)
+				manager.EXPECT().SessionDir().Return(sessionDir)
+				manager.EXPECT().Configuration().Return(cfg).Times(2)
+				Expect(options.Complete(factory, parent, nil)).To(Succeed())
+				Expect(options.Shell).To(Equal("fish"))
+				Expect(options.CmdPath).To(Equal(root.Name() + " " + parent.Name()))
+				Expect(options.Validate()).To(Succeed())
+			})
+
+			It("should prefer the shell sub-command over the configured default shell", func() {
+				cfg.DefaultShell = "fish"
+				factory.EXPECT().Manager().Return(manager, nil)
+				manager.EXPECT().SessionDir().Return(sessionDir)
+				manager.EXPECT().Configuration().Return(cfg)
+				Expect(options.Complete(factory, child, nil)).To(Succeed())
+				Expect(options.Shell).To(Equal(child.Name()))
+			})
+
+			It("should not use the configured default shell with the env output", func() {
+				cfg.DefaultShell = "fish"
+				options.Shell = ""
+				options.Output = "env"
+				factory.EXPECT().Manager().Return(manager, nil)
+				manager.EXPECT().SessionDir().Return(sessionDir)
+				manager.EXPECT().Configuration().Return(cfg)
+				Expect(options.Complete(factory, parent, nil)).To(Succeed())
+				Expect(options.Shell).To(BeEmpty())
+			})
+
 			It("should fail to complete options for providerType kubernetes", func() {
 				writeTempFile(filepath.Join("templates", "kubernetes.tmpl"), "{{define")
+				DeferCleanup(removeTempFile, filepath.Join("templates", "kubernetes.tmpl"))
 				Expect(options.Complete(factory, child, nil)).To(MatchError(MatchRegexp("^parsing template \\\"kubernetes\\\" failed:")))
 			})
 		})
@@ -121,6 +188,37 @@ var _ = Describe("Env Commands - Options", func() {
 				options.Shell = "cmd"
 				Expect(options.Validate()).To(MatchError(fmt.Sprintf("invalid shell given, must be one of %v", env.ValidShells())))
 			})
+
+			It("should not require a shell for the output format env", func() {
+				options.Shell = ""
+				options.Output = "env"
+				Expect(options.Validate()).To(Succeed())
+			})
+
+			It("should return an error when the output format is invalid", func() {
+				options.Shell = "bash"
+				options.Output = "json"
+				Expect(options.Validate()).To(MatchError(`--output must be "env"`))
+			})
+
+			It("should return an error when the output format env is combined with unset", func() {
+				options.Output = "env"
+				options.Unset = true
+				Expect(options.Validate()).To(MatchError("--unset cannot be combined with --output env"))
+			})
+
+			It("should return an error when the context name is invalid", func() {
+				options.Shell = "bash"
+				options.ContextName = "my\tshoot"
+				Expect(options.Validate()).To(MatchError(ContainSubstring("must not contain whitespace or control characters")))
+			})
+
+			It("should return an error when the context name is combined with the symlink", func() {
+				options.Shell = "bash"
+				options.ContextName = "my-shoot"
+				options.Symlink = true
+				Expect(options.Validate()).To(MatchError("--context-name cannot be combined with --link-kubeconfig"))
+			})
 		})
 
 		Describe("adding the command flags", func() {
@@ -174,6 +272,47 @@ var _ = Describe("Env Commands - Options", func() {
 						Expect(options.Run(factory)).To(Succeed())
 					})
 				})
+
+				It("should print the raw KUBECONFIG line for the output format env", func() {
+					options.Output = "env"
+
+					currentTarget := t.WithSeedName("")
+					manager.EXPECT().CurrentTarget().Return(currentTarget, nil)
+					manager.EXPECT().ClientConfig(ctx, currentTarget).Return(config, nil)
+					manager.EXPECT().WriteClientConfig(config).Return(pathToKubeconfig, nil)
+					Expect(options.Run(factory)).To(Succeed())
+					Expect(options.String()).To(Equal("KUBECONFIG=" + pathToKubeconfig + "\n"))
+				})
+
+				It("should rename the current context of the written kubeconfig", func() {
+					rawConfig := clientcmdapi.NewConfig()
+					rawConfig.Clusters["cluster"] = &clientcmdapi.Cluster{Server: "https://api.example.org"}
+					rawConfig.AuthInfos["user"] = &clientcmdapi.AuthInfo{Token: "token"}
+					rawConfig.Contexts["garden-project--shoot-external"] = &clientcmdapi.Context{Cluster: "cluster", AuthInfo: "user"}
+					rawConfig.CurrentContext = "garden-project--shoot-external"
+					config = clientcmd.NewDefaultClientConfig(*rawConfig, nil)
+					options.ContextName = "my-shoot"
+
+					currentTarget := t.WithSeedName("")
+					manager.EXPECT().CurrentTarget().Return(currentTarget, nil)
+					manager.EXPECT().ClientConfig(ctx, currentTarget).Return(config, nil)
+					manager.EXPECT().WriteClientConfig(gomock.Any()).DoAndReturn(func(written clientcmd.ClientConfig) (string, error) {
+						writtenRawConfig, err := written.RawConfig()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(writtenRawConfig.CurrentContext).To(Equal("my-shoot"))
+						Expect(writtenRawConfig.Contexts).To(HaveKey("my-shoot"))
+						Expect(writtenRawConfig.Contexts).NotTo(HaveKey("garden-project--shoot-external"))
+
+						return pathToKubeconfig, nil
+					})
+					mockTemplate.EXPECT().ExecuteTemplate(options.IOStreams.Out, shell, gomock.Any()).Return(nil)
+					Expect(options.Run(factory)).To(Succeed())
+
+					// the client configuration of the manager is not modified
+					originalRawConfig, err := config.RawConfig()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(originalRawConfig.CurrentContext).To(Equal("garden-project--shoot-external"))
+				})
 			})
 
 			Context("when an error occurs", func() {
@@ -214,6 +353,52 @@ var _ = Describe("Env Commands - Options", func() {
 			})
 		})
 
+		Describe("rendering the kubectl-env script", func() {
+			var ctx context.Context
+
+			BeforeEach(func() {
+				ctx = context.Background()
+				cmdPath = "gardenctl kubectl-env"
+				baseTemplate = env.NewTemplate("helpers")
+				Expect(baseTemplate.ParseFiles(filepath.Join(gardenHomeDir, "templates", "kubernetes.tmpl"))).To(Succeed())
+
+				factory.EXPECT().Context().Return(ctx)
+				factory.EXPECT().Manager().Return(manager, nil)
+			})
+
+			DescribeTable("exporting and unsetting the KUBECONFIG environment variable",
+				func(s string, exportFile string, unsetFile string) {
+					shell = s
+
+					By("exporting the KUBECONFIG")
+					currentTarget := target.NewTarget("test", "project", "", "shoot")
+					config := &clientcmd.DirectClientConfig{}
+					manager.EXPECT().CurrentTarget().Return(currentTarget, nil)
+					manager.EXPECT().ClientConfig(ctx, currentTarget).Return(config, nil)
+					manager.EXPECT().WriteClientConfig(config).Return("/path/to/kube/config", nil)
+					options.Shell = shell
+					options.CmdPath = cmdPath
+					options.Template = baseTemplate
+					Expect(options.Run(factory)).To(Succeed())
+					Expect(options.String()).To(Equal(readTestFile(exportFile)))
+
+					By("unsetting the KUBECONFIG without a targeted garden")
+					unsetOptions := kubectlenv.NewOptions()
+					unsetOptions.Shell = shell
+					unsetOptions.CmdPath = cmdPath
+					unsetOptions.Template = baseTemplate
+					unsetOptions.Unset = true
+					factory.EXPECT().Context().Return(ctx)
+					factory.EXPECT().Manager().Return(manager, nil)
+					manager.EXPECT().CurrentTarget().Return(target.NewTarget("", "", "", ""), nil)
+					Expect(unsetOptions.Run(factory)).To(Succeed())
+					Expect(unsetOptions.String()).To(Equal(readTestFile(unsetFile)))
+				},
+				Entry("bash", "bash", "kubernetes/export.bash", "kubernetes/unset.bash"),
+				Entry("powershell", "powershell", "kubernetes/export.pwsh", "kubernetes/unset.pwsh"),
+			)
+		})
+
 		Describe("rendering the usage hint", func() {
 			var (
 				targetFlags string
@@ -0,0 +1,100 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime/pprof"
+	"runtime/trace"
+
+	"github.com/spf13/pflag"
+)
+
+// profiler writes a CPU profile and an execution trace of a gardenctl command. It is intended for
+// maintainers debugging slow operations and therefore only configurable by hidden flags.
+type profiler struct {
+	// CPUProfile is the file the CPU profile is written to
+	CPUProfile string
+	// Trace is the file the execution trace is written to
+	Trace string
+
+	cpuProfileFile *os.File
+	traceFile      *os.File
+}
+
+// AddFlags binds the profiler options to a given flagset and hides them from the help output.
+func (p *profiler) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&p.CPUProfile, "cpu-profile", "", "Write a CPU profile of the command execution to this file")
+	flags.StringVar(&p.Trace, "trace", "", "Write an execution trace of the command execution to this file")
+
+	for _, name := range []string{"cpu-profile", "trace"} {
+		_ = flags.MarkHidden(name)
+	}
+}
+
+// Start starts CPU profiling and tracing if the respective files are configured.
+func (p *profiler) Start() error {
+	if p.CPUProfile != "" {
+		f, err := os.Create(p.CPUProfile)
+		if err != nil {
+			return fmt.Errorf("failed to create CPU profile file: %w", err)
+		}
+
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to start CPU profile: %w", err)
+		}
+
+		p.cpuProfileFile = f
+	}
+
+	if p.Trace != "" {
+		f, err := os.Create(p.Trace)
+		if err != nil {
+			return errors.Join(fmt.Errorf("failed to create trace file: %w", err), p.Stop())
+		}
+
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			return errors.Join(fmt.Errorf("failed to start trace: %w", err), p.Stop())
+		}
+
+		p.traceFile = f
+	}
+
+	return nil
+}
+
+// Stop stops CPU profiling and tracing and flushes the files. It is safe to call Stop multiple times
+// or without a previous call to Start.
+func (p *profiler) Stop() error {
+	var errs []error
+
+	if p.cpuProfileFile != nil {
+		pprof.StopCPUProfile()
+
+		if err := p.cpuProfileFile.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close CPU profile file: %w", err))
+		}
+
+		p.cpuProfileFile = nil
+	}
+
+	if p.traceFile != nil {
+		trace.Stop()
+
+		if err := p.traceFile.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close trace file: %w", err))
+		}
+
+		p.traceFile = nil
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,90 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package providerenv
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// awsRoleSessionName is the session name of the roles assumed by the aws scripts.
+const awsRoleSessionName = "gardenctl"
+
+var (
+	// awsRoleARNRegexp matches the ARNs of IAM roles in any partition, e.g. arn:aws:iam::123456789012:role/path/name.
+	awsRoleARNRegexp = regexp.MustCompile(`^arn:aws(-[a-z]+)*:iam::[0-9]{12}:role/[\w+=,.@/-]{1,512}$`)
+	// awsExternalIDRegexp matches the characters of the external IDs accepted by AWS STS.
+	awsExternalIDRegexp = regexp.MustCompile(`^[\w+=,.@:/-]+$`)
+)
+
+// awsAssumeRole adds the role the aws script assumes with the credentials of the cloud provider secret to the
+// template data. The role ARN, external ID and STS endpoint are taken from the flags or, if not set, from the
+// roleARN, externalID and stsEndpoint fields of the secret. Without a role ARN, no role is assumed.
+func (o *options) awsAssumeRole(secret *corev1.Secret, data map[string]interface{}) error {
+	roleARN := valueOrSecretField(o.AWSRoleARN, secret, "roleARN")
+	externalID := valueOrSecretField(o.AWSExternalID, secret, "externalID")
+	stsEndpoint := valueOrSecretField(o.AWSSTSEndpoint, secret, "stsEndpoint")
+
+	data["roleARN"] = roleARN
+	data["externalID"] = externalID
+	data["stsEndpoint"] = stsEndpoint
+
+	if roleARN == "" {
+		if externalID != "" || stsEndpoint != "" {
+			return errors.New("an AWS external ID or STS endpoint requires a role ARN to assume")
+		}
+
+		return nil
+	}
+
+	if !awsRoleARNRegexp.MatchString(roleARN) {
+		return fmt.Errorf("invalid AWS role ARN %q: must have the format arn:<partition>:iam::<account-id>:role/<role-name>", roleARN)
+	}
+
+	// the external ID is not included in the error, as it is a shared secret with the role owner
+	if externalID != "" && (len(externalID) < 2 || len(externalID) > 1224 || !awsExternalIDRegexp.MatchString(externalID)) {
+		return errors.New("invalid AWS external ID: must consist of 2 to 1224 alphanumeric characters or any of +=,.@:/-")
+	}
+
+	if stsEndpoint != "" {
+		if err := validateAWSSTSEndpoint(stsEndpoint); err != nil {
+			return err
+		}
+	}
+
+	data["roleSessionName"] = awsRoleSessionName
+
+	return nil
+}
+
+// validateAWSSTSEndpoint checks that the STS endpoint is an HTTPS URL without credentials, query or fragment,
+// as it is passed to the aws CLI in the script. A password in the endpoint is redacted in the error.
+func validateAWSSTSEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid AWS STS endpoint %q: %w", endpoint, err)
+	}
+
+	if u.Scheme != "https" || u.Hostname() == "" || u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("invalid AWS STS endpoint %q: must be an https URL without user information, query or fragment", u.Redacted())
+	}
+
+	return nil
+}
+
+// valueOrSecretField returns the given value or, if empty, the value of the given field of the secret.
+func valueOrSecretField(value string, secret *corev1.Secret, key string) string {
+	if value != "" {
+		return value
+	}
+
+	return string(secret.Data[key])
+}
@@ -0,0 +1,128 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package providerenv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	clientgarden "github.com/gardener/gardenctl-v2/internal/client/garden"
+	"github.com/gardener/gardenctl-v2/pkg/config"
+	"github.com/gardener/gardenctl-v2/pkg/env"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+// ShootResult holds the cloud provider CLI configuration generated for one shoot of a batch,
+// or the error that occurred while generating it.
+type ShootResult struct {
+	// Env is the cloud provider CLI configuration of the shoot.
+	Env map[string]interface{} `json:"env,omitempty"`
+	// Error is the error that occurred while generating the configuration.
+	Error string `json:"error,omitempty"`
+}
+
+// runBatch generates the cloud provider CLI configuration for all shoots given by o.Shoots and prints
+// them as a map from shoot name to ShootResult. Errors are collected per shoot, so that a failing shoot
+// does not abort the whole batch.
+func (o *options) runBatch(ctx context.Context, client clientgarden.Client, cfg *config.Config) error {
+	results := make(map[string]ShootResult, len(o.Shoots))
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, o.MaxConcurrentShoots)
+	)
+
+	for _, name := range o.Shoots {
+		wg.Add(1)
+
+		go func(name string) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			result := ShootResult{}
+
+			data, err := o.shootProviderEnv(ctx, client, cfg, o.Target.WithShootName(name))
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Env = data
+			}
+
+			mu.Lock()
+			results[name] = result
+			mu.Unlock()
+		}(name)
+	}
+
+	wg.Wait()
+
+	if err := o.PrintObject(results); err != nil {
+		return err
+	}
+
+	failed := 0
+
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to generate the cloud provider CLI configuration for %d of %d shoots", failed, len(results))
+	}
+
+	return nil
+}
+
+// shootProviderEnv generates the cloud provider CLI configuration for the shoot of the given target.
+func (o *options) shootProviderEnv(ctx context.Context, client clientgarden.Client, cfg *config.Config, t target.Target) (map[string]interface{}, error) {
+	shoot, err := clientgarden.FindShootWithRetry(ctx, client, o.WaitShoot, t.AsListOption())
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := getCredentialsSecret(ctx, client, shoot)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudProfile, err := o.getCloudProfile(ctx, client, shoot)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := checkAccessRestrictions(cfg, t.GardenName(), shoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// the shoots are named explicitly, hence the access restrictions must always be confirmed
+	if len(messages) > 0 && !o.ConfirmAccessRestriction {
+		return nil, errors.New("the access restrictions of the shoot must be confirmed with the --confirm-access-restriction flag")
+	}
+
+	// each shoot gets its own copy of the options, as the target, template and configuration directory are specific to the shoot
+	so := *o
+	so.Target = t
+	so.ConfigDirShoot = t.ShootName()
+	so.Template = env.NewTemplate("helpers")
+
+	providerType := shoot.Spec.Provider.Type
+	metadata := generateMetadata(&so, getProviderCLI(providerType))
+
+	if len(messages) > 0 {
+		metadata["notification"] = messages.String()
+	}
+
+	return generateData(&so, shoot, secret, cloudProfile, providerType, metadata)
+}
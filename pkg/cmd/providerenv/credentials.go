@@ -0,0 +1,434 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package providerenv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
+	"github.com/gardener/gardenctl-v2/pkg/flags"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+// outputTFVars is the output format for Terraform variable definitions.
+const outputTFVars = "tfvars"
+
+// credentialField is a field of the cloud provider secret.
+type credentialField struct {
+	// Key is the key of the field in the cloud provider secret.
+	Key string
+	// Variable is the name of the Terraform variable printed by provider-credentials. Fields without a variable
+	// are only validated.
+	Variable string
+	// Env is the environment variable of the cloud provider CLI that is exported by provider-env --output keychain
+	// and compared by provider-env --diff.
+	Env string
+	// Check returns why the value of the field is invalid, or an empty string if it is valid.
+	// If nil, the format of the value is not validated.
+	Check func(value []byte) string
+}
+
+var (
+	// equinixMetalAPITokenRegexp matches the API tokens of Equinix Metal.
+	equinixMetalAPITokenRegexp = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+	// equinixMetalProjectIDRegexp matches the project IDs of Equinix Metal, which are UUIDs.
+	equinixMetalProjectIDRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// credentialFields are the fields of the cloud provider secret per provider type. provider-env and provider-credentials
+// validate the fields with a check, and provider-credentials prints the fields with a Terraform variable.
+var credentialFields = map[string][]credentialField{
+	"alicloud": {
+		{Key: "accessKeyID", Variable: "access_key", Env: "ALICLOUD_ACCESS_KEY_ID"},
+		{Key: "accessKeySecret", Variable: "secret_key", Env: "ALICLOUD_ACCESS_KEY_SECRET"},
+	},
+	"aws": {
+		{Key: "accessKeyID", Variable: "access_key", Env: "AWS_ACCESS_KEY_ID"},
+		{Key: "secretAccessKey", Variable: "secret_key", Env: "AWS_SECRET_ACCESS_KEY"},
+	},
+	"azure": {
+		{Key: "clientID", Variable: "client_id", Env: "AZURE_CLIENT_ID"},
+		{Key: "clientSecret", Variable: "client_secret", Env: "AZURE_CLIENT_SECRET"},
+		{Key: "subscriptionID", Variable: "subscription_id", Env: "AZURE_SUBSCRIPTION_ID"},
+		{Key: "tenantID", Variable: "tenant_id", Env: "AZURE_TENANT_ID"},
+	},
+	"equinixmetal": {
+		{Key: "apiToken", Env: "METAL_AUTH_TOKEN", Check: matchCredential(equinixMetalAPITokenRegexp, "must be a non-empty alphanumeric string")},
+		{Key: "projectID", Env: "METAL_PROJECT_ID", Check: matchCredential(equinixMetalProjectIDRegexp, "must be a UUID")},
+	},
+	"gcp":    {{Key: "serviceaccount.json", Variable: "credentials", Env: "GOOGLE_CREDENTIALS"}},
+	"hcloud": {{Key: "hcloudToken", Variable: "hcloud_token", Env: "HCLOUD_TOKEN"}},
+	"openstack": {
+		{Key: "domainName", Env: "OS_PROJECT_DOMAIN_NAME"},
+		{Key: "domainName", Env: "OS_USER_DOMAIN_NAME"},
+		{Key: "tenantName", Env: "OS_TENANT_NAME"},
+		{Key: "username", Env: "OS_USERNAME"},
+		{Key: "password", Env: "OS_PASSWORD"},
+		{Key: "applicationCredentialID", Env: "OS_APPLICATION_CREDENTIAL_ID"},
+		{Key: "applicationCredentialName", Env: "OS_APPLICATION_CREDENTIAL_NAME"},
+		{Key: "applicationCredentialSecret", Env: "OS_APPLICATION_CREDENTIAL_SECRET"},
+	},
+	"vsphere": {
+		{Key: "vsphereUsername", Env: "GOVC_USERNAME", Check: notBlankCredential},
+		{Key: "vspherePassword", Env: "GOVC_PASSWORD", Check: notBlankCredential},
+	},
+}
+
+// matchCredential returns a check that the value of a credential field matches the given regular expression.
+func matchCredential(re *regexp.Regexp, reason string) func(value []byte) string {
+	return func(value []byte) string {
+		if !re.Match(value) {
+			return reason
+		}
+
+		return ""
+	}
+}
+
+// notBlankCredential checks that the value of a credential field is not empty or whitespace only.
+func notBlankCredential(value []byte) string {
+	if strings.TrimSpace(string(value)) == "" {
+		return "must not be empty"
+	}
+
+	return ""
+}
+
+// credentialValidationOptions are the options for the validation of the credentials in the cloud provider secret,
+// shared by provider-env and provider-credentials.
+type credentialValidationOptions struct {
+	// InsecureSkipCredentialValidation skips the format validation of the credentials in the cloud provider secret,
+	// e.g. for landscapes with non-standard but legitimate credentials. A warning is printed every time.
+	InsecureSkipCredentialValidation bool
+	// FirstCredentialErrorOnly reports only the first invalid field of the cloud provider secret instead of all of them,
+	// for scripts that rely on the former single-error format.
+	FirstCredentialErrorOnly bool
+}
+
+// addFlags binds the credential validation options to a given flagset.
+func (o *credentialValidationOptions) addFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(&o.InsecureSkipCredentialValidation, "insecure-skip-credential-validation", o.InsecureSkipCredentialValidation, "Skip the format validation of the credentials in the cloud provider secret. Only use this flag for non-standard credentials that are known to be legitimate.")
+	flags.BoolVar(&o.FirstCredentialErrorOnly, "first-credential-error-only", o.FirstCredentialErrorOnly, "Report only the first invalid field of the cloud provider secret instead of all invalid fields at once.")
+}
+
+// checkCredentials validates the credentials in the cloud provider secret, unless the validation is skipped
+// by the --insecure-skip-credential-validation flag, in which case a warning is printed.
+func (o *credentialValidationOptions) checkCredentials(errOut io.Writer, providerType string, secret *corev1.Secret) error {
+	if o.InsecureSkipCredentialValidation {
+		fmt.Fprintf(errOut, "WARNING: the validation of the credentials in Secret %q is skipped as requested by --insecure-skip-credential-validation. Never use this flag by default.\n", secret.Name)
+		return nil
+	}
+
+	return validateCredentials(providerType, secret, o.FirstCredentialErrorOnly)
+}
+
+// validateCredentials checks the format of the fields of the cloud provider secret. The errors of all invalid
+// fields are returned at once, or only the first one if firstOnly is set. The values are not included in the
+// errors, as they are confidential.
+func validateCredentials(providerType string, secret *corev1.Secret, firstOnly bool) error {
+	var errs []error
+
+	for _, field := range credentialFields[providerType] {
+		if field.Check == nil {
+			continue
+		}
+
+		if reason := field.Check(secret.Data[field.Key]); reason != "" {
+			err := fmt.Errorf("invalid %q data in Secret %q: %s", field.Key, secret.Name, reason)
+			if firstOnly {
+				return err
+			}
+
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// printableVariables are the variables that do not contain secrets and can therefore be printed with --field.
+var printableVariables = map[string]bool{
+	"access_key":      true,
+	"client_id":       true,
+	"project":         true,
+	"region":          true,
+	"subscription_id": true,
+	"tenant_id":       true,
+}
+
+// NewCmdProviderCredentials returns a new provider-credentials command.
+func NewCmdProviderCredentials(f util.Factory, ioStreams util.IOStreams) *cobra.Command {
+	o := &credentialsOptions{
+		Options: base.Options{
+			IOStreams: ioStreams,
+			Output:    outputTFVars,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:   "provider-credentials",
+		Short: "Print the cloud provider credentials of the targeted shoot as variables",
+		Long: `Print the cloud provider credentials of the targeted shoot as variables, e.g. for Terraform.
+The variables are derived from the cloud provider secret of the shoot, which must contain all fields required
+for the provider type. Supported provider types are alicloud, aws, azure, gcp and hcloud.
+
+The credentials are only written to disk if the --write-to flag is given. The --field flag prints the value of a single
+variable that does not contain a secret, e.g. the project or the region.`,
+		Example: `# print the credentials of the targeted shoot as Terraform variables
+gardenctl provider-credentials
+
+# write the credentials of the targeted shoot to a Terraform variable definitions file
+gardenctl provider-credentials --write-to credentials.auto.tfvars
+
+# print only the project of the targeted gcp shoot
+gardenctl provider-credentials --field project`,
+		Args: cobra.NoArgs,
+		RunE: base.WrapRunE(o, f),
+	}
+
+	cmdFlags := cmd.Flags()
+	o.AddFlags(cmdFlags)
+
+	f.TargetFlags().AddFlags(cmdFlags)
+	flags.RegisterCompletionFuncsForTargetFlags(cmd, f, ioStreams, cmdFlags)
+
+	utilruntime.Must(cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"json", "yaml", outputTFVars}, cobra.ShellCompDirectiveNoFileComp
+	}))
+
+	return cmd
+}
+
+type credentialsOptions struct {
+	base.Options
+	credentialValidationOptions
+
+	// ConfirmAccessRestriction, when set to true, implies the user's understanding of the access restrictions for the targeted shoot.
+	ConfirmAccessRestriction bool
+	// WriteTo is the path of the file the credentials are written to. If empty, the credentials are printed.
+	WriteTo string
+	// Field is the name of a single variable whose value is printed, without any formatting.
+	// Variables that contain secrets cannot be printed this way.
+	Field string
+}
+
+// AddFlags binds the command options to a given flagset.
+func (o *credentialsOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&o.Output, "output", "o", o.Output, "One of 'yaml', 'json' or 'tfvars'.")
+	flags.BoolVarP(&o.ConfirmAccessRestriction, "confirm-access-restriction", "y", o.ConfirmAccessRestriction, "Confirm any access restrictions. Set this flag only if you are completely aware of the access restrictions.")
+	flags.StringVar(&o.WriteTo, "write-to", o.WriteTo, "Write the credentials to the given file instead of printing them. The file is created with permissions 0600.")
+	flags.StringVar(&o.Field, "field", o.Field, "Print only the value of the given variable, e.g. project or region. Variables that contain secrets cannot be printed.")
+	o.credentialValidationOptions.addFlags(flags)
+}
+
+// Validate validates the provided command options.
+func (o *credentialsOptions) Validate() error {
+	if o.Output != "yaml" && o.Output != "json" && o.Output != outputTFVars {
+		return errors.New("--output must be one of 'yaml', 'json' or 'tfvars'")
+	}
+
+	if o.Field != "" && o.WriteTo != "" {
+		return errors.New("--field cannot be combined with --write-to")
+	}
+
+	return nil
+}
+
+// Run does the actual work of the command.
+func (o *credentialsOptions) Run(f util.Factory) error {
+	ctx := f.Context()
+
+	manager, err := f.Manager()
+	if err != nil {
+		return err
+	}
+
+	currentTarget, err := manager.CurrentTarget()
+	if err != nil {
+		return err
+	}
+
+	if currentTarget.GardenName() == "" {
+		return target.ErrNoGardenTargeted
+	}
+
+	if currentTarget.ShootName() == "" {
+		return target.ErrNoShootTargeted
+	}
+
+	client, err := manager.GardenClient(currentTarget.GardenName())
+	if err != nil {
+		return fmt.Errorf("failed to create garden cluster client: %w", err)
+	}
+
+	shoot, err := client.FindShoot(ctx, currentTarget.AsListOption())
+	if err != nil {
+		return err
+	}
+
+	messages, err := checkAccessRestrictions(manager.Configuration(), currentTarget.GardenName(), shoot)
+	if err != nil {
+		return err
+	}
+
+	if len(messages) > 0 && !o.ConfirmAccessRestriction {
+		return errors.New("the cloud provider credentials can only be printed if you confirm the access despite the existing restrictions. Use the --confirm-access-restriction flag to confirm the access")
+	}
+
+	secret, err := getCredentialsSecret(ctx, client, shoot)
+	if err != nil {
+		return err
+	}
+
+	if err := o.checkCredentials(o.IOStreams.ErrOut, shoot.Spec.Provider.Type, secret); err != nil {
+		return err
+	}
+
+	variables, err := credentialVariables(shoot.Spec.Provider.Type, secret, shoot.Spec.Region)
+	if err != nil {
+		return err
+	}
+
+	if o.Field != "" {
+		return o.printField(variables)
+	}
+
+	var buf bytes.Buffer
+
+	if o.Output == outputTFVars {
+		buf.WriteString(formatTFVars(variables))
+	} else {
+		printer := o.Options
+		printer.IOStreams.Out = &buf
+
+		if err := printer.PrintObject(variables); err != nil {
+			return err
+		}
+	}
+
+	if o.WriteTo == "" {
+		_, err = o.IOStreams.Out.Write(buf.Bytes())
+		return err
+	}
+
+	if err := util.WritePrivateFile(o.WriteTo, buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write the cloud provider credentials: %w", err)
+	}
+
+	fmt.Fprintf(o.IOStreams.Out, "Successfully wrote the cloud provider credentials to %q\n", o.WriteTo)
+
+	return nil
+}
+
+// printField prints the value of the variable given by the --field flag. Variables that contain secrets are rejected.
+func (o *credentialsOptions) printField(variables map[string]string) error {
+	value, ok := variables[o.Field]
+	if !ok {
+		names := make([]string, 0, len(variables))
+		for name := range variables {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		return fmt.Errorf("field %q not found, must be one of %s", o.Field, strings.Join(names, ", "))
+	}
+
+	if !printableVariables[o.Field] {
+		return fmt.Errorf("field %q contains a secret and cannot be printed, use --write-to to write the credentials to a file", o.Field)
+	}
+
+	_, err := fmt.Fprintln(o.IOStreams.Out, value)
+
+	return err
+}
+
+// credentialVariables returns the variables for the cloud provider credentials of the given secret.
+// An error is returned if the provider type is not supported or a required field is missing in the secret.
+func credentialVariables(providerType string, secret *corev1.Secret, region string) (map[string]string, error) {
+	fields := credentialFields[providerType]
+	if !slices.ContainsFunc(fields, func(field credentialField) bool { return field.Variable != "" }) {
+		return nil, fmt.Errorf("cloud provider %q is not supported", providerType)
+	}
+
+	variables := map[string]string{
+		"region": region,
+	}
+
+	for _, field := range fields {
+		if field.Variable == "" {
+			continue
+		}
+
+		value := secret.Data[field.Key]
+		if len(value) == 0 {
+			return nil, fmt.Errorf("no %q data in Secret %q", field.Key, secret.Name)
+		}
+
+		variables[field.Variable] = string(value)
+	}
+
+	if providerType == "gcp" {
+		credentials := make(map[string]interface{})
+
+		serviceaccountJSON, err := parseGCPCredentials(secret, &credentials)
+		if err != nil {
+			return nil, err
+		}
+
+		project, _ := credentials["project_id"].(string)
+		if project == "" {
+			return nil, fmt.Errorf("no \"project_id\" in the service account of Secret %q", secret.Name)
+		}
+
+		variables["credentials"] = string(serviceaccountJSON)
+		variables["project"] = project
+	}
+
+	return variables, nil
+}
+
+// hclEscaper escapes the characters that have a special meaning in quoted HCL strings.
+var hclEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+	"${", "$${",
+	"%{", "%%{",
+)
+
+// formatTFVars formats the given variables as Terraform variable definitions, sorted by name.
+func formatTFVars(variables map[string]string) string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var buf strings.Builder
+
+	for _, name := range names {
+		fmt.Fprintf(&buf, "%s = \"%s\"\n", name, hclEscaper.Replace(variables[name]))
+	}
+
+	return buf.String()
+}
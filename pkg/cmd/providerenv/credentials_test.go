@@ -0,0 +1,267 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package providerenv_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	clientgarden "github.com/gardener/gardenctl-v2/internal/client/garden"
+	"github.com/gardener/gardenctl-v2/internal/fake"
+	"github.com/gardener/gardenctl-v2/internal/util"
+	utilmocks "github.com/gardener/gardenctl-v2/internal/util/mocks"
+	"github.com/gardener/gardenctl-v2/pkg/ac"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/providerenv"
+	"github.com/gardener/gardenctl-v2/pkg/config"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+	targetmocks "github.com/gardener/gardenctl-v2/pkg/target/mocks"
+)
+
+var _ = Describe("Provider Credentials Command", func() {
+	var (
+		ctrl       *gomock.Controller
+		factory    *utilmocks.MockFactory
+		manager    *targetmocks.MockManager
+		streams    util.IOStreams
+		out        *util.SafeBytesBuffer
+		errOut     *util.SafeBytesBuffer
+		t          target.Target
+		shoot      *gardencorev1beta1.Shoot
+		secret     *corev1.Secret
+		cfg        *config.Config
+		secretName string
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		factory = utilmocks.NewMockFactory(ctrl)
+		manager = targetmocks.NewMockManager(ctrl)
+		streams, _, out, errOut = util.NewTestIOStreams()
+
+		t = target.NewTarget("test", "project", "", "shoot")
+		cfg = &config.Config{
+			Gardens: []config.Garden{{Name: t.GardenName()}},
+		}
+		secretName = "secret"
+
+		factory.EXPECT().Manager().Return(manager, nil).AnyTimes()
+		factory.EXPECT().TargetFlags().Return(target.NewTargetFlags("", "", "", "", false)).AnyTimes()
+		factory.EXPECT().Context().Return(context.Background()).AnyTimes()
+		manager.EXPECT().CurrentTarget().Return(t, nil).AnyTimes()
+		manager.EXPECT().Configuration().Return(cfg).AnyTimes()
+
+		shoot = &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      t.ShootName(),
+				Namespace: "garden-" + t.ProjectName(),
+			},
+			Spec: gardencorev1beta1.ShootSpec{
+				Region:            "europe",
+				SecretBindingName: ptr.To("secret-binding"),
+			},
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: shoot.Namespace,
+				Name:      secretName,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	execute := func(args ...string) error {
+		project := &gardencorev1beta1.Project{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: t.ProjectName(),
+			},
+			Spec: gardencorev1beta1.ProjectSpec{
+				Namespace: ptr.To(shoot.Namespace),
+			},
+		}
+		secretBinding := &gardencorev1beta1.SecretBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      *shoot.Spec.SecretBindingName,
+				Namespace: shoot.Namespace,
+			},
+			SecretRef: corev1.SecretReference{
+				Namespace: secret.Namespace,
+				Name:      secret.Name,
+			},
+		}
+		client := clientgarden.NewClient(
+			nil,
+			fake.NewClientWithObjects(project, shoot, secretBinding, secret),
+			t.GardenName(),
+		)
+		manager.EXPECT().GardenClient(t.GardenName()).Return(client, nil).AnyTimes()
+
+		cmd := providerenv.NewCmdProviderCredentials(factory, streams)
+		cmd.SetArgs(append([]string{}, args...))
+		cmd.SetOut(out)
+		cmd.SetErr(errOut)
+
+		return cmd.Execute()
+	}
+
+	Context("when the shoot is an aws shoot", func() {
+		BeforeEach(func() {
+			shoot.Spec.Provider.Type = "aws"
+			secret.Data = map[string][]byte{
+				"accessKeyID":     []byte("AKIA0123456789"),
+				"secretAccessKey": []byte(`s3cr"et\${key}`),
+			}
+		})
+
+		It("should print the credentials as Terraform variables", func() {
+			Expect(execute()).To(Succeed())
+			Expect(out.String()).To(Equal(`access_key = "AKIA0123456789"
+region = "europe"
+secret_key = "s3cr\"et\\$${key}"
+`))
+		})
+
+		It("should print the credentials in json format", func() {
+			Expect(execute("--output", "json")).To(Succeed())
+			Expect(out.String()).To(MatchJSON(`{
+  "access_key": "AKIA0123456789",
+  "region": "europe",
+  "secret_key": "s3cr\"et\\${key}"
+}`))
+		})
+
+		It("should write the credentials to the given file only", func() {
+			filename := filepath.Join(GinkgoT().TempDir(), "credentials.auto.tfvars")
+
+			Expect(execute("--write-to", filename)).To(Succeed())
+			Expect(out.String()).To(Equal("Successfully wrote the cloud provider credentials to \"" + filename + "\"\n"))
+
+			data, err := os.ReadFile(filename)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`access_key = "AKIA0123456789"`))
+
+			info, err := os.Stat(filename)
+			Expect(err).NotTo(HaveOccurred())
+			if os.PathSeparator == '/' {
+				Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+			}
+		})
+
+		It("should restrict the permissions of an existing file", func() {
+			filename := filepath.Join(GinkgoT().TempDir(), "credentials.auto.tfvars")
+			Expect(os.WriteFile(filename, []byte("# old"), 0o644)).To(Succeed())
+
+			Expect(execute("--write-to", filename)).To(Succeed())
+
+			data, err := os.ReadFile(filename)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).NotTo(ContainSubstring("# old"))
+
+			info, err := os.Stat(filename)
+			Expect(err).NotTo(HaveOccurred())
+			if os.PathSeparator == '/' {
+				Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+			}
+		})
+
+		It("should warn if the credential validation is skipped", func() {
+			Expect(execute("--insecure-skip-credential-validation")).To(Succeed())
+			Expect(errOut.String()).To(ContainSubstring(`WARNING: the validation of the credentials in Secret "secret" is skipped`))
+			Expect(out.String()).To(ContainSubstring(`access_key = "AKIA0123456789"`))
+		})
+
+		It("should fail if a required field is missing", func() {
+			delete(secret.Data, "secretAccessKey")
+
+			Expect(execute()).To(MatchError(`no "secretAccessKey" data in Secret "secret"`))
+			Expect(out.String()).NotTo(ContainSubstring("AKIA0123456789"))
+		})
+	})
+
+	Context("when the shoot is a gcp shoot", func() {
+		BeforeEach(func() {
+			shoot.Spec.Provider.Type = "gcp"
+			secret.Data = map[string][]byte{
+				"serviceaccount.json": []byte(readTestFile("gcp/serviceaccount.json")),
+			}
+		})
+
+		It("should print the credentials as Terraform variables", func() {
+			Expect(execute("--output", "tfvars")).To(Succeed())
+			Expect(out.String()).To(Equal(`credentials = "{\"client_email\":\"test@example.org\",\"project_id\":\"test\"}"
+project = "test"
+region = "europe"
+`))
+		})
+
+		It("should print only the project", func() {
+			Expect(execute("--field", "project")).To(Succeed())
+			Expect(out.String()).To(Equal("test\n"))
+		})
+
+		It("should reject printing the service account", func() {
+			Expect(execute("--field", "credentials")).To(MatchError(`field "credentials" contains a secret and cannot be printed, use --write-to to write the credentials to a file`))
+			Expect(out.String()).NotTo(ContainSubstring("client_email"))
+		})
+
+		It("should fail if the field does not exist", func() {
+			Expect(execute("--field", "project_id")).To(MatchError(`field "project_id" not found, must be one of credentials, project, region`))
+		})
+
+		It("should fail if the service account has no project", func() {
+			secret.Data["serviceaccount.json"] = []byte(`{"client_email":"test@example.org"}`)
+
+			Expect(execute()).To(MatchError(`no "project_id" in the service account of Secret "secret"`))
+		})
+	})
+
+	It("should fail for unsupported provider types", func() {
+		shoot.Spec.Provider.Type = "openstack"
+
+		Expect(execute()).To(MatchError(`cloud provider "openstack" is not supported`))
+	})
+
+	It("should not allow to combine --field and --write-to", func() {
+		Expect(execute("--field", "region", "--write-to", "credentials.auto.tfvars")).To(MatchError("--field cannot be combined with --write-to"))
+	})
+
+	It("should fail for an invalid output format", func() {
+		Expect(execute("--output", "hcl")).To(MatchError("--output must be one of 'yaml', 'json' or 'tfvars'"))
+	})
+
+	It("should require the access restrictions to be confirmed", func() {
+		cfg.Gardens[0].AccessRestrictions = []ac.AccessRestriction{{Key: "eu-access-only", Msg: "Do not access"}}
+		shoot.Spec.Provider.Type = "aws"
+		shoot.Spec.AccessRestrictions = []gardencorev1beta1.AccessRestrictionWithOptions{{
+			AccessRestriction: gardencorev1beta1.AccessRestriction{Name: "eu-access-only"},
+		}}
+
+		Expect(execute()).To(MatchError(ContainSubstring("--confirm-access-restriction")))
+	})
+})
+
+var _ = Describe("formatTFVars", func() {
+	It("should escape the values for HCL", func() {
+		Expect(providerenv.FormatTFVars(map[string]string{
+			"b": "line1\nline2\t\"quoted\"",
+			"a": `C:\path %{if} ${var}`,
+		})).To(Equal(`a = "C:\\path %%{if} $${var}"
+b = "line1\nline2\t\"quoted\""
+`))
+	})
+})
@@ -0,0 +1,132 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package providerenv
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// variableAdded indicates that the variable is not set in the current environment.
+	variableAdded = "added"
+	// variableChanged indicates that the variable is set to another value in the current environment.
+	variableChanged = "changed"
+	// variableUnchanged indicates that the variable is already set to the same value in the current environment.
+	variableUnchanged = "unchanged"
+	// variableUnknown indicates that the value of the variable is only known when the script runs, e.g. the
+	// temporary credentials of an assumed AWS role, and can therefore not be compared.
+	variableUnknown = "unknown"
+)
+
+// variableDiff is the difference of an environment variable set by the script to the current environment.
+type variableDiff struct {
+	// Name is the name of the environment variable.
+	Name string `json:"name"`
+	// Status is either added, changed, unchanged or unknown.
+	Status string `json:"status"`
+}
+
+// environmentDiff is the difference of the credential variables set by the script to the current environment.
+// It never contains the values of the variables.
+type environmentDiff struct {
+	// Shoot is the name of the shoot.
+	Shoot string `json:"shoot"`
+	// Variables are the credential variables in the order the script sets them.
+	Variables []variableDiff `json:"variables"`
+}
+
+// String returns the concise report of the environment diff.
+func (d *environmentDiff) String() string {
+	var sb strings.Builder
+
+	for _, variable := range d.Variables {
+		fmt.Fprintf(&sb, "%-10s %s\n", variable.Status+":", variable.Name)
+	}
+
+	return sb.String()
+}
+
+// printDiff compares the credential variables the script would set for the shoot with the given environment and
+// prints which of them are added, changed, unchanged or unknown instead of the script. The values are redacted.
+func (o *options) printDiff(shoot *gardencorev1beta1.Shoot, secret *corev1.Secret) error {
+	providerType := shoot.Spec.Provider.Type
+
+	fields, ok := credentialFields[providerType]
+	if !ok {
+		return fmt.Errorf("--diff is not supported for cloud provider %q", providerType)
+	}
+
+	if _, err := secretCredentialKeys(providerType, secret); err != nil {
+		return err
+	}
+
+	environment := make(map[string]string, len(o.Environ))
+
+	for _, kv := range o.Environ {
+		if name, value, ok := strings.Cut(kv, "="); ok {
+			environment[name] = value
+		}
+	}
+
+	// the aws script replaces the credentials of the secret with the temporary credentials of the assumed role
+	assumesRole := providerType == "aws" && valueOrSecretField(o.AWSRoleARN, secret, "roleARN") != ""
+
+	result := &environmentDiff{Shoot: shoot.Name}
+
+	for _, field := range fields {
+		var status string
+
+		switch value, ok := environment[field.Env]; {
+		case assumesRole:
+			status = variableUnknown
+		case !ok:
+			status = variableAdded
+		case sameVariableValue(value, scriptValue(providerType, secret, field.Key)):
+			status = variableUnchanged
+		default:
+			status = variableChanged
+		}
+
+		result.Variables = append(result.Variables, variableDiff{Name: field.Env, Status: status})
+	}
+
+	return o.PrintObject(result)
+}
+
+// scriptValue returns the value the script sets for the given field of the cloud provider secret. With openstack
+// application credentials, the script clears the variables of the password authentication.
+func scriptValue(providerType string, secret *corev1.Secret, key string) string {
+	if providerType == "openstack" {
+		if _, ok := secret.Data["applicationCredentialSecret"]; ok && slices.Contains([]string{"tenantName", "username", "password"}, key) {
+			return ""
+		}
+	}
+
+	return string(secret.Data[key])
+}
+
+// sameVariableValue returns true if the values are equal. JSON values, e.g. the service account of gcp, are
+// compared by content, as the script exports them in compact form.
+func sameVariableValue(current, value string) bool {
+	if current == value {
+		return true
+	}
+
+	var currentJSON, valueJSON interface{}
+	if json.Unmarshal([]byte(current), &currentJSON) != nil || json.Unmarshal([]byte(value), &valueJSON) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(currentJSON, valueJSON)
+}
@@ -0,0 +1,168 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package providerenv_test
+
+import (
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/gardenctl-v2/pkg/cmd/providerenv"
+)
+
+var _ = Describe("Provider Env Diff", func() {
+	var (
+		options *providerenv.TestOptions
+		shoot   *gardencorev1beta1.Shoot
+		secret  *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		options = providerenv.NewOptions()
+
+		shoot = &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shoot",
+				Namespace: "garden-project",
+			},
+			Spec: gardencorev1beta1.ShootSpec{
+				Provider: gardencorev1beta1.Provider{
+					Type: "aws",
+				},
+			},
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "secret",
+				Namespace: "garden-project",
+			},
+			Data: map[string][]byte{
+				"accessKeyID":     []byte("access-key-id"),
+				"secretAccessKey": []byte("secret-access-key"),
+			},
+		}
+	})
+
+	It("should report the variables that are not set as added", func() {
+		options.Environ = []string{"PATH=/usr/bin"}
+
+		Expect(options.PrintDiff(shoot, secret)).To(Succeed())
+		Expect(options.String()).To(Equal("added:     AWS_ACCESS_KEY_ID\nadded:     AWS_SECRET_ACCESS_KEY\n"))
+	})
+
+	It("should report the variables with another value as changed", func() {
+		options.Environ = []string{
+			"AWS_ACCESS_KEY_ID=access-key-id",
+			"AWS_SECRET_ACCESS_KEY=old-secret-access-key",
+		}
+
+		Expect(options.PrintDiff(shoot, secret)).To(Succeed())
+		Expect(options.String()).To(Equal("unchanged: AWS_ACCESS_KEY_ID\nchanged:   AWS_SECRET_ACCESS_KEY\n"))
+		Expect(options.String()).NotTo(ContainSubstring("secret-access-key"))
+	})
+
+	It("should report a variable set to an empty value as changed", func() {
+		options.Environ = []string{"AWS_ACCESS_KEY_ID=", "AWS_SECRET_ACCESS_KEY=secret-access-key"}
+		options.Output = "json"
+
+		Expect(options.PrintDiff(shoot, secret)).To(Succeed())
+		Expect(options.String()).To(MatchJSON(`{
+			"shoot": "shoot",
+			"variables": [
+				{"name": "AWS_ACCESS_KEY_ID", "status": "changed"},
+				{"name": "AWS_SECRET_ACCESS_KEY", "status": "unchanged"}
+			]
+		}`))
+	})
+
+	It("should report the credential variables as unknown if a role is assumed with --aws-role-arn", func() {
+		options.Environ = []string{"AWS_ACCESS_KEY_ID=access-key-id"}
+		options.AWSRoleARN = "arn:aws:iam::123456789012:role/gardenctl"
+
+		Expect(options.PrintDiff(shoot, secret)).To(Succeed())
+		Expect(options.String()).To(Equal("unknown:   AWS_ACCESS_KEY_ID\nunknown:   AWS_SECRET_ACCESS_KEY\n"))
+	})
+
+	It("should report the credential variables as unknown if a role is assumed with the roleARN of the secret", func() {
+		options.Environ = []string{}
+		secret.Data["roleARN"] = []byte("arn:aws:iam::123456789012:role/gardenctl")
+
+		Expect(options.PrintDiff(shoot, secret)).To(Succeed())
+		Expect(options.String()).To(Equal("unknown:   AWS_ACCESS_KEY_ID\nunknown:   AWS_SECRET_ACCESS_KEY\n"))
+	})
+
+	Context("when the cloud provider is openstack", func() {
+		BeforeEach(func() {
+			shoot.Spec.Provider.Type = "openstack"
+		})
+
+		It("should compare the variables of the password authentication", func() {
+			secret.Data = map[string][]byte{
+				"domainName": []byte("domain"),
+				"tenantName": []byte("tenant"),
+				"username":   []byte("user"),
+				"password":   []byte("secret"),
+			}
+			options.Environ = []string{
+				"OS_PROJECT_DOMAIN_NAME=domain",
+				"OS_USER_DOMAIN_NAME=domain",
+				"OS_TENANT_NAME=other-tenant",
+				"OS_USERNAME=user",
+				"OS_APPLICATION_CREDENTIAL_SECRET=",
+			}
+
+			Expect(options.PrintDiff(shoot, secret)).To(Succeed())
+			Expect(options.String()).To(Equal(`unchanged: OS_PROJECT_DOMAIN_NAME
+unchanged: OS_USER_DOMAIN_NAME
+changed:   OS_TENANT_NAME
+unchanged: OS_USERNAME
+added:     OS_PASSWORD
+added:     OS_APPLICATION_CREDENTIAL_ID
+added:     OS_APPLICATION_CREDENTIAL_NAME
+unchanged: OS_APPLICATION_CREDENTIAL_SECRET
+`))
+		})
+
+		It("should compare the cleared variables of the password authentication with application credentials", func() {
+			secret.Data = map[string][]byte{
+				"domainName":                  []byte("domain"),
+				"tenantName":                  []byte("tenant"),
+				"applicationCredentialID":     []byte("id"),
+				"applicationCredentialSecret": []byte("secret"),
+			}
+			options.Environ = []string{
+				"OS_TENANT_NAME=",
+				"OS_APPLICATION_CREDENTIAL_ID=id",
+				"OS_APPLICATION_CREDENTIAL_SECRET=old-secret",
+			}
+			options.Output = "json"
+
+			Expect(options.PrintDiff(shoot, secret)).To(Succeed())
+			Expect(options.String()).To(MatchJSON(`{
+				"shoot": "shoot",
+				"variables": [
+					{"name": "OS_PROJECT_DOMAIN_NAME", "status": "added"},
+					{"name": "OS_USER_DOMAIN_NAME", "status": "added"},
+					{"name": "OS_TENANT_NAME", "status": "unchanged"},
+					{"name": "OS_USERNAME", "status": "added"},
+					{"name": "OS_PASSWORD", "status": "added"},
+					{"name": "OS_APPLICATION_CREDENTIAL_ID", "status": "unchanged"},
+					{"name": "OS_APPLICATION_CREDENTIAL_NAME", "status": "added"},
+					{"name": "OS_APPLICATION_CREDENTIAL_SECRET", "status": "changed"}
+				]
+			}`))
+		})
+	})
+
+	It("should fail for an unsupported cloud provider", func() {
+		shoot.Spec.Provider.Type = "ironcore"
+
+		Expect(options.PrintDiff(shoot, secret)).To(MatchError(`--diff is not supported for cloud provider "ironcore"`))
+	})
+})
@@ -24,15 +24,17 @@ var (
 	GetKeyStoneURL      = getKeyStoneURL
 	GetProviderCLI      = getProviderCLI
 	GetTargetFlags      = getTargetFlags
+	FormatTFVars        = formatTFVars
 )
 
 type TestOptions struct {
 	options
-	out *util.SafeBytesBuffer
+	out    *util.SafeBytesBuffer
+	errOut *util.SafeBytesBuffer
 }
 
 func NewOptions() *TestOptions {
-	streams, _, out, _ := util.NewTestIOStreams()
+	streams, _, out, errOut := util.NewTestIOStreams()
 
 	return &TestOptions{
 		options: options{
@@ -40,7 +42,8 @@ func NewOptions() *TestOptions {
 				IOStreams: streams,
 			},
 		},
-		out: out,
+		out:    out,
+		errOut: errOut,
 	}
 }
 
@@ -48,6 +51,10 @@ func (o *TestOptions) PrintProviderEnv(shoot *gardencorev1beta1.Shoot, secret *c
 	return printProviderEnv(&o.options, shoot, secret, cloudProfile, messages)
 }
 
+func (o *TestOptions) PrintDiff(shoot *gardencorev1beta1.Shoot, secret *corev1.Secret) error {
+	return o.printDiff(shoot, secret)
+}
+
 func (o *TestOptions) GenerateMetadata(cli string) map[string]interface{} {
 	return generateMetadata(&o.options, cli)
 }
@@ -56,6 +63,10 @@ func (o *TestOptions) String() string {
 	return o.out.String()
 }
 
+func (o *TestOptions) ErrString() string {
+	return o.errOut.String()
+}
+
 type TestTemplate interface {
 	env.Template
 	Delegate() *template.Template
@@ -0,0 +1,153 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package providerenv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/spf13/cobra"
+	"github.com/zalando/go-keyring"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
+)
+
+const (
+	// outputKeychain is the output format that stores the credentials in the OS keyring and prints exports reading them back.
+	outputKeychain = "keychain"
+	// keychainService is the service of the OS keyring entries written by provider-env.
+	keychainService = "gardenctl-provider-env"
+)
+
+// keychainKey returns the key of the OS keyring entry with the credentials of the given shoot.
+func keychainKey(gardenName string, shoot *gardencorev1beta1.Shoot) string {
+	return fmt.Sprintf("%s/%s/%s", gardenName, shoot.Namespace, shoot.Name)
+}
+
+// printKeychainExports stores the validated credential fields of the cloud provider secret in the OS keyring under
+// a key scoped to the shoot and prints exports for bash and zsh that read them back with keychain-read. The values
+// are neither printed nor written to disk. With --unset, the keyring entry is deleted and the variables are unset.
+func printKeychainExports(o *options, shoot *gardencorev1beta1.Shoot, secret *corev1.Secret) error {
+	providerType := shoot.Spec.Provider.Type
+
+	fields, ok := credentialFields[providerType]
+	if !ok {
+		return fmt.Errorf("--output keychain is not supported for cloud provider %q", providerType)
+	}
+
+	key := keychainKey(o.Target.GardenName(), shoot)
+
+	if o.Unset {
+		if err := keyring.Delete(keychainService, key); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+			return fmt.Errorf("failed to delete the credentials from the keychain: %w", err)
+		}
+
+		for _, field := range fields {
+			fmt.Fprintf(o.IOStreams.Out, "unset %s;\n", field.Env)
+		}
+
+		return nil
+	}
+
+	if err := o.checkCredentials(o.IOStreams.ErrOut, providerType, secret); err != nil {
+		return err
+	}
+
+	if _, err := secretCredentialKeys(providerType, secret); err != nil {
+		return err
+	}
+
+	values := make(map[string]string, len(fields))
+	for _, field := range fields {
+		values[field.Key] = string(secret.Data[field.Key])
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	if err := keyring.Set(keychainService, key, string(data)); err != nil {
+		return fmt.Errorf("failed to store the credentials in the keychain: %w", err)
+	}
+
+	for _, field := range fields {
+		fmt.Fprintf(o.IOStreams.Out, "export %s=\"$(%s provider-env keychain-read %s %s)\";\n",
+			field.Env, o.CmdPath, util.ShellEscape(key), util.ShellEscape(field.Key))
+	}
+
+	return nil
+}
+
+// NewCmdProviderEnvKeychainRead returns a new provider-env keychain-read command.
+func NewCmdProviderEnvKeychainRead(f util.Factory, ioStreams util.IOStreams) *cobra.Command {
+	o := &keychainReadOptions{
+		Options: base.Options{
+			IOStreams: ioStreams,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:   "keychain-read KEY FIELD",
+		Short: "Print a credential field stored in the OS keychain by provider-env --output keychain",
+		Long: `Print a credential field stored in the OS keychain by provider-env --output keychain.
+The exports printed by provider-env --output keychain invoke this command to read the credentials back,
+so that they are neither printed nor written to disk.`,
+		Example: `# print the access key ID of an aws shoot
+gardenctl provider-env keychain-read my-garden/garden-my-project/my-shoot accessKeyID`,
+		Args: cobra.ExactArgs(2),
+		RunE: base.WrapRunE(o, f),
+	}
+
+	return cmd
+}
+
+type keychainReadOptions struct {
+	base.Options
+
+	// Key is the key of the OS keyring entry, which is scoped to the shoot.
+	Key string
+	// Field is the key of the credential field in the cloud provider secret.
+	Field string
+}
+
+// Complete adapts from the command line args to the data required.
+func (o *keychainReadOptions) Complete(_ util.Factory, _ *cobra.Command, args []string) error {
+	o.Key = args[0]
+	o.Field = args[1]
+
+	return nil
+}
+
+// Run does the actual work of the command.
+func (o *keychainReadOptions) Run(_ util.Factory) error {
+	data, err := keyring.Get(keychainService, o.Key)
+	if err != nil {
+		if errors.Is(err, keyring.ErrNotFound) {
+			return fmt.Errorf("no credentials for %q in the keychain", o.Key)
+		}
+
+		return fmt.Errorf("failed to read the credentials from the keychain: %w", err)
+	}
+
+	values := map[string]string{}
+	if err := json.Unmarshal([]byte(data), &values); err != nil {
+		return fmt.Errorf("invalid credentials for %q in the keychain: %w", o.Key, err)
+	}
+
+	value, ok := values[o.Field]
+	if !ok {
+		return fmt.Errorf("no %q field in the credentials for %q in the keychain", o.Field, o.Key)
+	}
+
+	_, err = fmt.Fprint(o.IOStreams.Out, value)
+
+	return err
+}
@@ -0,0 +1,142 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package providerenv_test
+
+import (
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/zalando/go-keyring"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	utilmocks "github.com/gardener/gardenctl-v2/internal/util/mocks"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/providerenv"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+var _ = Describe("Provider Env Keychain", func() {
+	const key = "test/garden-project/shoot"
+
+	var (
+		ctrl    *gomock.Controller
+		factory *utilmocks.MockFactory
+		options *providerenv.TestOptions
+		shoot   *gardencorev1beta1.Shoot
+		secret  *corev1.Secret
+	)
+
+	readKeychain := func(args ...string) (string, error) {
+		streams, _, out, _ := util.NewTestIOStreams()
+
+		cmd := providerenv.NewCmdProviderEnvKeychainRead(factory, streams)
+		cmd.SetArgs(args)
+		cmd.SetOut(GinkgoWriter)
+		cmd.SetErr(GinkgoWriter)
+
+		err := cmd.Execute()
+
+		return out.String(), err
+	}
+
+	BeforeEach(func() {
+		// use the in-memory keyring instead of the keyring of the OS
+		keyring.MockInit()
+
+		ctrl = gomock.NewController(GinkgoT())
+		factory = utilmocks.NewMockFactory(ctrl)
+
+		options = providerenv.NewOptions()
+		options.Output = "keychain"
+		options.CmdPath = "gardenctl"
+		options.Target = target.NewTarget("test", "project", "", "shoot")
+
+		shoot = &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shoot",
+				Namespace: "garden-project",
+			},
+			Spec: gardencorev1beta1.ShootSpec{
+				Provider: gardencorev1beta1.Provider{
+					Type: "aws",
+				},
+			},
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "secret",
+				Namespace: "garden-project",
+			},
+			Data: map[string][]byte{
+				"accessKeyID":     []byte("access-key-id"),
+				"secretAccessKey": []byte("secret-access-key"),
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should store the credentials and print exports that read them back", func() {
+		Expect(options.PrintProviderEnv(shoot, secret, nil)).To(Succeed())
+
+		Expect(options.String()).To(Equal(
+			`export AWS_ACCESS_KEY_ID="$(gardenctl provider-env keychain-read 'test/garden-project/shoot' 'accessKeyID')";` + "\n" +
+				`export AWS_SECRET_ACCESS_KEY="$(gardenctl provider-env keychain-read 'test/garden-project/shoot' 'secretAccessKey')";` + "\n",
+		))
+		Expect(options.String()).NotTo(ContainSubstring("secret-access-key"))
+
+		Expect(readKeychain(key, "accessKeyID")).To(Equal("access-key-id"))
+		Expect(readKeychain(key, "secretAccessKey")).To(Equal("secret-access-key"))
+	})
+
+	It("should not store invalid credentials", func() {
+		delete(secret.Data, "secretAccessKey")
+
+		Expect(options.PrintProviderEnv(shoot, secret, nil)).To(MatchError(`no "secretAccessKey" data in Secret "secret"`))
+
+		_, err := keyring.Get("gardenctl-provider-env", key)
+		Expect(err).To(MatchError(keyring.ErrNotFound))
+	})
+
+	It("should fail for an unsupported cloud provider", func() {
+		shoot.Spec.Provider.Type = "ironcore"
+
+		Expect(options.PrintProviderEnv(shoot, secret, nil)).To(MatchError(`--output keychain is not supported for cloud provider "ironcore"`))
+	})
+
+	It("should delete the credentials and unset the variables", func() {
+		Expect(options.PrintProviderEnv(shoot, secret, nil)).To(Succeed())
+
+		unsetOptions := providerenv.NewOptions()
+		unsetOptions.Output = "keychain"
+		unsetOptions.Unset = true
+		unsetOptions.Target = options.Target
+
+		Expect(unsetOptions.PrintProviderEnv(shoot, secret, nil)).To(Succeed())
+		Expect(unsetOptions.String()).To(Equal("unset AWS_ACCESS_KEY_ID;\nunset AWS_SECRET_ACCESS_KEY;\n"))
+
+		_, err := readKeychain(key, "accessKeyID")
+		Expect(err).To(MatchError(`no credentials for "test/garden-project/shoot" in the keychain`))
+	})
+
+	It("should fail to read an unknown field", func() {
+		Expect(options.PrintProviderEnv(shoot, secret, nil)).To(Succeed())
+
+		_, err := readKeychain(key, "password")
+		Expect(err).To(MatchError(`no "password" field in the credentials for "test/garden-project/shoot" in the keychain`))
+	})
+
+	It("should require the key and the field", func() {
+		_, err := readKeychain(key)
+		Expect(err).To(HaveOccurred())
+	})
+
+})
@@ -0,0 +1,93 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package providerenv
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// openstackCACertKey is the optional field of an openstack secret that holds the CA bundle of a private CA,
+	// which is required to verify the endpoints of the openstack landscape.
+	openstackCACertKey = "caCert"
+	// openstackCACertFilename is the name of the file in the openstack configuration directory that holds the CA bundle.
+	openstackCACertFilename = "cacert.pem"
+)
+
+// openstackCACert returns the CA bundle for the openstack CLI. The file given by the --os-cacert-file flag takes
+// precedence over the caCert field of the secret. If neither is set, nil is returned.
+func (o *options) openstackCACert(secret *corev1.Secret) ([]byte, error) {
+	caCert := secret.Data[openstackCACertKey]
+
+	if o.OSCACertFile != "" {
+		var err error
+
+		caCert, err = os.ReadFile(o.OSCACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+	}
+
+	if len(caCert) == 0 {
+		return nil, nil
+	}
+
+	if err := validateCACertBundle(caCert); err != nil {
+		return nil, err
+	}
+
+	return caCert, nil
+}
+
+// validateCACertBundle checks that the given CA bundle consists of PEM encoded certificates only.
+func validateCACertBundle(data []byte) error {
+	rest := bytes.TrimSpace(data)
+	if len(rest) == 0 {
+		return errors.New("CA bundle must not be empty")
+	}
+
+	for len(rest) > 0 {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return errors.New("CA bundle must only contain PEM encoded certificates")
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("CA bundle must only contain PEM encoded certificates, found PEM block of type %q", block.Type)
+		}
+
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("CA bundle contains an invalid certificate: %w", err)
+		}
+
+		rest = bytes.TrimSpace(rest)
+	}
+
+	return nil
+}
+
+// writeOpenstackCACert writes the CA bundle to the given openstack configuration directory and returns the filename.
+// The directory is scoped per shoot with --shoots, so that concurrently processed shoots do not overwrite their CA bundles.
+func writeOpenstackCACert(configDir string, caCert []byte) (string, error) {
+	filename := filepath.Join(configDir, openstackCACertFilename)
+
+	if err := os.WriteFile(filename, caCert, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write CA bundle: %w", err)
+	}
+
+	return filename, nil
+}
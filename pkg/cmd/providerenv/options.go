@@ -7,19 +7,27 @@ SPDX-License-Identifier: Apache-2.0
 package providerenv
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"strconv"
+	"strings"
+	"time"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	corev1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
 
 	clientgarden "github.com/gardener/gardenctl-v2/internal/client/garden"
 	"github.com/gardener/gardenctl-v2/internal/util"
@@ -32,15 +40,24 @@ import (
 
 type options struct {
 	base.Options
+	credentialValidationOptions
 
 	// Unset resets environment variables and configuration of the cloudprovider CLI for your shell.
 	Unset bool
 	// Shell to configure.
 	Shell string
+	// FishUniversal renders fish universal variables (set -Ux/set -Ue) instead of global ones.
+	FishUniversal bool
 	// GardenDir is the configuration directory of gardenctl.
 	GardenDir string
 	// SessionDir is the session directory of gardenctl.
 	SessionDir string
+	// Session is an optional name that scopes the configuration directory of the cloud provider CLI within the session directory,
+	// so that parallel shells of the same gardenctl session do not share the cloud provider CLI configuration.
+	Session string
+	// ConfigDirShoot is an optional shoot name that scopes the configuration directory of the cloud provider CLI to a shoot.
+	// It is set for each shoot of Shoots, so that concurrently processed shoots of the same provider type do not share it.
+	ConfigDirShoot string
 	// CmdPath is the path of the called command.
 	CmdPath string
 	// Target is the target used when executing the command
@@ -55,8 +72,56 @@ type options struct {
 	// ConfirmAccessRestriction, when set to true, implies the user's understanding of the access restrictions for the targeted shoot.
 	// When set to false and access restrictions are present, the command will terminate with an error.
 	ConfirmAccessRestriction bool
+	// SecretFromFile is the path to a YAML or JSON file containing the cloud provider secret.
+	// If set, the secret is read from this file instead of being fetched from the garden cluster.
+	SecretFromFile string
+	// CloudProfileFromFile is the path to a YAML or JSON file containing the CloudProfile or NamespacedCloudProfile.
+	// If set, the cloud profile is read from this file instead of being fetched from the garden cluster.
+	CloudProfileFromFile string
+	// CloudProfile is the name of a cloud profile that overrides the one referenced by the shoot.
+	// The name can be prefixed with the kind, e.g. NamespacedCloudProfile/my-profile, and defaults to a CloudProfile.
+	CloudProfile string
+	// Shoots is a list of shoot names for which the cloud provider CLI configuration is generated in one call.
+	// The shoots are looked up in the targeted garden and project.
+	Shoots []string
+	// WaitShoot is the maximum duration to wait for the targeted shoot to appear, e.g. if it has just been created.
+	WaitShoot time.Duration
+	// MaxConcurrentShoots is the maximum number of shoots that are processed concurrently if Shoots is set.
+	MaxConcurrentShoots int
+	// WithKubeconfig appends the KUBECONFIG export of kubectl-env for the target to the script.
+	WithKubeconfig bool
+	// Kubeconfig is the filename the appended KUBECONFIG export points to.
+	Kubeconfig string
+	// OSCACertFile is the path to a PEM encoded CA bundle that is exported as OS_CACERT for openstack.
+	// It takes precedence over the caCert field of the cloud provider secret.
+	OSCACertFile string
+	// AWSRoleARN is the ARN of the IAM role that the aws script assumes with the credentials of the cloud provider secret.
+	// It takes precedence over the roleARN field of the cloud provider secret.
+	AWSRoleARN string
+	// AWSExternalID is the external ID passed when assuming AWSRoleARN.
+	// It takes precedence over the externalID field of the cloud provider secret.
+	AWSExternalID string
+	// AWSSTSEndpoint is the URL of the STS endpoint used to assume AWSRoleARN.
+	// It takes precedence over the stsEndpoint field of the cloud provider secret.
+	AWSSTSEndpoint string
+	// ValidateOnly validates the credentials in the cloud provider secret of the targeted shoot and prints the result
+	// instead of the script. Nothing is written to disk.
+	ValidateOnly bool
+	// Diff prints which credential variables the script would add or change in the current environment instead of
+	// the script. The values are redacted.
+	Diff bool
+	// Environ is the current environment in the form of os.Environ, which the script is compared with by Diff.
+	Environ []string
 }
 
+var (
+	// sessionNameRegexp matches the valid names of the --session flag.
+	sessionNameRegexp = regexp.MustCompile(`^[\w-]{1,64}$`)
+)
+
+// defaultMaxConcurrentShoots is the default maximum number of shoots that are processed concurrently.
+const defaultMaxConcurrentShoots = 4
+
 // Complete adapts from the command line args to the data required.
 func (o *options) Complete(f util.Factory, cmd *cobra.Command, _ []string) error {
 	ctx := f.Context()
@@ -76,9 +141,20 @@ func (o *options) Complete(f util.Factory, cmd *cobra.Command, _ []string) error
 		return err
 	}
 
+	if o.Shell == "" && o.Output == "" && !o.ValidateOnly && !o.Diff {
+		// the configured default shell is used if provider-env is called without a shell sub-command
+		if o.Shell = manager.Configuration().DefaultShell; o.Shell != "" {
+			o.CmdPath = cmd.CommandPath()
+		}
+	}
+
 	o.SessionDir = manager.SessionDir()
 	o.TargetFlags = f.TargetFlags()
 
+	if o.Diff && o.Environ == nil {
+		o.Environ = os.Environ()
+	}
+
 	if o.Force {
 		o.ConfirmAccessRestriction = true
 
@@ -90,19 +166,154 @@ func (o *options) Complete(f util.Factory, cmd *cobra.Command, _ []string) error
 
 // Validate validates the provided command options.
 func (o *options) Validate() error {
+	if o.ValidateOnly {
+		return o.validateValidateOnly()
+	}
+
+	if o.Diff {
+		return o.validateDiff()
+	}
+
 	if o.Shell == "" && o.Output == "" {
 		return pflag.ErrHelp
 	}
 
 	// Usually, we would check and return an error if both shell and output are set (not empty). However, this is not required because the output flag is not set for the shell subcommands.
 
+	if o.FishUniversal && o.Shell != "fish" {
+		return errors.New("--fish-universal can only be used with the fish shell")
+	}
+
+	if o.WaitShoot < 0 {
+		return errors.New("--wait-shoot must not be negative")
+	}
+
+	if o.Session != "" && !sessionNameRegexp.MatchString(o.Session) {
+		return fmt.Errorf("invalid session name %q: must only contain alphanumeric characters, underscore and dash and have a maximum length of 64", o.Session)
+	}
+
+	if o.CloudProfile != "" {
+		if o.CloudProfileFromFile != "" {
+			return errors.New("--cloud-profile and --cloud-profile-from-file cannot be used together")
+		}
+
+		if _, err := parseCloudProfileReference(o.CloudProfile); err != nil {
+			return err
+		}
+	}
+
+	if len(o.Shoots) > 0 {
+		if err := o.validateShoots(); err != nil {
+			return err
+		}
+	}
+
+	if o.WithKubeconfig && o.Shell == "" {
+		return errors.New("--with-kubeconfig cannot be combined with --output")
+	}
+
 	if o.Shell != "" {
 		s := env.Shell(o.Shell)
 
 		return s.Validate()
 	}
 
-	return o.Options.Validate()
+	if o.Output == outputKeychain {
+		if len(o.Shoots) > 0 {
+			return errors.New("--shoots cannot be combined with --output keychain")
+		}
+
+		return nil
+	}
+
+	if o.Output == outputSecretYAML {
+		if len(o.Shoots) > 0 {
+			return errors.New("--shoots cannot be combined with --output secret-yaml")
+		}
+
+		if o.Unset {
+			return errors.New("--unset cannot be combined with --output secret-yaml")
+		}
+
+		return nil
+	}
+
+	if o.Output != "" && o.Output != "yaml" && o.Output != "json" {
+		return errors.New("--output must be one of 'yaml', 'json', 'secret-yaml' or 'keychain'")
+	}
+
+	return nil
+}
+
+// validateValidateOnly validates the options for validating the credentials only.
+func (o *options) validateValidateOnly() error {
+	if o.Output != "" && o.Output != "yaml" && o.Output != "json" {
+		return errors.New("--validate-only can only be combined with --output 'yaml' or 'json'")
+	}
+
+	switch {
+	case o.Unset:
+		return errors.New("--validate-only cannot be combined with --unset")
+	case len(o.Shoots) > 0:
+		return errors.New("--validate-only cannot be combined with --shoots")
+	case o.WithKubeconfig:
+		return errors.New("--validate-only cannot be combined with --with-kubeconfig")
+	case o.InsecureSkipCredentialValidation:
+		return errors.New("--validate-only cannot be combined with --insecure-skip-credential-validation")
+	case o.Diff:
+		return errors.New("--validate-only cannot be combined with --diff")
+	}
+
+	if o.WaitShoot < 0 {
+		return errors.New("--wait-shoot must not be negative")
+	}
+
+	return nil
+}
+
+// validateDiff validates the options for comparing the script with the current environment.
+func (o *options) validateDiff() error {
+	if o.Output != "" && o.Output != "yaml" && o.Output != "json" {
+		return errors.New("--diff can only be combined with --output 'yaml' or 'json'")
+	}
+
+	switch {
+	case o.Unset:
+		return errors.New("--diff cannot be combined with --unset")
+	case len(o.Shoots) > 0:
+		return errors.New("--diff cannot be combined with --shoots")
+	case o.WithKubeconfig:
+		return errors.New("--diff cannot be combined with --with-kubeconfig")
+	}
+
+	if o.WaitShoot < 0 {
+		return errors.New("--wait-shoot must not be negative")
+	}
+
+	return nil
+}
+
+// validateShoots validates the options for generating the cloud provider CLI configuration of multiple shoots.
+func (o *options) validateShoots() error {
+	if o.Output == "" {
+		return errors.New("--shoots can only be used with the --output flag")
+	}
+
+	if o.SecretFromFile != "" || o.CloudProfileFromFile != "" {
+		return errors.New("--shoots cannot be combined with --secret-from-file or --cloud-profile-from-file")
+	}
+
+	if o.MaxConcurrentShoots < 1 {
+		return errors.New("--max-concurrent-shoots must be at least 1")
+	}
+
+	for i, name := range o.Shoots {
+		if name == "" {
+			return fmt.Errorf("shoots[%d] must not be empty", i)
+		}
+	}
+
+	return nil
 }
 
 // AddFlags binds the command options to a given flagset.
@@ -110,6 +321,35 @@ func (o *options) AddFlags(flags *pflag.FlagSet) {
 	flags.BoolVarP(&o.Force, "force", "f", false, "Deprecated. Use --confirm-access-restriction instead. Generate the script even if there are access restrictions to be confirmed.")
 	flags.BoolVarP(&o.ConfirmAccessRestriction, "confirm-access-restriction", "y", o.ConfirmAccessRestriction, "Confirm any access restrictions. Set this flag only if you are completely aware of the access restrictions.")
 	flags.BoolVarP(&o.Unset, "unset", "u", o.Unset, fmt.Sprintf("Generate the script to unset the cloud provider CLI environment variables and logout for %s", o.Shell))
+	flags.BoolVar(&o.FishUniversal, "fish-universal", o.FishUniversal, "Use fish universal variables (set -Ux) instead of global variables. Only valid with the fish shell.")
+	flags.StringVar(&o.Session, "session", o.Session, "Name that scopes the configuration directory of the cloud provider CLI within the gardenctl session, so that parallel shells targeting the same shoot do not share it. Pass the same name together with --unset, the hinted commands of the generated script already include it.")
+	flags.StringVar(&o.SecretFromFile, "secret-from-file", o.SecretFromFile, "Read the cloud provider secret from the given YAML or JSON file instead of fetching it from the garden cluster. Intended for testing.")
+	flags.StringVar(&o.CloudProfile, "cloud-profile", o.CloudProfile, "Name of the cloud profile to use instead of the one referenced by the shoot, e.g. for debugging. Prefix the name with NamespacedCloudProfile/ to use a NamespacedCloudProfile. The cloud profile must have the same provider type as the shoot.")
+	o.credentialValidationOptions.addFlags(flags)
+	flags.StringVar(&o.CloudProfileFromFile, "cloud-profile-from-file", o.CloudProfileFromFile, "Read the CloudProfile or NamespacedCloudProfile from the given YAML or JSON file instead of fetching it from the garden cluster. Intended for testing.")
+	flags.DurationVar(&o.WaitShoot, "wait-shoot", o.WaitShoot, "Maximum duration to wait for the targeted shoot to appear, e.g. if it has just been created. By default the shoot must already exist.")
+	flags.StringVar(&o.OSCACertFile, "os-cacert-file", o.OSCACertFile, "Path to a PEM encoded CA bundle for openstack landscapes with a private CA. It is written to the session and exported as OS_CACERT. Takes precedence over the caCert field of the cloud provider secret.")
+	flags.StringVar(&o.AWSRoleARN, "aws-role-arn", o.AWSRoleARN, "ARN of the IAM role that the aws script assumes with the credentials of the cloud provider secret, so that the session credentials of the role are exported. Takes precedence over the roleARN field of the cloud provider secret.")
+	flags.StringVar(&o.AWSExternalID, "aws-external-id", o.AWSExternalID, "External ID passed when assuming the IAM role of --aws-role-arn. Takes precedence over the externalID field of the cloud provider secret.")
+	flags.StringVar(&o.AWSSTSEndpoint, "aws-sts-endpoint", o.AWSSTSEndpoint, "HTTPS URL of the STS endpoint used to assume the IAM role of --aws-role-arn. Takes precedence over the stsEndpoint field of the cloud provider secret.")
+	flags.BoolVar(&o.WithKubeconfig, "with-kubeconfig", o.WithKubeconfig, "Append the KUBECONFIG export of kubectl-env for the target to the script, so that one eval configures both the cloud provider CLI and kubectl. Combined with --unset, both are reset.")
+}
+
+// AddOutputFlags binds the output flags to a given flagset.
+func (o *options) AddOutputFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&o.Output, "output", "o", o.Output, "One of 'yaml', 'json', 'secret-yaml' or 'keychain'. The format 'secret-yaml' prints the credentials of the cloud provider secret as Kubernetes Secret manifest named after the shoot. The format 'keychain' stores the credentials in the OS keychain and prints exports for bash and zsh that read them back.")
+	flags.BoolVar(&o.ValidateOnly, "validate-only", o.ValidateOnly, "Only validate the credentials in the cloud provider secret of the targeted shoot and print a report instead of the script. Nothing is written to disk. The command fails if the credentials are invalid. Can be combined with --output 'yaml' or 'json'.")
+	flags.BoolVar(&o.Diff, "diff", o.Diff, "Print which credential variables the script would add or change in the current environment instead of the script. The values are redacted. The credentials of an assumed AWS role are reported as unknown, as they are only known when the script runs. Can be combined with --output 'yaml' or 'json'.")
+}
+
+// AddBatchFlags binds the options for generating the cloud provider CLI configuration of multiple shoots to a given flagset.
+func (o *options) AddBatchFlags(flags *pflag.FlagSet) {
+	if o.MaxConcurrentShoots == 0 {
+		o.MaxConcurrentShoots = defaultMaxConcurrentShoots
+	}
+
+	flags.StringSliceVar(&o.Shoots, "shoots", o.Shoots, "Comma separated list of shoots of the targeted project for which the cloud provider CLI configuration is printed as a map from shoot name to configuration. Requires the --output flag.")
+	flags.IntVar(&o.MaxConcurrentShoots, "max-concurrent-shoots", o.MaxConcurrentShoots, "Maximum number of shoots processed concurrently when using the --shoots flag.")
 }
 
 // Run does the actual work of the command.
@@ -137,6 +377,10 @@ func (o *options) Run(f util.Factory) error {
 		return fmt.Errorf("failed to create garden cluster client: %w", err)
 	}
 
+	if len(o.Shoots) > 0 {
+		return o.runBatch(ctx, client, manager.Configuration())
+	}
+
 	if o.Target.ShootName() == "" && o.Target.SeedName() != "" {
 		shoot, err := client.GetShootOfManagedSeed(ctx, o.Target.SeedName())
 		if err != nil {
@@ -161,14 +405,73 @@ func (o *options) Run(f util.Factory) error {
 		return target.ErrNoShootTargeted
 	}
 
-	shoot, err := client.FindShoot(ctx, o.Target.AsListOption())
+	shoot, err := clientgarden.FindShootWithRetry(ctx, client, o.WaitShoot, o.Target.AsListOption())
 	if err != nil {
 		return err
 	}
 
+	var secret *corev1.Secret
+
+	if o.SecretFromFile != "" {
+		secret, err = readSecretFromFile(o.SecretFromFile)
+	} else {
+		secret, err = getCredentialsSecret(ctx, client, shoot)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if o.ValidateOnly {
+		return o.validateOnly(shoot, secret)
+	}
+
+	if o.Diff {
+		return o.printDiff(shoot, secret)
+	}
+
+	cloudProfile, err := o.getCloudProfile(ctx, client, shoot)
+	if err != nil {
+		return err
+	}
+
+	// check access restrictions
+	messages, err := checkAccessRestrictions(manager.Configuration(), o.Target.GardenName(), shoot)
+	if err != nil {
+		return err
+	}
+
+	if o.WithKubeconfig && !o.Unset {
+		o.Kubeconfig, err = kubeconfigFilename(ctx, manager, o.Target)
+		if err != nil {
+			return err
+		}
+	}
+
+	return printProviderEnv(o, shoot, secret, cloudProfile, messages)
+}
+
+// getCloudProfile returns the cloud profile used for the given shoot, considering the cloud profile overrides.
+func (o *options) getCloudProfile(ctx context.Context, client clientgarden.Client, shoot *gardencorev1beta1.Shoot) (*clientgarden.CloudProfileUnion, error) {
+	switch {
+	case o.CloudProfileFromFile != "":
+		return readCloudProfileFromFile(o.CloudProfileFromFile)
+	case o.CloudProfile != "":
+		return getCloudProfileOverride(ctx, client, o.CloudProfile, shoot)
+	default:
+		if shoot.Spec.CloudProfile == nil {
+			return nil, fmt.Errorf("shoot %q does not reference a cloud profile", shoot.Name)
+		}
+
+		return client.GetCloudProfile(ctx, *shoot.Spec.CloudProfile)
+	}
+}
+
+// getCredentialsSecret returns the cloud provider secret referenced by the secret or credentials binding of the shoot.
+func getCredentialsSecret(ctx context.Context, client clientgarden.Client, shoot *gardencorev1beta1.Shoot) (*corev1.Secret, error) {
 	if (shoot.Spec.SecretBindingName == nil || *shoot.Spec.SecretBindingName == "") &&
 		(shoot.Spec.CredentialsBindingName == nil || *shoot.Spec.CredentialsBindingName == "") {
-		return fmt.Errorf("shoot %q is not bound to a cloud provider credential", o.Target.ShootName())
+		return nil, fmt.Errorf("shoot %q is not bound to a cloud provider credential", shoot.Name)
 	}
 
 	var (
@@ -179,7 +482,7 @@ func (o *options) Run(f util.Factory) error {
 	if shoot.Spec.SecretBindingName != nil && *shoot.Spec.SecretBindingName != "" {
 		secretBinding, err := client.GetSecretBinding(ctx, shoot.Namespace, *shoot.Spec.SecretBindingName)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		secretName = secretBinding.SecretRef.Name
@@ -188,34 +491,109 @@ func (o *options) Run(f util.Factory) error {
 		// TODO: This code should eventually support credentials of type workload identity
 		credentialsBinding, err := client.GetCredentialsBinding(ctx, shoot.Namespace, *shoot.Spec.CredentialsBindingName)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		secretName = credentialsBinding.CredentialsRef.Name
 		secretNamespace = credentialsBinding.CredentialsRef.Namespace
 	}
 
-	secret, err := client.GetSecret(ctx, secretNamespace, secretName)
+	return client.GetSecret(ctx, secretNamespace, secretName)
+}
+
+// parseCloudProfileReference parses a cloud profile name, which is optionally prefixed with its kind.
+func parseCloudProfileReference(value string) (*gardencorev1beta1.CloudProfileReference, error) {
+	kind, name, found := strings.Cut(value, "/")
+	if !found {
+		kind, name = corev1beta1constants.CloudProfileReferenceKindCloudProfile, value
+	}
+
+	if kind != corev1beta1constants.CloudProfileReferenceKindCloudProfile && kind != corev1beta1constants.CloudProfileReferenceKindNamespacedCloudProfile {
+		return nil, fmt.Errorf("invalid cloud profile kind %q, must be %s or %s", kind,
+			corev1beta1constants.CloudProfileReferenceKindCloudProfile, corev1beta1constants.CloudProfileReferenceKindNamespacedCloudProfile)
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("invalid cloud profile %q, name must not be empty", value)
+	}
+
+	return &gardencorev1beta1.CloudProfileReference{Kind: kind, Name: name}, nil
+}
+
+// getCloudProfileOverride fetches the given cloud profile and ensures that it is compatible with the provider type of the shoot.
+func getCloudProfileOverride(ctx context.Context, client clientgarden.Client, value string, shoot *gardencorev1beta1.Shoot) (*clientgarden.CloudProfileUnion, error) {
+	ref, err := parseCloudProfileReference(value)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	cloudProfile, err := client.GetCloudProfile(ctx, *ref)
+	if err != nil {
+		return nil, err
 	}
 
-	if shoot.Spec.CloudProfile == nil {
-		return fmt.Errorf("shoot %q does not reference a cloud profile", o.Target.ShootName())
+	if providerType := cloudProfile.GetCloudProfileSpec().Type; providerType != shoot.Spec.Provider.Type {
+		return nil, fmt.Errorf("%s %q has provider type %q, which does not match the provider type %q of shoot %q",
+			ref.Kind, ref.Name, providerType, shoot.Spec.Provider.Type, shoot.Name)
 	}
 
-	cloudProfile, err := client.GetCloudProfile(ctx, *shoot.Spec.CloudProfile)
+	return cloudProfile, nil
+}
+
+// readSecretFromFile reads a secret from a YAML or JSON file. Values of stringData are merged into data.
+func readSecretFromFile(filename string) (*corev1.Secret, error) {
+	content, err := os.ReadFile(filename) // #nosec G304 -- Accepting user-provided file path by design
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to read secret file: %w", err)
 	}
 
-	// check access restrictions
-	messages, err := o.checkAccessRestrictions(manager.Configuration(), o.Target.GardenName(), shoot)
+	secret := &corev1.Secret{}
+	if err := yaml.Unmarshal(content, secret); err != nil {
+		return nil, fmt.Errorf("failed to decode secret file %q: %w", filename, err)
+	}
+
+	if len(secret.StringData) > 0 && secret.Data == nil {
+		secret.Data = make(map[string][]byte, len(secret.StringData))
+	}
+
+	for key, value := range secret.StringData {
+		secret.Data[key] = []byte(value)
+	}
+
+	return secret, nil
+}
+
+// readCloudProfileFromFile reads a CloudProfile or NamespacedCloudProfile from a YAML or JSON file.
+// If the kind is omitted, the content is decoded as CloudProfile.
+func readCloudProfileFromFile(filename string) (*clientgarden.CloudProfileUnion, error) {
+	content, err := os.ReadFile(filename) // #nosec G304 -- Accepting user-provided file path by design
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to read cloud profile file: %w", err)
 	}
 
-	return printProviderEnv(o, shoot, secret, cloudProfile, messages)
+	typeMeta := &metav1.TypeMeta{}
+	if err := yaml.Unmarshal(content, typeMeta); err != nil {
+		return nil, fmt.Errorf("failed to decode cloud profile file %q: %w", filename, err)
+	}
+
+	switch typeMeta.Kind {
+	case "", corev1beta1constants.CloudProfileReferenceKindCloudProfile:
+		cloudProfile := &gardencorev1beta1.CloudProfile{}
+		if err := yaml.Unmarshal(content, cloudProfile); err != nil {
+			return nil, fmt.Errorf("failed to decode cloud profile file %q: %w", filename, err)
+		}
+
+		return &clientgarden.CloudProfileUnion{CloudProfile: cloudProfile}, nil
+	case corev1beta1constants.CloudProfileReferenceKindNamespacedCloudProfile:
+		namespacedCloudProfile := &gardencorev1beta1.NamespacedCloudProfile{}
+		if err := yaml.Unmarshal(content, namespacedCloudProfile); err != nil {
+			return nil, fmt.Errorf("failed to decode cloud profile file %q: %w", filename, err)
+		}
+
+		return &clientgarden.CloudProfileUnion{NamespacedCloudProfile: namespacedCloudProfile}, nil
+	default:
+		return nil, fmt.Errorf("unknown CloudProfile kind in file %q: %s", filename, typeMeta.Kind)
+	}
 }
 
 func printProviderEnv(o *options, shoot *gardencorev1beta1.Shoot, secret *corev1.Secret, cloudProfile *clientgarden.CloudProfileUnion, messages ac.AccessRestrictionMessages) error {
@@ -247,18 +625,69 @@ func printProviderEnv(o *options, shoot *gardencorev1beta1.Shoot, secret *corev1
 		}
 	}
 
+	if o.Output == outputSecretYAML {
+		return printCredentialsSecret(o, shoot, secret)
+	}
+
+	if o.Output == outputKeychain {
+		return printKeychainExports(o, shoot, secret)
+	}
+
 	data, err := generateData(o, shoot, secret, cloudProfile, providerType, metadata)
 	if err != nil {
 		return err
 	}
 
+	if !o.Unset {
+		if err := o.checkCredentialsRotation(secret); err != nil {
+			return err
+		}
+	}
+
 	if o.Output != "" {
 		return o.PrintObject(data)
 	}
 
+	if o.WithKubeconfig {
+		if err := printKubeconfigEnv(o, metadata); err != nil {
+			return err
+		}
+	}
+
 	return o.Template.ExecuteTemplate(o.IOStreams.Out, o.Shell, data)
 }
 
+// kubeconfigFilename returns the kubeconfig filename of the given target, as it would be exported by kubectl-env.
+func kubeconfigFilename(ctx context.Context, manager target.Manager, t target.Target) (string, error) {
+	if manager.Configuration().SymlinkTargetKubeconfig() {
+		return filepath.Join(manager.SessionDir(), "kubeconfig.yaml"), nil
+	}
+
+	config, err := manager.ClientConfig(ctx, t)
+	if err != nil {
+		return "", fmt.Errorf("failed to get kubeconfig of the target: %w", err)
+	}
+
+	return manager.WriteClientConfig(config)
+}
+
+// printKubeconfigEnv prints the statement of the kubectl-env script that exports or, if unset, resets the
+// KUBECONFIG environment variable. The usage hint of the cloud provider CLI script covers both.
+func printKubeconfigEnv(o *options, metadata map[string]interface{}) error {
+	t := env.NewTemplate("helpers")
+
+	if err := t.ParseFiles(filepath.Join(o.GardenDir, "templates", "kubernetes.tmpl")); err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"__meta":   metadata,
+		"filename": o.Kubeconfig,
+	}
+
+	return t.ExecuteTemplate(o.IOStreams.Out, "kubeconfig-"+o.Shell, data)
+}
+
 func generateData(o *options, shoot *gardencorev1beta1.Shoot, secret *corev1.Secret, cloudProfile *clientgarden.CloudProfileUnion, providerType string, metadata map[string]interface{}) (map[string]interface{}, error) {
 	data := map[string]interface{}{
 		"__meta": metadata,
@@ -269,10 +698,24 @@ func generateData(o *options, shoot *gardencorev1beta1.Shoot, secret *corev1.Sec
 		data[key] = string(value)
 	}
 
+	if err := o.checkCredentials(o.IOStreams.ErrOut, providerType, secret); err != nil {
+		return nil, err
+	}
+
+	if providerType != "aws" && (o.AWSRoleARN != "" || o.AWSExternalID != "" || o.AWSSTSEndpoint != "") {
+		return nil, fmt.Errorf("assuming an AWS role is not supported for cloud provider %q", providerType)
+	}
+
 	switch providerType {
+	case "aws":
+		if !o.Unset {
+			if err := o.awsAssumeRole(secret, data); err != nil {
+				return nil, err
+			}
+		}
 	case "azure":
 		if !o.Unset {
-			configDir, err := createProviderConfigDir(o.SessionDir, providerType)
+			configDir, err := createProviderConfigDir(o.SessionDir, o.Session, o.ConfigDirShoot, providerType)
 			if err != nil {
 				return nil, err
 			}
@@ -288,7 +731,7 @@ func generateData(o *options, shoot *gardencorev1beta1.Shoot, secret *corev1.Sec
 		}
 
 		if !o.Unset {
-			configDir, err := createProviderConfigDir(o.SessionDir, providerType)
+			configDir, err := createProviderConfigDir(o.SessionDir, o.Session, o.ConfigDirShoot, providerType)
 			if err != nil {
 				return nil, err
 			}
@@ -320,6 +763,34 @@ func generateData(o *options, shoot *gardencorev1beta1.Shoot, secret *corev1.Sec
 			data["applicationCredentialName"] = ""
 			data["applicationCredentialSecret"] = ""
 		}
+
+		caCert, err := o.openstackCACert(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		if caCert != nil && !o.Unset {
+			configDir, err := createProviderConfigDir(o.SessionDir, o.Session, o.ConfigDirShoot, providerType)
+			if err != nil {
+				return nil, err
+			}
+
+			if data["caCertFile"], err = writeOpenstackCACert(configDir, caCert); err != nil {
+				return nil, err
+			}
+		}
+	case "vsphere":
+		endpoint, insecure, err := getVSphereEndpoint(cloudProfile, shoot.Spec.Region)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := validateVSphereEndpoint(endpoint); err != nil {
+			return nil, err
+		}
+
+		data["url"] = endpoint
+		data["insecure"] = strconv.FormatBool(insecure)
 	}
 
 	filename := filepath.Join(o.GardenDir, "templates", providerType+".tmpl")
@@ -337,6 +808,27 @@ func generateMetadata(o *options, cli string) map[string]interface{} {
 	metadata["cli"] = cli
 	metadata["targetFlags"] = getTargetFlags(o.Target)
 
+	// the hinted commands must use the same universal variables, session and KUBECONFIG handling as the script
+	var hintFlags []string
+
+	if o.FishUniversal {
+		metadata["fishUniversal"] = true
+
+		hintFlags = append(hintFlags, "--fish-universal")
+	}
+
+	if o.Session != "" {
+		hintFlags = append(hintFlags, "--session "+o.Session)
+	}
+
+	if o.WithKubeconfig {
+		hintFlags = append(hintFlags, "--with-kubeconfig")
+	}
+
+	if len(hintFlags) > 0 {
+		metadata["hintFlags"] = strings.Join(hintFlags, " ")
+	}
+
 	if o.Shell != "" {
 		metadata["shell"] = o.Shell
 		metadata["prompt"] = env.Shell(o.Shell).Prompt(runtime.GOOS)
@@ -353,6 +845,10 @@ func getProviderCLI(providerType string) string {
 		return "gcloud"
 	case "azure":
 		return "az"
+	case "equinixmetal":
+		return "metal"
+	case "vsphere":
+		return "govc"
 	default:
 		return providerType
 	}
@@ -398,9 +894,20 @@ func parseGCPCredentials(secret *corev1.Secret, credentials interface{}) ([]byte
 	return json.Marshal(credentials)
 }
 
-func createProviderConfigDir(sessionDir string, providerType string) (string, error) {
+// createProviderConfigDir creates the configuration directory of the cloud provider CLI in the session directory.
+// If a session name is given, the directory is scoped to this name. If a shoot name is given, the directory
+// is created in a subdirectory of this shoot.
+func createProviderConfigDir(sessionDir string, session string, shoot string, providerType string) (string, error) {
 	cli := getProviderCLI(providerType)
+
 	configDir := filepath.Join(sessionDir, ".config", cli)
+	if shoot != "" {
+		configDir = filepath.Join(sessionDir, ".config", "shoots", shoot, cli)
+	}
+
+	if session != "" {
+		configDir += "-" + session
+	}
 
 	err := os.MkdirAll(configDir, 0o700)
 	if err != nil {
@@ -410,7 +917,7 @@ func createProviderConfigDir(sessionDir string, providerType string) (string, er
 	return configDir, nil
 }
 
-func (o *options) checkAccessRestrictions(cfg *config.Config, gardenName string, shoot *gardencorev1beta1.Shoot) (ac.AccessRestrictionMessages, error) {
+func checkAccessRestrictions(cfg *config.Config, gardenName string, shoot *gardencorev1beta1.Shoot) (ac.AccessRestrictionMessages, error) {
 	if cfg == nil {
 		return nil, errors.New("garden configuration is required")
 	}
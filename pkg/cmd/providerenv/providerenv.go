@@ -11,6 +11,7 @@ import (
 	"runtime"
 
 	"github.com/spf13/cobra"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 
 	"github.com/gardener/gardenctl-v2/internal/util"
 	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
@@ -36,7 +37,30 @@ The generated script sets the environment variables for the cloud provider CLI o
 In addition, the Azure CLI requires to sign in with a service principal and the gcloud CLI requires to activate a service-account.
 Thereby the configuration location of the corresponding cloud provider CLI is pointed to a temporary folder in the
 session directory, so that the standard configuration files in the user's home folder are not affected.
+If multiple shells of the same session target the same shoot, use the --session flag to give each shell its own folder.
+The hinted commands of the generated script, e.g. to unset the configuration, include the --session flag. Unsetting
+the configuration does not remove the folder, it is removed together with the session directory.
 By using the --unset flag you can force a logout or revoke the service-account.
+For openstack landscapes with a private CA, the CA bundle of the caCert field of the cloud provider secret or the
+--os-cacert-file flag is written to the session directory and exported as OS_CACERT.
+For aws shoots accessed via an IAM role, the script assumes the role of the roleARN field of the cloud provider secret
+or the --aws-role-arn flag with the credentials of the secret and exports the session credentials of the role.
+An external ID and STS endpoint can be given in the same way.
+
+With --output secret-yaml the credentials of the cloud provider secret are printed as Kubernetes Secret manifest
+named after the shoot, e.g. to bootstrap infrastructure tooling. The manifest is only printed and never written to disk.
+
+With --output keychain the credentials of the cloud provider secret are stored in the OS keychain, e.g. the macOS
+keychain or the secret service on Linux, under a key scoped to the shoot. Exports for bash and zsh are printed, which
+read the credentials back with the keychain-read sub-command, so that they are neither printed nor written to disk.
+
+With --validate-only the credentials of the cloud provider secret are only validated, e.g. to check them in CI before
+running infrastructure tooling. A report is printed instead of the script, nothing is written to disk and the command
+fails if the credentials are invalid.
+
+With --diff the credential variables the script would set are compared with the current environment, e.g. to check
+before eval'ing the script which of them would change. The added, changed and unchanged variables are printed instead
+of the script, the values are redacted.
 
 The CLI of a corresponding cloud provider must be installed.
 Please refer to the installation instructions of the respective provider:
@@ -46,11 +70,15 @@ Please refer to the installation instructions of the respective provider:
 * Openstack (openstack) - https://docs.openstack.org/newton/user-guide/common/cli-install-openstack-command-line-clients.html
 * Alibaba cloud (aliyun) - alicloud - https://www.alibabacloud.com/help/product/29991.htm
 * Hetzner cloud (hcloud) - https://community.hetzner.com/tutorials/howto-hcloud-cli
+* Equinix Metal (metal) - equinixmetal - https://github.com/equinix/metal-cli
+* VMware vSphere (govc) - vsphere - https://github.com/vmware/govmomi/tree/main/govc
 
 To overwrite the default templates or add support for custom (out of tree) cloud providers place a template
 for the respective provider in the "templates" folder of the gardenctl home directory ($GCTL_HOME or $HOME/.garden).
 Please refer to the templates of the already supported cloud providers which can be found
-here https://github.com/gardener/gardenctl-v2/tree/master/pkg/cmd/env/templates.`,
+here https://github.com/gardener/gardenctl-v2/tree/master/pkg/cmd/env/templates.
+Besides the sprig template functions, e.g. "b64enc" and "b64dec", the templates can use "shellEscape" and
+"jsonPath" to extract nested values from JSON, e.g. {{.serviceaccountJSON | jsonPath "{.client_email}"}}.`,
 		Aliases: []string{"p-env", "cloud-env"},
 		RunE:    runE,
 	}
@@ -61,9 +89,14 @@ here https://github.com/gardener/gardenctl-v2/tree/master/pkg/cmd/env/templates.
 	f.TargetFlags().AddFlags(persistentFlags)
 	flags.RegisterCompletionFuncsForTargetFlags(cmd, f, ioStreams, persistentFlags)
 
-	// add output flag only to the base provider-env command
+	// add output and batch flags only to the base provider-env command
 	cmdFlags := cmd.Flags()
-	o.Options.AddFlags(cmdFlags)
+	o.AddOutputFlags(cmdFlags)
+	o.AddBatchFlags(cmdFlags)
+
+	utilruntime.Must(cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"json", "yaml", outputSecretYAML, outputKeychain}, cobra.ShellCompDirectiveNoFileComp
+	}))
 
 	for _, s := range env.ValidShells() {
 		cmd.AddCommand(&cobra.Command{
@@ -77,5 +110,8 @@ here https://github.com/gardener/gardenctl-v2/tree/master/pkg/cmd/env/templates.
 		})
 	}
 
+	cmd.AddCommand(NewCmdProviderEnvPrune(f, ioStreams))
+	cmd.AddCommand(NewCmdProviderEnvKeychainRead(f, ioStreams))
+
 	return cmd
 }
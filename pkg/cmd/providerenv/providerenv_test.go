@@ -8,6 +8,7 @@ package providerenv_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 
@@ -83,9 +84,12 @@ var _ = Describe("Env Commands", func() {
 			Expect(flag).NotTo(BeNil())
 			Expect(flag.Shorthand).To(Equal("u"))
 			subCmds := cmd.Commands()
-			Expect(len(subCmds)).To(Equal(4))
+			Expect(len(subCmds)).To(Equal(6))
 			for _, c := range subCmds {
 				Expect(c.Flag("unset")).To(BeIdenticalTo(flag))
+				if c.Name() == "prune" || c.Name() == "keychain-read" {
+					continue
+				}
 				Expect(c.Flag("output")).To(BeNil())
 				s := env.Shell(c.Name())
 				Expect(s).To(BeElementOf(env.ValidShells()))
@@ -122,7 +126,6 @@ var _ = Describe("Env Commands", func() {
 
 				manager.EXPECT().SessionDir().Return(sessionDir)
 				manager.EXPECT().CurrentTarget().Return(t, nil)
-				manager.EXPECT().Configuration().Return(cfg)
 
 				factory.EXPECT().GardenHomeDir().Return(gardenHomeDir)
 
@@ -193,7 +196,9 @@ var _ = Describe("Env Commands", func() {
 						},
 					},
 				}
+			})
 
+			JustBeforeEach(func() {
 				client := clientgarden.NewClient(
 					nil,
 					fake.NewClientWithObjects(project, shoot, secretBinding, secret, cloudProfile),
@@ -203,11 +208,29 @@ var _ = Describe("Env Commands", func() {
 			})
 
 			It("should output in yaml format", func() {
+				manager.EXPECT().Configuration().Return(cfg)
 				parent.SetArgs([]string{"provider-env", "--output", "yaml"})
 				Expect(parent.Execute()).To(Succeed())
 				configDir := filepath.Join(sessionDir, ".config", "gcloud")
 				Expect(out.String()).To(Equal(fmt.Sprintf(readTestFile("gcp/export.yaml"), configDir)))
 			})
+
+			Context("when the cloud provider secret does not exist", func() {
+				BeforeEach(func() {
+					secret.Name = "other"
+				})
+
+				It("should output the error in json format", func() {
+					parent.SilenceUsage = true
+					parent.SetArgs([]string{"provider-env", "--output", "json"})
+					err := parent.Execute()
+					Expect(err).To(MatchError(ContainSubstring("not found")))
+
+					var result map[string]interface{}
+					Expect(json.Unmarshal([]byte(out.String()), &result)).To(Succeed())
+					Expect(result).To(Equal(map[string]interface{}{"error": err.Error()}))
+				})
+			})
 		})
 	})
 })
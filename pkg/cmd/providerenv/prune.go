@@ -0,0 +1,158 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package providerenv
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
+)
+
+// defaultPruneMaxAge is the default age after which the cloud provider CLI configuration directories are pruned.
+const defaultPruneMaxAge = 24 * time.Hour
+
+// NewCmdProviderEnvPrune returns a new provider-env prune command.
+func NewCmdProviderEnvPrune(f util.Factory, ioStreams util.IOStreams) *cobra.Command {
+	o := &pruneOptions{
+		Options: base.Options{
+			IOStreams: ioStreams,
+		},
+		MaxAge: defaultPruneMaxAge,
+	}
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove old cloud provider CLI configuration directories of gardenctl sessions",
+		Long: `Remove old cloud provider CLI configuration directories of gardenctl sessions.
+The provider-env command points the configuration location of some cloud provider CLIs, e.g. az and gcloud, to a folder
+in the session directory. These folders remain after the session ended. The prune command removes the folders of all
+sessions that have not been modified within the given maximum age. The folders of the current session are never removed.`,
+		Example: `# show the configuration directories that would be removed
+gardenctl provider-env prune --dry-run
+
+# remove the configuration directories that have not been modified for a week
+gardenctl provider-env prune --max-age 168h`,
+		Args: cobra.NoArgs,
+		RunE: base.WrapRunE(o, f),
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+type pruneOptions struct {
+	base.Options
+
+	// MaxAge is the minimum duration since the last modification of a directory to be removed.
+	MaxAge time.Duration
+	// DryRun only reports the directories that would be removed.
+	DryRun bool
+}
+
+// AddFlags binds the command options to a given flagset.
+func (o *pruneOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.DurationVar(&o.MaxAge, "max-age", o.MaxAge, "Remove the configuration directories that have not been modified within this duration.")
+	flags.BoolVar(&o.DryRun, "dry-run", o.DryRun, "Only print the configuration directories that would be removed.")
+}
+
+// Validate validates the provided command options.
+func (o *pruneOptions) Validate() error {
+	if o.MaxAge <= 0 {
+		return errors.New("--max-age must be positive")
+	}
+
+	return nil
+}
+
+// Run does the actual work of the command.
+func (o *pruneOptions) Run(f util.Factory) error {
+	manager, err := f.Manager()
+	if err != nil {
+		return err
+	}
+
+	currentSessionDir := filepath.Clean(manager.SessionDir())
+	threshold := f.Clock().Now().Add(-o.MaxAge)
+
+	configDirs, err := filepath.Glob(filepath.Join(f.GardenTempDir(), "sessions", "*", ".config", "*"))
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(configDirs)
+
+	var pruned int
+
+	for _, configDir := range configDirs {
+		sessionDir := filepath.Dir(filepath.Dir(configDir))
+		if sessionDir == currentSessionDir {
+			continue
+		}
+
+		modTime, err := lastModified(configDir)
+		if err != nil {
+			return fmt.Errorf("failed to determine the modification time of %q: %w", configDir, err)
+		}
+
+		if !modTime.Before(threshold) {
+			continue
+		}
+
+		pruned++
+
+		if o.DryRun {
+			fmt.Fprintf(o.IOStreams.Out, "Would remove %s\n", configDir)
+			continue
+		}
+
+		if err := os.RemoveAll(configDir); err != nil {
+			return fmt.Errorf("failed to remove %q: %w", configDir, err)
+		}
+
+		fmt.Fprintf(o.IOStreams.Out, "Removed %s\n", configDir)
+	}
+
+	if pruned == 0 {
+		fmt.Fprintln(o.IOStreams.Out, "No cloud provider CLI configuration directories to prune")
+	}
+
+	return nil
+}
+
+// lastModified returns the latest modification time of the given directory and of all files and directories within it.
+func lastModified(dir string) (time.Time, error) {
+	var latest time.Time
+
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+
+		return nil
+	})
+
+	return latest, err
+}
@@ -0,0 +1,117 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package providerenv_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	utilmocks "github.com/gardener/gardenctl-v2/internal/util/mocks"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/providerenv"
+	targetmocks "github.com/gardener/gardenctl-v2/pkg/target/mocks"
+)
+
+var _ = Describe("Provider Env Prune Command", func() {
+	var (
+		ctrl          *gomock.Controller
+		factory       *utilmocks.MockFactory
+		manager       *targetmocks.MockManager
+		streams       util.IOStreams
+		out           *util.SafeBytesBuffer
+		errOut        *util.SafeBytesBuffer
+		gardenTempDir string
+		now           time.Time
+		oldDir        string
+		recentDir     string
+		currentDir    string
+	)
+
+	createConfigDir := func(session, cli string, modTime time.Time) string {
+		configDir := filepath.Join(gardenTempDir, "sessions", session, ".config", cli)
+		Expect(os.MkdirAll(configDir, 0o700)).To(Succeed())
+
+		filename := filepath.Join(configDir, "credentials")
+		Expect(os.WriteFile(filename, []byte("secret"), 0o600)).To(Succeed())
+		Expect(os.Chtimes(filename, modTime, modTime)).To(Succeed())
+		Expect(os.Chtimes(configDir, modTime, modTime)).To(Succeed())
+
+		return configDir
+	}
+
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := providerenv.NewCmdProviderEnvPrune(factory, streams)
+		cmd.SetArgs(append([]string{}, args...))
+		cmd.SetOut(errOut)
+		cmd.SetErr(errOut)
+
+		return cmd
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		factory = utilmocks.NewMockFactory(ctrl)
+		manager = targetmocks.NewMockManager(ctrl)
+		streams, _, out, errOut = util.NewTestIOStreams()
+
+		gardenTempDir = GinkgoT().TempDir()
+		now = time.Now()
+
+		clock := utilmocks.NewMockClock(ctrl)
+		clock.EXPECT().Now().Return(now).AnyTimes()
+
+		factory.EXPECT().Manager().Return(manager, nil).AnyTimes()
+		factory.EXPECT().Clock().Return(clock).AnyTimes()
+		factory.EXPECT().GardenTempDir().Return(gardenTempDir).AnyTimes()
+		manager.EXPECT().SessionDir().Return(filepath.Join(gardenTempDir, "sessions", "current")).AnyTimes()
+
+		oldDir = createConfigDir("old", "gcloud", now.Add(-48*time.Hour))
+		recentDir = createConfigDir("recent", "az", now.Add(-time.Hour))
+		currentDir = createConfigDir("current", "gcloud", now.Add(-48*time.Hour))
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should remove the old configuration directories", func() {
+		Expect(newCmd().Execute()).To(Succeed())
+
+		Expect(out.String()).To(Equal(fmt.Sprintf("Removed %s\n", oldDir)))
+		Expect(oldDir).NotTo(BeADirectory())
+		Expect(recentDir).To(BeADirectory())
+		Expect(currentDir).To(BeADirectory())
+	})
+
+	It("should consider the files within the configuration directories", func() {
+		Expect(os.WriteFile(filepath.Join(oldDir, "access_tokens.db"), nil, 0o600)).To(Succeed())
+
+		Expect(newCmd().Execute()).To(Succeed())
+
+		Expect(out.String()).To(Equal("No cloud provider CLI configuration directories to prune\n"))
+		Expect(oldDir).To(BeADirectory())
+	})
+
+	It("should only report the directories in dry-run mode", func() {
+		Expect(newCmd("--dry-run", "--max-age", "30m").Execute()).To(Succeed())
+
+		Expect(out.String()).To(Equal(fmt.Sprintf("Would remove %s\nWould remove %s\n", oldDir, recentDir)))
+		Expect(oldDir).To(BeADirectory())
+		Expect(recentDir).To(BeADirectory())
+	})
+
+	It("should reject a non-positive maximum age", func() {
+		Expect(newCmd("--max-age", "0s").Execute()).To(MatchError("--max-age must be positive"))
+	})
+})
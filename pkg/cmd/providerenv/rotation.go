@@ -0,0 +1,55 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package providerenv
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// resourceVersionFile returns the path of the file in the session directory that holds the resourceVersion
+// of the given secret, as observed when the cloud provider CLI configuration was last generated.
+func resourceVersionFile(sessionDir string, secret *corev1.Secret) string {
+	return filepath.Join(sessionDir, "provider-env", secret.Namespace, secret.Name+".resourceVersion")
+}
+
+// checkCredentialsRotation warns if the resourceVersion of the secret changed since the cloud provider CLI
+// configuration was last generated in this session, as shells configured before still use the old credentials.
+// The observed resourceVersion is written to the session directory. Secrets without a resourceVersion,
+// e.g. read from a file, are skipped.
+func (o *options) checkCredentialsRotation(secret *corev1.Secret) error {
+	if secret.ResourceVersion == "" || o.SessionDir == "" {
+		return nil
+	}
+
+	filename := resourceVersionFile(o.SessionDir, secret)
+
+	previous, err := os.ReadFile(filename) // #nosec G304 -- The file is located in the session directory
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read resourceVersion of Secret %q: %w", secret.Name, err)
+	}
+
+	if len(previous) > 0 && strings.TrimSpace(string(previous)) != secret.ResourceVersion {
+		fmt.Fprintf(o.IOStreams.ErrOut, "WARNING: the credentials in Secret %q have been rotated since the cloud provider CLI configuration was last generated. Re-evaluate the output of %s in shells configured before.\n", secret.Name, o.CmdPath)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o700); err != nil {
+		return fmt.Errorf("failed to create directory for resourceVersion of Secret %q: %w", secret.Name, err)
+	}
+
+	if err := os.WriteFile(filename, []byte(secret.ResourceVersion), 0o600); err != nil {
+		return fmt.Errorf("failed to write resourceVersion of Secret %q: %w", secret.Name, err)
+	}
+
+	return nil
+}
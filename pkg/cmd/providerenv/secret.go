@@ -0,0 +1,119 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package providerenv
+
+import (
+	"fmt"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// outputSecretYAML is the output format for a Kubernetes Secret manifest containing the cloud provider credentials.
+const outputSecretYAML = "secret-yaml"
+
+// openstackApplicationCredentialKeys are the optional fields of an openstack secret with application credentials.
+var openstackApplicationCredentialKeys = []string{"applicationCredentialID", "applicationCredentialName", "domainName", "tenantName", "username"}
+
+// secretCredentialKeys returns the fields of the cloud provider secret that hold the credentials.
+// An error is returned if the provider type is not supported or a required field is missing in the secret.
+func secretCredentialKeys(providerType string, secret *corev1.Secret) ([]string, error) {
+	var required, optional []string
+
+	switch providerType {
+	case "openstack":
+		if _, ok := secret.Data["applicationCredentialSecret"]; ok {
+			if len(secret.Data["applicationCredentialID"]) == 0 && len(secret.Data["applicationCredentialName"]) == 0 {
+				return nil, fmt.Errorf("no \"applicationCredentialID\" or \"applicationCredentialName\" data in Secret %q", secret.Name)
+			}
+
+			required = []string{"applicationCredentialSecret"}
+			optional = openstackApplicationCredentialKeys
+		} else {
+			required = []string{"domainName", "tenantName", "username", "password"}
+		}
+
+		optional = append(optional, openstackCACertKey)
+	default:
+		fields, ok := credentialFields[providerType]
+		if !ok {
+			return nil, fmt.Errorf("cloud provider %q is not supported", providerType)
+		}
+
+		for _, field := range fields {
+			required = append(required, field.Key)
+		}
+	}
+
+	keys := make([]string, 0, len(required)+len(optional))
+
+	for _, key := range required {
+		if len(secret.Data[key]) == 0 {
+			return nil, fmt.Errorf("no %q data in Secret %q", key, secret.Name)
+		}
+
+		keys = append(keys, key)
+	}
+
+	for _, key := range optional {
+		if len(secret.Data[key]) > 0 {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
+
+// newCredentialsSecret returns a Secret named after the given shoot, which contains only the credential fields
+// of the given cloud provider secret. The namespace is left empty, so that the manifest can be applied to any namespace.
+func newCredentialsSecret(shoot *gardencorev1beta1.Shoot, secret *corev1.Secret) (*corev1.Secret, error) {
+	keys, err := secretCredentialKeys(shoot.Spec.Provider.Type, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		data[key] = secret.Data[key]
+	}
+
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: shoot.Name + "-provider-credentials",
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}, nil
+}
+
+// printCredentialsSecret prints the credentials of the cloud provider secret as Kubernetes Secret manifest.
+// The manifest is never written to disk.
+func printCredentialsSecret(o *options, shoot *gardencorev1beta1.Shoot, secret *corev1.Secret) error {
+	if err := o.checkCredentials(o.IOStreams.ErrOut, shoot.Spec.Provider.Type, secret); err != nil {
+		return err
+	}
+
+	credentialsSecret, err := newCredentialsSecret(shoot, secret)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(credentialsSecret)
+	if err != nil {
+		return err
+	}
+
+	_, err = o.IOStreams.Out.Write(data)
+
+	return err
+}
@@ -0,0 +1,85 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package providerenv
+
+import (
+	"fmt"
+	"strings"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// credentialValidation is the result of validating the credentials in the cloud provider secret of a shoot.
+type credentialValidation struct {
+	// Shoot is the name of the shoot.
+	Shoot string `json:"shoot"`
+	// ProviderType is the provider type of the shoot.
+	ProviderType string `json:"providerType"`
+	// Secret is the name of the cloud provider secret.
+	Secret string `json:"secret"`
+	// Valid is true if the credentials are present and have a valid format.
+	Valid bool `json:"valid"`
+	// Errors are the reasons why the credentials are invalid. They never contain the values of the credentials.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// String returns the concise report of the credential validation.
+func (v *credentialValidation) String() string {
+	var sb strings.Builder
+
+	if v.Valid {
+		fmt.Fprintf(&sb, "The credentials in Secret %q of shoot %q (%s) are valid.\n", v.Secret, v.Shoot, v.ProviderType)
+		return sb.String()
+	}
+
+	fmt.Fprintf(&sb, "The credentials in Secret %q of shoot %q (%s) are invalid:\n", v.Secret, v.Shoot, v.ProviderType)
+
+	for _, err := range v.Errors {
+		fmt.Fprintf(&sb, "  - %s\n", err)
+	}
+
+	return sb.String()
+}
+
+// validateOnly validates the credentials in the cloud provider secret of the shoot and prints the result instead of
+// the script. Nothing is written to disk. An error is returned if the credentials are invalid, so that the exit code
+// of the command reflects the result.
+func (o *options) validateOnly(shoot *gardencorev1beta1.Shoot, secret *corev1.Secret) error {
+	providerType := shoot.Spec.Provider.Type
+
+	result := &credentialValidation{
+		Shoot:        shoot.Name,
+		ProviderType: providerType,
+		Secret:       secret.Name,
+	}
+
+	// the format is only checked if the fields required by the template of the provider type are present
+	if _, err := secretCredentialKeys(providerType, secret); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	} else if err := validateCredentials(providerType, secret, o.FirstCredentialErrorOnly); err != nil {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, err := range joined.Unwrap() {
+				result.Errors = append(result.Errors, err.Error())
+			}
+		} else {
+			result.Errors = append(result.Errors, err.Error())
+		}
+	}
+
+	result.Valid = len(result.Errors) == 0
+
+	if err := o.PrintObject(result); err != nil {
+		return err
+	}
+
+	if !result.Valid {
+		return fmt.Errorf("the credentials in Secret %q are invalid", secret.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package providerenv
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	clientgarden "github.com/gardener/gardenctl-v2/internal/client/garden"
+)
+
+// vsphereCloudProfileConfig contains the fields of the vSphere provider config of a cloud profile that are required
+// to configure the govc CLI.
+type vsphereCloudProfileConfig struct {
+	// Regions is the list of supported regions.
+	Regions []vsphereRegionSpec `json:"regions"`
+}
+
+// vsphereRegionSpec contains the vSphere endpoint of a region.
+type vsphereRegionSpec struct {
+	// Name is the name of the region.
+	Name string `json:"name"`
+	// VsphereHost is the vSphere host.
+	VsphereHost string `json:"vsphereHost"`
+	// VsphereInsecureSSL is a flag if insecure HTTPS is allowed for the vSphere host.
+	VsphereInsecureSSL bool `json:"vsphereInsecureSSL,omitempty"`
+}
+
+// getVSphereEndpoint returns the URL of the vSphere host for the given region and whether insecure HTTPS is allowed.
+// A host without scheme is prefixed with https.
+func getVSphereEndpoint(cloudProfile *clientgarden.CloudProfileUnion, region string) (string, bool, error) {
+	name := cloudProfile.GetObjectMeta().Name
+
+	providerConfig := cloudProfile.GetCloudProfileSpec().ProviderConfig
+	if providerConfig == nil {
+		return "", false, fmt.Errorf("providerConfig of cloudprofile %q is empty", name)
+	}
+
+	raw := providerConfig.Raw
+	if raw == nil && providerConfig.Object != nil {
+		var err error
+		if raw, err = json.Marshal(providerConfig.Object); err != nil {
+			return "", false, fmt.Errorf("cannot encode providerConfig of cloudprofile %q: %w", name, err)
+		}
+	}
+
+	config := &vsphereCloudProfileConfig{}
+	if err := json.Unmarshal(raw, config); err != nil {
+		return "", false, fmt.Errorf("cannot decode providerConfig of cloudprofile %q: %w", name, err)
+	}
+
+	for _, r := range config.Regions {
+		if r.Name != region || r.VsphereHost == "" {
+			continue
+		}
+
+		endpoint := r.VsphereHost
+		if !strings.Contains(endpoint, "://") {
+			endpoint = "https://" + endpoint
+		}
+
+		return endpoint, r.VsphereInsecureSSL, nil
+	}
+
+	return "", false, fmt.Errorf("cannot find vSphere host for region %q in cloudprofile %q", region, name)
+}
+
+// validateVSphereEndpoint checks that the endpoint of the vSphere host is an HTTPS URL without credentials,
+// as the credentials are always taken from the cloud provider secret. A password in the endpoint is redacted in the error.
+func validateVSphereEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid vSphere endpoint %q: %w", endpoint, err)
+	}
+
+	if u.Scheme != "https" || u.Hostname() == "" || u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("invalid vSphere endpoint %q: must be an https URL without user information, query or fragment", u.Redacted())
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
 	"strings"
 	"time"
 
@@ -32,6 +33,11 @@ type AccessConfig struct {
 	// AutoDetected indicates if the public IPs of the user were automatically detected.
 	// AutoDetected is false in case the CIDRs were provided via flags.
 	AutoDetected bool
+
+	// IPDetectionURL is the URL of a service that responds with the public IP address
+	// of the user as plain text. If not given, the URL from the gardenctl configuration
+	// or the default detection service is used.
+	IPDetectionURL string
 }
 
 func (o *AccessConfig) Complete(f util.Factory, _ *cobra.Command, _ []string) error {
@@ -39,10 +45,27 @@ func (o *AccessConfig) Complete(f util.Factory, _ *cobra.Command, _ []string) er
 	logger := klog.FromContext(ctx)
 
 	if len(o.CIDRs) == 0 {
+		if o.IPDetectionURL == "" {
+			manager, err := f.Manager()
+			if err != nil {
+				return err
+			}
+
+			if cfg := manager.Configuration(); cfg != nil {
+				o.IPDetectionURL = cfg.IPDetectionURL
+			}
+		}
+
+		if o.IPDetectionURL != "" {
+			if err := validateIPDetectionURL(o.IPDetectionURL); err != nil {
+				return err
+			}
+		}
+
 		ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 		defer cancel()
 
-		publicIPs, err := f.PublicIPs(ctx)
+		publicIPs, err := o.publicIPs(ctx, f)
 		if err != nil {
 			return fmt.Errorf("failed to determine your system's public IP addresses: %w", err)
 		}
@@ -77,6 +100,24 @@ func (o *AccessConfig) Validate() error {
 
 func (o *AccessConfig) AddFlags(flags *pflag.FlagSet) {
 	flags.StringArrayVar(&o.CIDRs, "cidr", nil, "CIDRs to allow access to the bastion host; if not given, your system's public IPs (v4 and v6) are auto-detected.")
+	flags.StringVar(&o.IPDetectionURL, "ip-detection-url", o.IPDetectionURL, "URL of a service that responds with your system's public IP address as plain text. Used to auto-detect the CIDR if --cidr is not given. Overrides the ipDetectionURL of the gardenctl configuration.")
+}
+
+func validateIPDetectionURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid IP detection URL %q: %w", rawURL, err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid IP detection URL %q: scheme must be http or https", rawURL)
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("invalid IP detection URL %q: host must not be empty", rawURL)
+	}
+
+	return nil
 }
 
 type (
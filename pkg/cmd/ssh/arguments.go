@@ -8,6 +8,7 @@ package ssh
 
 import (
 	"fmt"
+	"net"
 	"strings"
 
 	"github.com/gardener/gardenctl-v2/internal/util"
@@ -65,14 +66,17 @@ func sshCommandArguments(
 	sshPrivateKeyFile PrivateKeyFile,
 	bastionUserKnownHostsFiles []string,
 	bastionStrictHostKeyChecking StrictHostKeyChecking,
+	httpsProxy string,
 	nodeUserKnownHostsFiles []string,
 	nodeStrictHostKeyChecking StrictHostKeyChecking,
+	hashKnownHosts bool,
 	nodeHostname string,
 	nodePrivateKeyFiles []PrivateKeyFile,
+	nodeAgentKeyOnly bool,
+	identityAgent string,
 	user string,
 ) arguments {
 	bastionUserKnownHostsFilesArg := userKnownHostsFilesArgument(bastionUserKnownHostsFiles)
-	nodeUserKnownHostsFilesArg := userKnownHostsFilesArgument(nodeUserKnownHostsFiles)
 
 	proxyCmdArgs := sshProxyCmdArguments(
 		bastionHost,
@@ -80,14 +84,60 @@ func sshCommandArguments(
 		sshPrivateKeyFile,
 		bastionUserKnownHostsFilesArg,
 		bastionStrictHostKeyChecking,
+		hashKnownHosts,
+		httpsProxy,
 	)
 
-	args := []argument{
-		{value: "-oIdentitiesOnly=yes", shellEscapeDisabled: true},
-		{value: fmt.Sprintf("-oStrictHostKeyChecking=%s", nodeStrictHostKeyChecking), shellEscapeDisabled: true},
+	args := nodeArguments(nodeUserKnownHostsFiles, nodeStrictHostKeyChecking, hashKnownHosts, nodePrivateKeyFiles, nodeAgentKeyOnly, identityAgent)
+
+	args = append(args, argument{value: fmt.Sprintf("-oProxyCommand=%s", proxyCmdArgs.String())})
+
+	args = append(args, argument{value: fmt.Sprintf("%s@%s", user, nodeHostname)})
+
+	return arguments{list: args}
+}
+
+// directSSHCommandArguments returns the arguments to connect to a node without a bastion,
+// i.e. the node must be reachable from the client.
+func directSSHCommandArguments(
+	nodeUserKnownHostsFiles []string,
+	nodeStrictHostKeyChecking StrictHostKeyChecking,
+	hashKnownHosts bool,
+	nodeHostname string,
+	nodePrivateKeyFiles []PrivateKeyFile,
+	nodeAgentKeyOnly bool,
+	identityAgent string,
+	user string,
+) arguments {
+	args := nodeArguments(nodeUserKnownHostsFiles, nodeStrictHostKeyChecking, hashKnownHosts, nodePrivateKeyFiles, nodeAgentKeyOnly, identityAgent)
+
+	args = append(args, argument{value: fmt.Sprintf("%s@%s", user, nodeHostname)})
+
+	return arguments{list: args}
+}
+
+func nodeArguments(
+	nodeUserKnownHostsFiles []string,
+	nodeStrictHostKeyChecking StrictHostKeyChecking,
+	hashKnownHosts bool,
+	nodePrivateKeyFiles []PrivateKeyFile,
+	nodeAgentKeyOnly bool,
+	identityAgent string,
+) []argument {
+	var args []argument
+
+	// without a node private key file, ssh must be allowed to offer the identities of the SSH agent
+	if !nodeAgentKeyOnly {
+		args = append(args, argument{value: "-oIdentitiesOnly=yes", shellEscapeDisabled: true})
+	}
+
+	args = append(args, argument{value: fmt.Sprintf("-oStrictHostKeyChecking=%s", nodeStrictHostKeyChecking), shellEscapeDisabled: true})
+
+	if hashKnownHosts {
+		args = append(args, argument{value: "-oHashKnownHosts=yes", shellEscapeDisabled: true})
 	}
 
-	if nodeUserKnownHostsFilesArg != nil {
+	if nodeUserKnownHostsFilesArg := userKnownHostsFilesArgument(nodeUserKnownHostsFiles); nodeUserKnownHostsFilesArg != nil {
 		args = append(args, *nodeUserKnownHostsFilesArg)
 	}
 
@@ -95,11 +145,13 @@ func sshCommandArguments(
 		args = append(args, argument{value: fmt.Sprintf("-i%s", file)})
 	}
 
-	args = append(args, argument{value: fmt.Sprintf("-oProxyCommand=%s", proxyCmdArgs.String())})
-
-	args = append(args, argument{value: fmt.Sprintf("%s@%s", user, nodeHostname)})
+	// the SSH agent of the node hop is overridden, e.g. to use a hardware token instead of SSH_AUTH_SOCK.
+	// Like the known hosts files, the path is escaped, as ssh splits the option value at whitespace
+	if identityAgent != "" {
+		args = append(args, argument{value: fmt.Sprintf("-oIdentityAgent=%s", util.ShellEscape(identityAgent))})
+	}
 
-	return arguments{list: args}
+	return args
 }
 
 func sshProxyCmdArguments(
@@ -108,13 +160,54 @@ func sshProxyCmdArguments(
 	sshPrivateKeyFile PrivateKeyFile,
 	userKnownHostsFileArg *argument,
 	bastionStrictHostKeyChecking StrictHostKeyChecking,
+	hashKnownHosts bool,
+	httpsProxy string,
 ) arguments {
 	args := []argument{
 		{value: "ssh", shellEscapeDisabled: true},
 		{value: "-W%h:%p", shellEscapeDisabled: true},
+	}
+
+	var proxyCommand string
+
+	// the proxy URL has already been validated, hence an invalid value is ignored
+	if proxy, err := parseHTTPSProxy(httpsProxy); httpsProxy != "" && err == nil {
+		// the tokens are escaped, so that they are expanded by this (inner) ssh command
+		// instead of the outer one, which runs it as its ProxyCommand
+		proxyCommand = strings.ReplaceAll(httpsProxyCommand(proxy), "%", "%%")
+	}
+
+	args = append(args, bastionArguments(
+		bastionHost,
+		bastionPort,
+		sshPrivateKeyFile,
+		userKnownHostsFileArg,
+		bastionStrictHostKeyChecking,
+		hashKnownHosts,
+		proxyCommand,
+	)...)
+
+	return arguments{list: args}
+}
+
+// bastionArguments returns the options and the destination of an ssh command connecting to the bastion.
+func bastionArguments(
+	bastionHost string,
+	bastionPort string,
+	sshPrivateKeyFile PrivateKeyFile,
+	userKnownHostsFileArg *argument,
+	bastionStrictHostKeyChecking StrictHostKeyChecking,
+	hashKnownHosts bool,
+	proxyCommand string,
+) []argument {
+	args := []argument{
 		{value: fmt.Sprintf("-oStrictHostKeyChecking=%s", bastionStrictHostKeyChecking), shellEscapeDisabled: true},
 	}
 
+	if hashKnownHosts {
+		args = append(args, argument{value: "-oHashKnownHosts=yes", shellEscapeDisabled: true})
+	}
+
 	if sshPrivateKeyFile != "" {
 		args = append(args, argument{value: "-oIdentitiesOnly=yes", shellEscapeDisabled: true})
 		args = append(args, argument{value: fmt.Sprintf("-i%s", sshPrivateKeyFile)})
@@ -124,11 +217,55 @@ func sshProxyCmdArguments(
 		args = append(args, *userKnownHostsFileArg)
 	}
 
+	if proxyCommand != "" {
+		args = append(args, argument{value: fmt.Sprintf("-oProxyCommand=%s", proxyCommand)})
+	}
+
 	args = append(args, argument{value: fmt.Sprintf("%s@%s", SSHBastionUsername, bastionHost)})
 
 	if bastionPort != "" {
 		args = append(args, argument{value: fmt.Sprintf("-p%s", bastionPort)})
 	}
 
+	return args
+}
+
+// portForwardArguments returns the arguments of an ssh command that forwards the given local port
+// through the bastion to the remote host and port, without executing a remote command.
+func portForwardArguments(
+	bastionHost string,
+	bastionPort string,
+	sshPrivateKeyFile PrivateKeyFile,
+	bastionUserKnownHostsFiles []string,
+	bastionStrictHostKeyChecking StrictHostKeyChecking,
+	hashKnownHosts bool,
+	httpsProxy string,
+	localPort int,
+	remoteHost string,
+	remotePort string,
+) arguments {
+	args := []argument{
+		{value: "-N", shellEscapeDisabled: true},
+		{value: fmt.Sprintf("-L127.0.0.1:%d:%s", localPort, net.JoinHostPort(remoteHost, remotePort))},
+		{value: "-oExitOnForwardFailure=yes", shellEscapeDisabled: true},
+	}
+
+	var proxyCommand string
+
+	// the proxy URL has already been validated, hence an invalid value is ignored
+	if proxy, err := parseHTTPSProxy(httpsProxy); httpsProxy != "" && err == nil {
+		proxyCommand = httpsProxyCommand(proxy)
+	}
+
+	args = append(args, bastionArguments(
+		bastionHost,
+		bastionPort,
+		sshPrivateKeyFile,
+		userKnownHostsFilesArgument(bastionUserKnownHostsFiles),
+		bastionStrictHostKeyChecking,
+		hashKnownHosts,
+		proxyCommand,
+	)...)
+
 	return arguments{list: args}
 }
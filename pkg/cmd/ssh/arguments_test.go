@@ -21,10 +21,14 @@ type testCase struct {
 	sshPrivateKeyFile            ssh.PrivateKeyFile
 	bastionUserKnownHostsFiles   []string
 	bastionStrictHostKeyChecking ssh.StrictHostKeyChecking
+	httpsProxy                   string
 	nodeUserKnownHostsFiles      []string
 	nodeStrictHostKeyChecking    ssh.StrictHostKeyChecking
+	hashKnownHosts               bool
 	nodeHostname                 string
 	nodePrivateKeyFiles          []ssh.PrivateKeyFile
+	nodeAgentKeyOnly             bool
+	identityAgent                string
 	expectedArgs                 []string
 	user                         string
 }
@@ -54,10 +58,14 @@ var _ = Describe("Arguments", func() {
 					tc.sshPrivateKeyFile,
 					tc.bastionUserKnownHostsFiles,
 					tc.bastionStrictHostKeyChecking,
+					tc.httpsProxy,
 					tc.nodeUserKnownHostsFiles,
 					tc.nodeStrictHostKeyChecking,
+					tc.hashKnownHosts,
 					tc.nodeHostname,
 					tc.nodePrivateKeyFiles,
+					tc.nodeAgentKeyOnly,
+					tc.identityAgent,
 					tc.user,
 				)
 				res := args.String()
@@ -75,6 +83,30 @@ var _ = Describe("Arguments", func() {
 				}
 				return tc
 			}()),
+			Entry("node accessed with an identity of the SSH agent", func() testCase {
+				tc := newTestCase()
+				tc.nodePrivateKeyFiles = nil
+				tc.nodeAgentKeyOnly = true
+				tc.expectedArgs = []string{
+					"-oStrictHostKeyChecking=ask",
+					`'-oProxyCommand=ssh -W%h:%p -oStrictHostKeyChecking=ask -oIdentitiesOnly=yes '"'"'-ipath/to/private/key'"'"' '"'"'gardener@bastion.example.com'"'"' '"'"'-p22'"'"''`,
+					"'gardener@node.example.com'",
+				}
+				return tc
+			}()),
+			Entry("node accessed with the identities of another SSH agent", func() testCase {
+				tc := newTestCase()
+				tc.nodePrivateKeyFiles = nil
+				tc.nodeAgentKeyOnly = true
+				tc.identityAgent = "/run/user/1000/yubikey-agent/yubikey-agent.sock"
+				tc.expectedArgs = []string{
+					"-oStrictHostKeyChecking=ask",
+					`'-oIdentityAgent='"'"'/run/user/1000/yubikey-agent/yubikey-agent.sock'"'"''`,
+					`'-oProxyCommand=ssh -W%h:%p -oStrictHostKeyChecking=ask -oIdentitiesOnly=yes '"'"'-ipath/to/private/key'"'"' '"'"'gardener@bastion.example.com'"'"' '"'"'-p22'"'"''`,
+					"'gardener@node.example.com'",
+				}
+				return tc
+			}()),
 			Entry("basic case with other ssh username", func() testCase {
 				tc := newTestCase()
 				tc.user = "aaa"
@@ -127,6 +159,165 @@ var _ = Describe("Arguments", func() {
 				}
 				return tc
 			}()),
+			Entry("multiple node private key files", func() testCase {
+				tc := newTestCase()
+				tc.nodePrivateKeyFiles = []ssh.PrivateKeyFile{"path/to/node/private/key", "path/to/node/private/key.old"}
+				tc.expectedArgs = []string{
+					"-oIdentitiesOnly=yes",
+					"-oStrictHostKeyChecking=ask",
+					"'-ipath/to/node/private/key'",
+					"'-ipath/to/node/private/key.old'",
+					`'-oProxyCommand=ssh -W%h:%p -oStrictHostKeyChecking=ask -oIdentitiesOnly=yes '"'"'-ipath/to/private/key'"'"' '"'"'gardener@bastion.example.com'"'"' '"'"'-p22'"'"''`,
+					"'gardener@node.example.com'",
+				}
+				return tc
+			}()),
+			Entry("https proxy", func() testCase {
+				tc := newTestCase()
+				tc.httpsProxy = "http://proxy.example.com:3128"
+				tc.expectedArgs = []string{
+					"-oIdentitiesOnly=yes",
+					"-oStrictHostKeyChecking=ask",
+					"'-ipath/to/node/private/key'",
+					`'-oProxyCommand=ssh -W%h:%p -oStrictHostKeyChecking=ask -oIdentitiesOnly=yes '"'"'-ipath/to/private/key'"'"' '"'"'-oProxyCommand=nc -X connect -x proxy.example.com:3128 %%h %%p'"'"' '"'"'gardener@bastion.example.com'"'"' '"'"'-p22'"'"''`,
+					"'gardener@node.example.com'",
+				}
+				return tc
+			}()),
+			Entry("hash known hosts", func() testCase {
+				tc := newTestCase()
+				tc.hashKnownHosts = true
+				tc.expectedArgs = []string{
+					"-oIdentitiesOnly=yes",
+					"-oStrictHostKeyChecking=ask",
+					"-oHashKnownHosts=yes",
+					"'-ipath/to/node/private/key'",
+					`'-oProxyCommand=ssh -W%h:%p -oStrictHostKeyChecking=ask -oHashKnownHosts=yes -oIdentitiesOnly=yes '"'"'-ipath/to/private/key'"'"' '"'"'gardener@bastion.example.com'"'"' '"'"'-p22'"'"''`,
+					"'gardener@node.example.com'",
+				}
+				return tc
+			}()),
+		)
+	})
+
+	Describe("directSSHCommandArguments", func() {
+		DescribeTable("should match the expected arguments as string",
+			func(tc testCase) {
+				args := ssh.DirectSSHCommandArguments(
+					tc.nodeUserKnownHostsFiles,
+					tc.nodeStrictHostKeyChecking,
+					tc.hashKnownHosts,
+					tc.nodeHostname,
+					tc.nodePrivateKeyFiles,
+					tc.nodeAgentKeyOnly,
+					tc.identityAgent,
+					tc.user,
+				)
+				res := args.String()
+				exp := strings.Join(tc.expectedArgs, " ")
+				Expect(res).To(Equal(exp))
+			},
+			Entry("basic case", func() testCase {
+				tc := newTestCase()
+				tc.expectedArgs = []string{
+					"-oIdentitiesOnly=yes",
+					"-oStrictHostKeyChecking=ask",
+					"'-ipath/to/node/private/key'",
+					"'gardener@node.example.com'",
+				}
+				return tc
+			}()),
+			Entry("known hosts file and multiple node private key files", func() testCase {
+				tc := newTestCase()
+				tc.nodeUserKnownHostsFiles = []string{"path/to/node_known_hosts"}
+				tc.nodeStrictHostKeyChecking = "yes"
+				tc.nodeHostname = "10.250.0.5"
+				tc.nodePrivateKeyFiles = []ssh.PrivateKeyFile{"path/to/node/private/key", "path/to/node/private/key.old"}
+				tc.expectedArgs = []string{
+					"-oIdentitiesOnly=yes",
+					"-oStrictHostKeyChecking=yes",
+					`'-oUserKnownHostsFile='"'"'path/to/node_known_hosts'"'"''`,
+					"'-ipath/to/node/private/key'",
+					"'-ipath/to/node/private/key.old'",
+					"'gardener@10.250.0.5'",
+				}
+				return tc
+			}()),
+			Entry("hash known hosts", func() testCase {
+				tc := newTestCase()
+				tc.hashKnownHosts = true
+				tc.expectedArgs = []string{
+					"-oIdentitiesOnly=yes",
+					"-oStrictHostKeyChecking=ask",
+					"-oHashKnownHosts=yes",
+					"'-ipath/to/node/private/key'",
+					"'gardener@node.example.com'",
+				}
+				return tc
+			}()),
+			Entry("identity agent with a space in its path", func() testCase {
+				tc := newTestCase()
+				tc.identityAgent = "/Users/me/Library/Group Containers/agent.sock"
+				tc.expectedArgs = []string{
+					"-oIdentitiesOnly=yes",
+					"-oStrictHostKeyChecking=ask",
+					"'-ipath/to/node/private/key'",
+					`'-oIdentityAgent='"'"'/Users/me/Library/Group Containers/agent.sock'"'"''`,
+					"'gardener@node.example.com'",
+				}
+				return tc
+			}()),
+		)
+	})
+
+	Describe("portForwardArguments", func() {
+		DescribeTable("should match the expected arguments as string",
+			func(tc testCase) {
+				args := ssh.PortForwardArguments(
+					tc.bastionHost,
+					tc.bastionPort,
+					tc.sshPrivateKeyFile,
+					tc.bastionUserKnownHostsFiles,
+					tc.bastionStrictHostKeyChecking,
+					tc.hashKnownHosts,
+					tc.httpsProxy,
+					16443,
+					"api.example.com",
+					"443",
+				)
+				res := args.String()
+				exp := strings.Join(tc.expectedArgs, " ")
+				Expect(res).To(Equal(exp))
+			},
+			Entry("basic case", func() testCase {
+				tc := newTestCase()
+				tc.expectedArgs = []string{
+					"-N",
+					"'-L127.0.0.1:16443:api.example.com:443'",
+					"-oExitOnForwardFailure=yes",
+					"-oStrictHostKeyChecking=ask",
+					"-oIdentitiesOnly=yes",
+					"'-ipath/to/private/key'",
+					"'gardener@bastion.example.com'",
+					"'-p22'",
+				}
+				return tc
+			}()),
+			Entry("known hosts file and no private key file", func() testCase {
+				tc := newTestCase()
+				tc.sshPrivateKeyFile = ""
+				tc.bastionUserKnownHostsFiles = []string{"path/to/known_hosts"}
+				tc.bastionPort = ""
+				tc.expectedArgs = []string{
+					"-N",
+					"'-L127.0.0.1:16443:api.example.com:443'",
+					"-oExitOnForwardFailure=yes",
+					"-oStrictHostKeyChecking=ask",
+					`'-oUserKnownHostsFile='"'"'path/to/known_hosts'"'"''`,
+					"'gardener@bastion.example.com'",
+				}
+				return tc
+			}()),
 		)
 	})
 })
@@ -0,0 +1,66 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ssh
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"k8s.io/klog/v2"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+)
+
+// isTerminalWriter checks if the io.Writer is connected to a terminal.
+var isTerminalWriter = func(out io.Writer) bool {
+	return util.IsTerminal(out)
+}
+
+// colorEnabled returns whether the status lines written to out are colorized. Colors are disabled by the
+// --no-color flag, the NO_COLOR environment variable, the json and yaml output and if out is not a terminal.
+func (o *SSHOptions) colorEnabled(out io.Writer) bool {
+	if o.NoColor || o.Output != "" {
+		return false
+	}
+
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+
+	return isTerminalWriter(out)
+}
+
+// logStatus logs a status line about the progress of the command. If colors are enabled, the status line is
+// printed colorized to stderr instead, followed by the given key/value pairs.
+func (o *SSHOptions) logStatus(logger klog.Logger, attribute color.Attribute, msg string, keysAndValues ...interface{}) {
+	if !o.colorEnabled(o.IOStreams.ErrOut) {
+		logger.Info(msg, keysAndValues...)
+		return
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("> " + msg)
+
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		// like klog, string values are quoted
+		if value, ok := keysAndValues[i+1].(string); ok {
+			fmt.Fprintf(&sb, " %v=%q", keysAndValues[i], value)
+		} else {
+			fmt.Fprintf(&sb, " %v=%v", keysAndValues[i], keysAndValues[i+1])
+		}
+	}
+
+	c := color.New(attribute)
+	// the color is enabled explicitly, as color.NoColor only reflects whether stdout is a terminal
+	c.EnableColor()
+
+	fmt.Fprintln(o.IOStreams.ErrOut, c.Sprint(sb.String()))
+}
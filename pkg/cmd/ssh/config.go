@@ -0,0 +1,257 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ssh
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
+	"github.com/gardener/gardenctl-v2/pkg/config"
+	"github.com/gardener/gardenctl-v2/pkg/flags"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+// NewCmdConfig returns a new ssh config command.
+func NewCmdConfig(f util.Factory, ioStreams util.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the SSH configuration of Shoot clusters",
+		Long: `Manage the SSH configuration of Shoot clusters.
+
+The SSH configuration of a shoot is stored in the garden home directory and provides the default values for the flags of the ssh command
+whenever the shoot is targeted. Flags given on the command line take precedence over the configuration of the shoot,
+which in turn takes precedence over the ssh section of the gardenctl configuration file.`,
+	}
+
+	cmd.AddCommand(NewCmdConfigSet(f, ioStreams))
+
+	return cmd
+}
+
+// NewCmdConfigSet returns a new ssh config set command.
+func NewCmdConfigSet(f util.Factory, ioStreams util.IOStreams) *cobra.Command {
+	o := &ConfigSetOptions{
+		Options: base.Options{
+			IOStreams: ioStreams,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set the SSH configuration of the targeted shoot",
+		Long: `Set the SSH configuration of the targeted shoot.
+Only the given flags are written, the other values of an existing configuration are kept.`,
+		Example: `# Always log in as user "core" to the nodes of the targeted shoot
+gardenctl ssh config set --user core
+
+# Reset the user for the targeted shoot to the default
+gardenctl ssh config set --user ""`,
+		Args: cobra.NoArgs,
+		RunE: base.WrapRunE(o, f),
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	f.TargetFlags().AddFlags(cmd.Flags())
+	flags.RegisterCompletionFuncsForTargetFlags(cmd, f, ioStreams, cmd.Flags())
+
+	return cmd
+}
+
+// ConfigSetOptions is a struct to support the ssh config set command.
+type ConfigSetOptions struct {
+	base.Options
+
+	// SSHConfig holds the values given on the command line.
+	SSHConfig config.SSHConfig
+
+	// changed holds the names of the flags given on the command line.
+	changed map[string]bool
+}
+
+// AddFlags adds command-line flags to the flag set.
+func (o *ConfigSetOptions) AddFlags(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&o.SSHConfig.User, "user", o.SSHConfig.User, "Name of the Shoot cluster node ssh login username.")
+	flagSet.StringVar(&o.SSHConfig.BastionPort, "bastion-port", o.SSHConfig.BastionPort, "SSH port of the bastion used for the SSH client command.")
+	flagSet.StringVar(&o.SSHConfig.BastionStrictHostKeyChecking, "bastion-strict-host-key-checking", o.SSHConfig.BastionStrictHostKeyChecking, "Specifies how the SSH client performs host key checking for the bastion host. Valid options are 'yes', 'no', or 'ask'.")
+	flagSet.StringVar(&o.SSHConfig.NodeStrictHostKeyChecking, "node-strict-host-key-checking", o.SSHConfig.NodeStrictHostKeyChecking, "Specifies how the SSH client performs host key checking for the shoot node. Valid options are 'yes', 'no', or 'ask'.")
+}
+
+// Complete adapts from the command line args to the data required.
+func (o *ConfigSetOptions) Complete(_ util.Factory, cmd *cobra.Command, _ []string) error {
+	o.changed = map[string]bool{}
+
+	if cmd != nil {
+		cmd.Flags().Visit(func(flag *pflag.Flag) {
+			o.changed[flag.Name] = true
+		})
+	}
+
+	return nil
+}
+
+// Validate validates the provided options.
+func (o *ConfigSetOptions) Validate() error {
+	if !o.changed["user"] && !o.changed["bastion-port"] && !o.changed["bastion-strict-host-key-checking"] && !o.changed["node-strict-host-key-checking"] {
+		return errors.New("at least one of --user, --bastion-port, --bastion-strict-host-key-checking or --node-strict-host-key-checking is required")
+	}
+
+	return validateSSHConfig(&o.SSHConfig)
+}
+
+// Run executes the command.
+func (o *ConfigSetOptions) Run(f util.Factory) error {
+	manager, err := f.Manager()
+	if err != nil {
+		return err
+	}
+
+	currentTarget, err := manager.CurrentTarget()
+	if err != nil {
+		return err
+	}
+
+	if currentTarget.GardenName() == "" {
+		return target.ErrNoGardenTargeted
+	}
+
+	if currentTarget.ShootName() == "" {
+		return target.ErrNoShootTargeted
+	}
+
+	if currentTarget.ProjectName() == "" {
+		return errors.New("the SSH configuration can only be set for a shoot that is targeted via a project")
+	}
+
+	filename := config.ShootSSHConfigFilename(f.GardenHomeDir(), currentTarget.GardenName(), currentTarget.ProjectName(), currentTarget.ShootName())
+
+	sshConfig, err := config.LoadSSHConfigFromFile(filename)
+	if err != nil {
+		return err
+	}
+
+	if o.changed["user"] {
+		sshConfig.User = o.SSHConfig.User
+	}
+
+	if o.changed["bastion-port"] {
+		sshConfig.BastionPort = o.SSHConfig.BastionPort
+	}
+
+	if o.changed["bastion-strict-host-key-checking"] {
+		sshConfig.BastionStrictHostKeyChecking = o.SSHConfig.BastionStrictHostKeyChecking
+	}
+
+	if o.changed["node-strict-host-key-checking"] {
+		sshConfig.NodeStrictHostKeyChecking = o.SSHConfig.NodeStrictHostKeyChecking
+	}
+
+	if err := sshConfig.SaveToFile(filename); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.IOStreams.Out, "Successfully set the SSH configuration of shoot %q\n", currentTarget.ShootName())
+
+	return nil
+}
+
+// validateSSHConfig returns an error if a value of the given SSH configuration is invalid. Empty values are valid.
+func validateSSHConfig(sshConfig *config.SSHConfig) error {
+	if sshConfig.BastionPort != "" {
+		if port, err := strconv.Atoi(sshConfig.BastionPort); err != nil || port < 1 || port > 65535 {
+			return fmt.Errorf("invalid bastion port %q: must be a number between 1 and 65535", sshConfig.BastionPort)
+		}
+	}
+
+	var strictHostKeyChecking StrictHostKeyChecking
+
+	if sshConfig.BastionStrictHostKeyChecking != "" {
+		if err := strictHostKeyChecking.Set(sshConfig.BastionStrictHostKeyChecking); err != nil {
+			return fmt.Errorf("invalid bastion strict host key checking: %w", err)
+		}
+	}
+
+	if sshConfig.NodeStrictHostKeyChecking != "" {
+		if err := strictHostKeyChecking.Set(sshConfig.NodeStrictHostKeyChecking); err != nil {
+			return fmt.Errorf("invalid node strict host key checking: %w", err)
+		}
+	}
+
+	if sshConfig.WaitTimeout != nil && sshConfig.WaitTimeout.Duration <= 0 {
+		return fmt.Errorf("invalid wait timeout %q: must be greater than zero", sshConfig.WaitTimeout.Duration)
+	}
+
+	return nil
+}
+
+// completeSSHConfig applies the SSH configuration of the targeted shoot and the ssh section of the gardenctl
+// configuration to the options whose flags were not given on the command line.
+func (o *SSHOptions) completeSSHConfig(f util.Factory, cmd *cobra.Command) error {
+	logger := klog.FromContext(f.Context())
+
+	manager, err := f.Manager()
+	if err != nil {
+		return err
+	}
+
+	sshConfig := manager.Configuration().SSH
+
+	// the target is validated when the command runs, an incomplete target only means that there is no shoot configuration
+	currentTarget, err := manager.CurrentTarget()
+	if err != nil {
+		logger.V(4).Info("could not read the current target, skipping the SSH configuration of the shoot", "error", err)
+	} else if currentTarget.GardenName() != "" && currentTarget.ProjectName() != "" && currentTarget.ShootName() != "" {
+		filename := config.ShootSSHConfigFilename(f.GardenHomeDir(), currentTarget.GardenName(), currentTarget.ProjectName(), currentTarget.ShootName())
+
+		shootSSHConfig, err := config.LoadSSHConfigFromFile(filename)
+		if err != nil {
+			return err
+		}
+
+		sshConfig = shootSSHConfig.WithDefaults(sshConfig)
+	}
+
+	if sshConfig == nil {
+		return nil
+	}
+
+	if err := validateSSHConfig(sshConfig); err != nil {
+		return fmt.Errorf("invalid SSH configuration: %w", err)
+	}
+
+	changed := func(name string) bool {
+		return cmd != nil && cmd.Flags().Changed(name)
+	}
+
+	if sshConfig.User != "" && !changed("user") {
+		o.User = sshConfig.User
+	}
+
+	if sshConfig.BastionPort != "" && !changed("bastion-port") {
+		o.BastionPort = sshConfig.BastionPort
+	}
+
+	if sshConfig.BastionStrictHostKeyChecking != "" && !changed("bastion-strict-host-key-checking") {
+		o.BastionStrictHostKeyChecking = StrictHostKeyChecking(sshConfig.BastionStrictHostKeyChecking)
+	}
+
+	if sshConfig.NodeStrictHostKeyChecking != "" && !changed("node-strict-host-key-checking") {
+		o.NodeStrictHostKeyChecking = StrictHostKeyChecking(sshConfig.NodeStrictHostKeyChecking)
+	}
+
+	if sshConfig.WaitTimeout != nil && !changed("wait-timeout") {
+		o.WaitTimeout = sshConfig.WaitTimeout.Duration
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ssh_test
+
+import (
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	internalfake "github.com/gardener/gardenctl-v2/internal/fake"
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/ssh"
+	"github.com/gardener/gardenctl-v2/pkg/config"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+var _ = Describe("SSH Config", func() {
+	var (
+		factory        *internalfake.Factory
+		cfg            *config.Config
+		streams        util.IOStreams
+		out            *util.SafeBytesBuffer
+		errOut         *util.SafeBytesBuffer
+		shootFilename  string
+		targetProvider *internalfake.TargetProvider
+	)
+
+	BeforeEach(func() {
+		streams, _, out, errOut = util.NewTestIOStreams()
+
+		cfg = &config.Config{
+			LinkKubeconfig: ptr.To(false),
+			Gardens:        []config.Garden{{Name: "mygarden"}},
+		}
+		targetProvider = internalfake.NewFakeTargetProvider(target.NewTarget("mygarden", "prod1", "", "myshoot"))
+
+		factory = internalfake.NewFakeFactory(cfg, nil, nil, targetProvider)
+		factory.GardenHomeDirectory = GinkgoT().TempDir()
+
+		shootFilename = config.ShootSSHConfigFilename(factory.GardenHomeDirectory, "mygarden", "prod1", "myshoot")
+	})
+
+	Describe("set", func() {
+		newCmd := func(args ...string) *cobra.Command {
+			cmd := ssh.NewCmdConfigSet(factory, streams)
+			cmd.SetArgs(append([]string{}, args...))
+			cmd.SetOut(errOut)
+			cmd.SetErr(errOut)
+
+			return cmd
+		}
+
+		It("should write the given flags only", func() {
+			Expect((&config.SSHConfig{User: "core", BastionPort: "2222"}).SaveToFile(shootFilename)).To(Succeed())
+
+			cmd := newCmd("--bastion-port", "2022", "--node-strict-host-key-checking", "no")
+			Expect(cmd.Execute()).To(Succeed())
+
+			Expect(out.String()).To(Equal("Successfully set the SSH configuration of shoot \"myshoot\"\n"))
+
+			sshConfig, err := config.LoadSSHConfigFromFile(shootFilename)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sshConfig).To(Equal(&config.SSHConfig{User: "core", BastionPort: "2022", NodeStrictHostKeyChecking: "no"}))
+		})
+
+		It("should require at least one flag", func() {
+			cmd := newCmd()
+			Expect(cmd.Execute()).To(MatchError(ContainSubstring("at least one of --user")))
+		})
+
+		It("should reject invalid values", func() {
+			cmd := newCmd("--bastion-strict-host-key-checking", "maybe")
+			Expect(cmd.Execute()).To(MatchError(ContainSubstring("invalid bastion strict host key checking")))
+
+			_, err := os.Stat(shootFilename)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		It("should require a targeted shoot", func() {
+			targetProvider.Target = target.NewTarget("mygarden", "prod1", "", "")
+
+			cmd := newCmd("--user", "core")
+			Expect(cmd.Execute()).To(MatchError(target.ErrNoShootTargeted))
+		})
+	})
+
+	Describe("Complete", func() {
+		var o *ssh.SSHOptions
+
+		BeforeEach(func() {
+			o = ssh.NewSSHOptions(streams)
+			o.NoBastion = true
+		})
+
+		It("should use the built-in defaults without configuration", func() {
+			Expect(o.Complete(factory, nil, nil)).To(Succeed())
+
+			Expect(o.User).To(Equal(ssh.DefaultUsername))
+			Expect(o.BastionPort).To(Equal("22"))
+			Expect(o.NodeStrictHostKeyChecking).To(Equal(ssh.StrictHostKeyCheckingAsk))
+		})
+
+		It("should prefer the shoot configuration over the global configuration", func() {
+			cfg.SSH = &config.SSHConfig{User: "global", BastionPort: "2222"}
+			Expect((&config.SSHConfig{User: "core", NodeStrictHostKeyChecking: "no"}).SaveToFile(shootFilename)).To(Succeed())
+
+			Expect(o.Complete(factory, nil, nil)).To(Succeed())
+
+			Expect(o.User).To(Equal("core"))
+			Expect(o.BastionPort).To(Equal("2222"))
+			Expect(o.NodeStrictHostKeyChecking).To(Equal(ssh.StrictHostKeyCheckingNo))
+			Expect(o.BastionStrictHostKeyChecking).To(Equal(ssh.StrictHostKeyCheckingAsk))
+		})
+
+		It("should prefer the flags over the configuration", func() {
+			cfg.SSH = &config.SSHConfig{BastionPort: "2222"}
+			Expect((&config.SSHConfig{User: "core"}).SaveToFile(shootFilename)).To(Succeed())
+
+			cmd := ssh.NewCmdSSH(factory, o)
+			Expect(cmd.Flags().Set("user", "admin")).To(Succeed())
+			Expect(cmd.Flags().Set("bastion-port", "22")).To(Succeed())
+
+			Expect(o.Complete(factory, cmd, nil)).To(Succeed())
+
+			Expect(o.User).To(Equal("admin"))
+			Expect(o.BastionPort).To(Equal("22"))
+		})
+
+		It("should not use a shoot configuration of another target", func() {
+			Expect((&config.SSHConfig{User: "core"}).SaveToFile(shootFilename)).To(Succeed())
+			targetProvider.Target = target.NewTarget("mygarden", "prod1", "", "othershoot")
+
+			Expect(o.Complete(factory, nil, nil)).To(Succeed())
+
+			Expect(o.User).To(Equal(ssh.DefaultUsername))
+		})
+
+		It("should fail for an invalid configuration", func() {
+			cfg.SSH = &config.SSHConfig{BastionPort: "ssh"}
+
+			Expect(o.Complete(factory, nil, nil)).To(MatchError(ContainSubstring("invalid bastion port \"ssh\"")))
+		})
+
+		Context("wait timeout", func() {
+			It("should use the wait timeout of the configuration", func() {
+				cfg.SSH = &config.SSHConfig{WaitTimeout: &metav1.Duration{Duration: 2 * time.Minute}}
+
+				Expect(o.Complete(factory, ssh.NewCmdSSH(factory, o), nil)).To(Succeed())
+
+				Expect(o.WaitTimeout).To(Equal(2 * time.Minute))
+			})
+
+			It("should prefer the flag over the configuration", func() {
+				cfg.SSH = &config.SSHConfig{WaitTimeout: &metav1.Duration{Duration: 2 * time.Minute}}
+
+				cmd := ssh.NewCmdSSH(factory, o)
+				Expect(cmd.Flags().Set("wait-timeout", "30s")).To(Succeed())
+
+				Expect(o.Complete(factory, cmd, nil)).To(Succeed())
+
+				Expect(o.WaitTimeout).To(Equal(30 * time.Second))
+			})
+
+			It("should keep the built-in default without configuration", func() {
+				Expect(o.Complete(factory, nil, nil)).To(Succeed())
+
+				Expect(o.WaitTimeout).To(Equal(10 * time.Minute))
+			})
+
+			It("should fail for a zero wait timeout", func() {
+				cfg.SSH = &config.SSHConfig{WaitTimeout: &metav1.Duration{}}
+
+				Expect(o.Complete(factory, nil, nil)).To(MatchError(ContainSubstring(`invalid wait timeout "0s": must be greater than zero`)))
+			})
+		})
+	})
+})
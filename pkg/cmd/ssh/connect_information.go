@@ -30,6 +30,12 @@ type ConnectInformation struct {
 	// NodePrivateKeyFiles is a list of file paths containing the private SSH keys for the worker nodes.
 	NodePrivateKeyFiles []PrivateKeyFile `json:"nodePrivateKeyFiles"`
 
+	// NodeAgentKeyOnly is true if the worker nodes are accessed with an identity of the SSH agent instead of a private key file.
+	NodeAgentKeyOnly bool `json:"nodeAgentKeyOnly,omitempty"`
+
+	// IdentityAgent is the socket of the SSH agent used for the worker nodes instead of SSH_AUTH_SOCK.
+	IdentityAgent string `json:"identityAgent,omitempty"`
+
 	// Nodes is a list of Node objects containing information about the worker nodes.
 	Nodes []Node `json:"nodes"`
 
@@ -41,6 +47,12 @@ type ConnectInformation struct {
 
 	// NodeStrictHostKeyChecking controls the SSH strict host key checking behavior for the shoot node.
 	NodeStrictHostKeyChecking StrictHostKeyChecking `json:"nodeStrictHostKeyChecking"`
+
+	// HashKnownHosts controls whether host names and addresses are hashed when they are added to the known hosts files.
+	HashKnownHosts bool `json:"hashKnownHosts,omitempty"`
+
+	// wide controls whether the node table includes the zone, instance type and kubelet version of the nodes.
+	wide bool
 }
 
 var _ fmt.Stringer = &ConnectInformation{}
@@ -65,6 +77,8 @@ type Bastion struct {
 	UserKnownHostsFiles []string `json:"userKnownHostsFiles"`
 	// StrictHostKeyChecking controls the StrictHostKeyChecking option for the SSH connection to the bastion.
 	StrictHostKeyChecking StrictHostKeyChecking `json:"strictHostKeyChecking"`
+	// HTTPSProxy is the URL of the HTTP proxy used to tunnel the SSH connection to the bastion.
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
 }
 
 // Node holds information about a worker node.
@@ -75,6 +89,12 @@ type Node struct {
 	Status string `json:"status"`
 	// Address holds information about the IP address and hostname of the worker node.
 	Address
+	// Zone is the availability zone of the worker node. Only set for wide output.
+	Zone string `json:"zone,omitempty"`
+	// InstanceType is the machine type of the worker node. Only set for wide output.
+	InstanceType string `json:"instanceType,omitempty"`
+	// KubeletVersion is the version of the kubelet running on the worker node. Only set for wide output.
+	KubeletVersion string `json:"kubeletVersion,omitempty"`
 }
 
 // Address holds information about an IP address and hostname.
@@ -91,8 +111,10 @@ func NewConnectInformation(
 	bastionPort string,
 	bastionUserKnownHostsFiles []string,
 	bastionStrictHostKeyChecking StrictHostKeyChecking,
+	httpsProxy string,
 	nodeUserKnownHostsFiles []string,
 	nodeStrictHostKeyChecking StrictHostKeyChecking,
+	hashKnownHosts bool,
 	nodeHostname string,
 	sshPublicKeyFile PublicKeyFile,
 	sshPrivateKeyFile PrivateKeyFile,
@@ -100,6 +122,7 @@ func NewConnectInformation(
 	nodes []corev1.Node,
 	pendingNodeNames []string,
 	user string,
+	wide bool,
 ) (*ConnectInformation, error) {
 	nodeMap := make(map[string]Node)
 
@@ -120,6 +143,12 @@ func NewConnectInformation(
 			n.Status = "Not Ready"
 		}
 
+		if wide {
+			n.Zone = node.Labels[corev1.LabelTopologyZone]
+			n.InstanceType = node.Labels[corev1.LabelInstanceTypeStable]
+			n.KubeletVersion = node.Status.NodeInfo.KubeletVersion
+		}
+
 		for _, addr := range node.Status.Addresses {
 			switch addr.Type {
 			case corev1.NodeInternalIP:
@@ -166,13 +195,16 @@ func NewConnectInformation(
 			SSHPrivateKeyFile:     sshPrivateKeyFile,
 			UserKnownHostsFiles:   bastionUserKnownHostsFiles,
 			StrictHostKeyChecking: bastionStrictHostKeyChecking,
+			HTTPSProxy:            httpsProxy,
 		},
 		NodeHostname:              nodeHostname,
 		NodePrivateKeyFiles:       nodePrivateKeyFiles,
 		NodeUserKnownHostsFiles:   nodeUserKnownHostsFiles,
 		NodeStrictHostKeyChecking: nodeStrictHostKeyChecking,
+		HashKnownHosts:            hashKnownHosts,
 		Nodes:                     nodeList,
 		User:                      user,
+		wide:                      wide,
 	}, nil
 }
 
@@ -209,10 +241,21 @@ func (p *ConnectInformation) String() string {
 			Rows: []metav1.TableRow{},
 		}
 
+		if p.wide {
+			table.ColumnDefinitions = append(table.ColumnDefinitions,
+				metav1.TableColumnDefinition{Name: "Zone", Type: "string"},
+				metav1.TableColumnDefinition{Name: "Instance Type", Type: "string"},
+				metav1.TableColumnDefinition{Name: "Kubelet Version", Type: "string"},
+			)
+		}
+
 		for _, node := range p.Nodes {
-			table.Rows = append(table.Rows, metav1.TableRow{
-				Cells: []interface{}{node.Name, node.Status, node.IP, node.Hostname},
-			})
+			cells := []interface{}{node.Name, node.Status, node.IP, node.Hostname}
+			if p.wide {
+				cells = append(cells, node.Zone, node.InstanceType, node.KubeletVersion)
+			}
+
+			table.Rows = append(table.Rows, metav1.TableRow{Cells: cells})
 		}
 
 		fmt.Fprintf(&buf, "> The shoot cluster has the following nodes:\n\n")
@@ -232,10 +275,14 @@ func (p *ConnectInformation) String() string {
 		p.Bastion.SSHPrivateKeyFile,
 		p.Bastion.UserKnownHostsFiles,
 		p.Bastion.StrictHostKeyChecking,
+		p.Bastion.HTTPSProxy,
 		p.NodeUserKnownHostsFiles,
 		p.NodeStrictHostKeyChecking,
+		p.HashKnownHosts,
 		nodeHostname,
 		p.NodePrivateKeyFiles,
+		p.NodeAgentKeyOnly,
+		p.IdentityAgent,
 		p.User,
 	)
 
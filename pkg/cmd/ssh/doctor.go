@@ -0,0 +1,389 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clientgarden "github.com/gardener/gardenctl-v2/internal/client/garden"
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
+	"github.com/gardener/gardenctl-v2/pkg/flags"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+const (
+	// DoctorStatusPass indicates that a check of ssh doctor passed.
+	DoctorStatusPass = "PASS"
+	// DoctorStatusFail indicates that a check of ssh doctor failed.
+	DoctorStatusFail = "FAIL"
+	// DoctorStatusSkip indicates that a check of ssh doctor was skipped because a check it depends on failed,
+	// or that a check of an optional prerequisite did not pass.
+	DoctorStatusSkip = "SKIP"
+)
+
+// NewCmdDoctor returns a new ssh doctor command.
+func NewCmdDoctor(f util.Factory, ioStreams util.IOStreams) *cobra.Command {
+	o := &DoctorOptions{
+		Options: base.Options{
+			IOStreams: ioStreams,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the prerequisites for an SSH connection to a node of a Shoot cluster",
+		Long: `Check the prerequisites for an SSH connection to a node of a Shoot cluster and print a report with remediations for the failed checks.
+
+No bastion is created. The following is checked:
+- the garden cluster is reachable
+- the targeted shoot is found
+- SSH access to the nodes is enabled for the shoot
+- the node keypair secrets of the shoot are present
+- the SSH agent holds at least one identity, which is only required with --use-agent-key, --node-agent-key
+  or --identity-agent, as gardenctl ssh generates a temporary keypair otherwise
+- the CIDRs allowed to access the bastion can be detected
+
+Checks that depend on a failed check are skipped.`,
+		Example: `# Check the prerequisites for an SSH connection to a node of the targeted shoot
+gardenctl ssh doctor
+
+# Print the report in JSON format
+gardenctl ssh doctor --output json`,
+		Args: cobra.NoArgs,
+		RunE: base.WrapRunE(o, f),
+	}
+
+	o.AddFlags(cmd.Flags())
+	o.RegisterCompletionsForOutputFlag(cmd)
+
+	o.AccessConfig.AddFlags(cmd.Flags())
+	RegisterCompletionFuncsForAccessConfigFlags(cmd, f)
+
+	f.TargetFlags().AddFlags(cmd.Flags())
+	flags.RegisterCompletionFuncsForTargetFlags(cmd, f, ioStreams, cmd.Flags())
+
+	return cmd
+}
+
+// DoctorOptions is a struct to support the doctor command.
+type DoctorOptions struct {
+	base.Options
+
+	// AccessConfig holds the CIDRs and the IP detection URL that are used to check the egress CIDRs.
+	AccessConfig
+
+	// IdentityAgent is the socket of the SSH agent that is checked instead of the one of SSH_AUTH_SOCK.
+	IdentityAgent string
+
+	// UseAgentKey is the identity of the SSH agent that gardenctl ssh is going to use for the bastion.
+	UseAgentKey string
+
+	// NodeAgentKey is true if gardenctl ssh is going to access the node with an identity of the SSH agent.
+	NodeAgentKey bool
+}
+
+// AddFlags adds command-line flags to the flag set.
+func (o *DoctorOptions) AddFlags(flagSet *pflag.FlagSet) {
+	o.Options.AddFlags(flagSet)
+	flagSet.StringVar(&o.IdentityAgent, "identity-agent", o.IdentityAgent, "Socket of the SSH agent to check instead of the one of SSH_AUTH_SOCK.")
+	flagSet.StringVar(&o.UseAgentKey, "use-agent-key", o.UseAgentKey, "Comment or SHA256 fingerprint of the identity of the SSH agent that gardenctl ssh is going to use. The SSH agent check fails if the agent holds no identity.")
+	flagSet.BoolVar(&o.NodeAgentKey, "node-agent-key", o.NodeAgentKey, "Check the prerequisites of gardenctl ssh --node-agent-key. The SSH agent check fails if the agent holds no identity.")
+}
+
+// Complete adapts from the command line args to the data required.
+func (o *DoctorOptions) Complete(f util.Factory, _ *cobra.Command, _ []string) error {
+	// the CIDRs are detected by the egress check, which must not fail the command
+	if len(o.CIDRs) == 0 && o.IPDetectionURL == "" {
+		manager, err := f.Manager()
+		if err != nil {
+			return err
+		}
+
+		if cfg := manager.Configuration(); cfg != nil {
+			o.IPDetectionURL = cfg.IPDetectionURL
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the provided options. Invalid CIDRs are reported by the egress check.
+func (o *DoctorOptions) Validate() error {
+	return o.Options.Validate()
+}
+
+// DoctorCheck is the result of a single check of ssh doctor.
+type DoctorCheck struct {
+	// Name is the name of the check.
+	Name string `json:"name"`
+	// Status is either PASS, FAIL or SKIP.
+	Status string `json:"status"`
+	// Message describes the result of the check.
+	Message string `json:"message"`
+	// Remediation describes how to fix a failed check.
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// DoctorReport is the result of all checks of ssh doctor.
+type DoctorReport struct {
+	// Checks are the results of the checks in the order they were run.
+	Checks []DoctorCheck `json:"checks"`
+}
+
+var _ fmt.Stringer = &DoctorReport{}
+
+// Failed returns the number of failed checks.
+func (r *DoctorReport) Failed() int {
+	failed := 0
+
+	for _, check := range r.Checks {
+		if check.Status == DoctorStatusFail {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+func (r *DoctorReport) String() string {
+	var sb strings.Builder
+
+	for _, check := range r.Checks {
+		fmt.Fprintf(&sb, "%-4s %s: %s\n", check.Status, check.Name, check.Message)
+
+		if check.Remediation != "" {
+			fmt.Fprintf(&sb, "     Remediation: %s\n", check.Remediation)
+		}
+	}
+
+	return sb.String()
+}
+
+func (r *DoctorReport) pass(name, message string) {
+	r.Checks = append(r.Checks, DoctorCheck{Name: name, Status: DoctorStatusPass, Message: message})
+}
+
+func (r *DoctorReport) fail(name, message, remediation string) {
+	r.Checks = append(r.Checks, DoctorCheck{Name: name, Status: DoctorStatusFail, Message: message, Remediation: remediation})
+}
+
+func (r *DoctorReport) skip(name, dependency string) {
+	r.Checks = append(r.Checks, DoctorCheck{Name: name, Status: DoctorStatusSkip, Message: fmt.Sprintf("skipped because the %s check failed", dependency)})
+}
+
+// Run executes the command.
+func (o *DoctorOptions) Run(f util.Factory) error {
+	ctx := f.Context()
+	report := &DoctorReport{}
+
+	var shoot *gardencorev1beta1.Shoot
+
+	gardenClient, currentTarget := o.checkGarden(ctx, f, report)
+	if gardenClient != nil {
+		shoot = o.checkShoot(ctx, gardenClient, currentTarget, report)
+	} else {
+		report.skip("shoot", "garden")
+	}
+
+	switch {
+	case shoot == nil:
+		report.skip("ssh-access", "shoot")
+		report.skip("node-keypair", "shoot")
+	case o.checkSSHAccess(shoot, report):
+		o.checkNodeKeypair(ctx, gardenClient.RuntimeClient(), shoot, report)
+	default:
+		report.skip("node-keypair", "ssh-access")
+	}
+
+	o.checkSSHAgent(report)
+	o.checkEgressCIDRs(ctx, f, report)
+
+	if err := o.PrintObject(report); err != nil {
+		return err
+	}
+
+	if failed := report.Failed(); failed > 0 {
+		return fmt.Errorf("%d of %d SSH checks failed", failed, len(report.Checks))
+	}
+
+	return nil
+}
+
+// checkGarden checks that the garden of the current target is reachable. It returns a client for the garden and
+// the current target, or nil if the check failed.
+func (o *DoctorOptions) checkGarden(ctx context.Context, f util.Factory, report *DoctorReport) (clientgarden.Client, target.Target) {
+	const name = "garden"
+
+	remediation := "check the kubeconfig of the garden in the gardenctl configuration, your network connection and that your credentials are valid"
+
+	manager, err := f.Manager()
+	if err != nil {
+		report.fail(name, err.Error(), remediation)
+		return nil, nil
+	}
+
+	currentTarget, err := manager.CurrentTarget()
+	if err != nil {
+		report.fail(name, err.Error(), "target a garden, e.g. with gardenctl target garden GARDEN")
+		return nil, nil
+	}
+
+	if currentTarget.GardenName() == "" {
+		report.fail(name, target.ErrNoGardenTargeted.Error(), "target a garden, e.g. with gardenctl target garden GARDEN")
+		return nil, nil
+	}
+
+	gardenClient, err := manager.GardenClient(currentTarget.GardenName())
+	if err != nil {
+		report.fail(name, err.Error(), remediation)
+		return nil, nil
+	}
+
+	if _, err := gardenClient.ListProjects(ctx, client.Limit(1)); err != nil {
+		report.fail(name, fmt.Sprintf("garden %q is not reachable: %v", currentTarget.GardenName(), err), remediation)
+		return nil, nil
+	}
+
+	report.pass(name, fmt.Sprintf("garden %q is reachable", currentTarget.GardenName()))
+
+	return gardenClient, currentTarget
+}
+
+// checkShoot checks that the targeted shoot is found. It returns the shoot, or nil if the check failed.
+func (o *DoctorOptions) checkShoot(ctx context.Context, gardenClient clientgarden.Client, currentTarget target.Target, report *DoctorReport) *gardencorev1beta1.Shoot {
+	const name = "shoot"
+
+	currentTarget, err := resolveShootTarget(ctx, gardenClient, currentTarget)
+	if err != nil {
+		report.fail(name, err.Error(), "target a shoot, e.g. with gardenctl target --garden GARDEN --project PROJECT --shoot SHOOT")
+		return nil
+	}
+
+	shoot, err := gardenClient.FindShoot(ctx, currentTarget.AsListOption())
+	if err != nil {
+		report.fail(name, err.Error(), "check the name of the targeted shoot and that you are a member of its project")
+		return nil
+	}
+
+	report.pass(name, fmt.Sprintf("shoot %s/%s is found", shoot.Namespace, shoot.Name))
+
+	return shoot
+}
+
+// checkSSHAccess checks that SSH access to the nodes is enabled for the shoot.
+func (o *DoctorOptions) checkSSHAccess(shoot *gardencorev1beta1.Shoot, report *DoctorReport) bool {
+	const name = "ssh-access"
+
+	workersSettings := shoot.Spec.Provider.WorkersSettings
+	if workersSettings != nil && workersSettings.SSHAccess != nil && !workersSettings.SSHAccess.Enabled {
+		report.fail(name, "node SSH access is disabled for the shoot", "set spec.provider.workersSettings.sshAccess.enabled to true in the shoot")
+		return false
+	}
+
+	report.pass(name, "node SSH access is enabled for the shoot")
+
+	return true
+}
+
+// checkNodeKeypair checks that the node keypair secrets of the shoot are present.
+func (o *DoctorOptions) checkNodeKeypair(ctx context.Context, gardenClient client.Client, shoot *gardencorev1beta1.Shoot, report *DoctorReport) {
+	const name = "node-keypair"
+
+	keys, err := getShootNodePrivateKeySecrets(ctx, gardenClient, shoot)
+	if err != nil {
+		report.fail(name, err.Error(), fmt.Sprintf("check that you are allowed to read secrets in namespace %q, or use gardenctl ssh --node-agent-key to authenticate with the identities of the SSH agent", shoot.Namespace))
+		return
+	}
+
+	secretNames := make([]string, 0, len(keys))
+	for _, key := range keys {
+		secretNames = append(secretNames, key.secretName)
+	}
+
+	report.pass(name, fmt.Sprintf("node keypair secrets are present: %s", strings.Join(secretNames, ", ")))
+}
+
+// checkSSHAgent checks that the SSH agent holds at least one identity. As gardenctl ssh generates a temporary
+// keypair by default, the check only fails if the agent is required by --use-agent-key, --node-agent-key or
+// --identity-agent, and is skipped otherwise.
+func (o *DoctorOptions) checkSSHAgent(report *DoctorReport) {
+	const name = "ssh-agent"
+
+	var message string
+
+	if sshAgentSocket(o.IdentityAgent) == "" {
+		message = "no SSH agent is configured, SSH_AUTH_SOCK is not set"
+	} else if count, err := countSSHAgentSigners(o.IdentityAgent); err != nil {
+		message = err.Error()
+	} else if count == 0 {
+		message = "the SSH agent holds no identity"
+	} else {
+		report.pass(name, fmt.Sprintf("the SSH agent holds %d identities", count))
+		return
+	}
+
+	if o.UseAgentKey == "" && !o.NodeAgentKey && o.IdentityAgent == "" {
+		report.Checks = append(report.Checks, DoctorCheck{
+			Name:    name,
+			Status:  DoctorStatusSkip,
+			Message: message + ", which is not required as gardenctl ssh generates a temporary keypair",
+		})
+
+		return
+	}
+
+	report.fail(name, message, "start an SSH agent and add a key with ssh-add, or pass --public-key-file and --private-key-file to gardenctl ssh")
+}
+
+// checkEgressCIDRs checks that the CIDRs allowed to access the bastion are valid, or can be detected if not given.
+func (o *DoctorOptions) checkEgressCIDRs(ctx context.Context, f util.Factory, report *DoctorReport) {
+	const name = "egress-cidr"
+
+	if len(o.CIDRs) > 0 {
+		if err := o.AccessConfig.Validate(); err != nil {
+			report.fail(name, err.Error(), "pass valid CIDRs with --cidr")
+			return
+		}
+
+		report.pass(name, fmt.Sprintf("CIDRs are given: %s", strings.Join(o.CIDRs, ", ")))
+
+		return
+	}
+
+	remediation := "pass the CIDRs with --cidr, or set --ip-detection-url or the ipDetectionURL of the gardenctl configuration to a reachable IP detection service"
+
+	if o.IPDetectionURL != "" {
+		if err := validateIPDetectionURL(o.IPDetectionURL); err != nil {
+			report.fail(name, err.Error(), remediation)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	publicIPs, err := o.publicIPs(ctx, f)
+	if err != nil {
+		report.fail(name, fmt.Sprintf("failed to determine your system's public IP addresses: %v", err), remediation)
+		return
+	}
+
+	cidrs := make([]string, 0, len(publicIPs))
+	for _, ip := range publicIPs {
+		cidrs = append(cidrs, ipToCIDR(ip))
+	}
+
+	report.pass(name, fmt.Sprintf("CIDRs are detected: %s", strings.Join(cidrs, ", ")))
+}
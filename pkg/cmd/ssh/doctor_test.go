@@ -0,0 +1,355 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ssh_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh/agent"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clientmocks "github.com/gardener/gardenctl-v2/internal/client/mocks"
+	internalfake "github.com/gardener/gardenctl-v2/internal/fake"
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/ssh"
+	"github.com/gardener/gardenctl-v2/pkg/config"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+var _ = Describe("Doctor Command", func() {
+	const (
+		gardenName           = "mygarden"
+		gardenKubeconfigFile = "/not/a/real/kubeconfig"
+	)
+
+	var (
+		ctrl          *gomock.Controller
+		factory       *internalfake.Factory
+		streams       util.IOStreams
+		out           *util.SafeBytesBuffer
+		testProject   *gardencorev1beta1.Project
+		testShoot     *gardencorev1beta1.Shoot
+		objects       []client.Object
+		shootName     string
+		gardenErr     error
+		args          []string
+		serveIdentity bool
+	)
+
+	runDoctor := func() (*ssh.DoctorReport, error) {
+		cmd := ssh.NewCmdDoctor(factory, streams)
+		Expect(cmd.ParseFlags(append([]string{"--output", "json"}, args...))).To(Succeed())
+
+		err := cmd.RunE(cmd, nil)
+
+		report := &ssh.DoctorReport{}
+		Expect(json.Unmarshal([]byte(out.String()), report)).To(Succeed())
+
+		return report, err
+	}
+
+	checkNamed := func(report *ssh.DoctorReport, name string) ssh.DoctorCheck {
+		for _, check := range report.Checks {
+			if check.Name == name {
+				return check
+			}
+		}
+
+		Fail(fmt.Sprintf("no check named %q", name))
+
+		return ssh.DoctorCheck{}
+	}
+
+	BeforeEach(func() {
+		testProject = &gardencorev1beta1.Project{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "prod1",
+			},
+			Spec: gardencorev1beta1.ProjectSpec{
+				Namespace: ptr.To("garden-prod1"),
+			},
+		}
+
+		testShoot = &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-shoot",
+				Namespace: *testProject.Spec.Namespace,
+			},
+		}
+
+		keypairSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-shoot.ssh-keypair",
+				Namespace: *testProject.Spec.Namespace,
+			},
+			Data: map[string][]byte{
+				"id_rsa": []byte("current-key"),
+			},
+		}
+
+		objects = []client.Object{testProject, testShoot, keypairSecret}
+		shootName = testShoot.Name
+		gardenErr = nil
+		args = nil
+		serveIdentity = true
+
+		streams, _, out, _ = util.NewTestIOStreams()
+	})
+
+	JustBeforeEach(func() {
+		if serveIdentity {
+			_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+
+			keyring := agent.NewKeyring()
+			Expect(keyring.Add(agent.AddedKey{PrivateKey: privateKey})).To(Succeed())
+			serveSSHAgent(keyring)
+		}
+
+		cfg := &config.Config{
+			LinkKubeconfig: ptr.To(false),
+			Gardens: []config.Garden{{
+				Name:       gardenName,
+				Kubeconfig: gardenKubeconfigFile,
+			}},
+		}
+
+		ctrl = gomock.NewController(GinkgoT())
+		DeferCleanup(ctrl.Finish)
+
+		clientProvider := clientmocks.NewMockProvider(ctrl)
+		clientConfig, err := cfg.ClientConfig(gardenName)
+		Expect(err).NotTo(HaveOccurred())
+
+		if gardenErr != nil {
+			clientProvider.EXPECT().FromClientConfig(gomock.Eq(clientConfig)).Return(nil, gardenErr).AnyTimes()
+		} else {
+			clientProvider.EXPECT().FromClientConfig(gomock.Eq(clientConfig)).Return(internalfake.NewClientWithObjects(objects...), nil).AnyTimes()
+		}
+
+		targetProvider := internalfake.NewFakeTargetProvider(target.NewTarget(gardenName, testProject.Name, "", shootName))
+		factory = internalfake.NewFakeFactory(cfg, nil, clientProvider, targetProvider)
+		factory.ContextImpl = context.Background()
+	})
+
+	It("should pass all checks", func() {
+		report, err := runDoctor()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(report.Checks).To(HaveLen(6))
+		Expect(report.Failed()).To(BeZero())
+
+		Expect(checkNamed(report, "node-keypair").Message).To(Equal("node keypair secrets are present: test-shoot.ssh-keypair"))
+		Expect(checkNamed(report, "ssh-agent").Message).To(Equal("the SSH agent holds 1 identities"))
+		Expect(checkNamed(report, "egress-cidr").Message).To(Equal("CIDRs are detected: 192.0.2.42/32, 2001:db8::/64"))
+	})
+
+	It("should print a report with the status of each check", func() {
+		cmd := ssh.NewCmdDoctor(factory, streams)
+		Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+		Expect(out.String()).To(Equal(`PASS garden: garden "mygarden" is reachable
+PASS shoot: shoot garden-prod1/test-shoot is found
+PASS ssh-access: node SSH access is enabled for the shoot
+PASS node-keypair: node keypair secrets are present: test-shoot.ssh-keypair
+PASS ssh-agent: the SSH agent holds 1 identities
+PASS egress-cidr: CIDRs are detected: 192.0.2.42/32, 2001:db8::/64
+`))
+	})
+
+	Context("when the garden is not reachable", func() {
+		BeforeEach(func() {
+			gardenErr = errors.New("connection refused")
+		})
+
+		It("should fail the garden check and skip the dependent checks", func() {
+			report, err := runDoctor()
+			Expect(err).To(MatchError("1 of 6 SSH checks failed"))
+
+			garden := checkNamed(report, "garden")
+			Expect(garden.Status).To(Equal(ssh.DoctorStatusFail))
+			Expect(garden.Message).To(ContainSubstring("connection refused"))
+			Expect(garden.Remediation).NotTo(BeEmpty())
+
+			for _, name := range []string{"shoot", "ssh-access", "node-keypair"} {
+				Expect(checkNamed(report, name).Status).To(Equal(ssh.DoctorStatusSkip))
+			}
+
+			Expect(checkNamed(report, "ssh-agent").Status).To(Equal(ssh.DoctorStatusPass))
+		})
+	})
+
+	Context("when the shoot is not found", func() {
+		BeforeEach(func() {
+			shootName = "other-shoot"
+		})
+
+		It("should fail the shoot check and skip the dependent checks", func() {
+			report, err := runDoctor()
+			Expect(err).To(MatchError("1 of 6 SSH checks failed"))
+
+			Expect(checkNamed(report, "garden").Status).To(Equal(ssh.DoctorStatusPass))
+
+			shoot := checkNamed(report, "shoot")
+			Expect(shoot.Status).To(Equal(ssh.DoctorStatusFail))
+			Expect(shoot.Remediation).NotTo(BeEmpty())
+
+			Expect(checkNamed(report, "ssh-access").Status).To(Equal(ssh.DoctorStatusSkip))
+			Expect(checkNamed(report, "node-keypair").Status).To(Equal(ssh.DoctorStatusSkip))
+		})
+	})
+
+	Context("when SSH access is disabled", func() {
+		BeforeEach(func() {
+			testShoot.Spec.Provider.WorkersSettings = &gardencorev1beta1.WorkersSettings{
+				SSHAccess: &gardencorev1beta1.SSHAccess{Enabled: false},
+			}
+		})
+
+		It("should fail the ssh-access check and skip the node-keypair check", func() {
+			report, err := runDoctor()
+			Expect(err).To(MatchError("1 of 6 SSH checks failed"))
+
+			sshAccess := checkNamed(report, "ssh-access")
+			Expect(sshAccess.Status).To(Equal(ssh.DoctorStatusFail))
+			Expect(sshAccess.Message).To(Equal("node SSH access is disabled for the shoot"))
+			Expect(sshAccess.Remediation).To(ContainSubstring("sshAccess.enabled"))
+
+			Expect(checkNamed(report, "node-keypair").Status).To(Equal(ssh.DoctorStatusSkip))
+		})
+	})
+
+	Context("when no node keypair secret exists", func() {
+		BeforeEach(func() {
+			objects = []client.Object{testProject, testShoot}
+		})
+
+		It("should fail the node-keypair check", func() {
+			report, err := runDoctor()
+			Expect(err).To(MatchError("1 of 6 SSH checks failed"))
+
+			nodeKeypair := checkNamed(report, "node-keypair")
+			Expect(nodeKeypair.Status).To(Equal(ssh.DoctorStatusFail))
+			Expect(nodeKeypair.Message).To(Equal("no SSH keypair is available for the shoot nodes"))
+			Expect(nodeKeypair.Remediation).To(ContainSubstring(`namespace "garden-prod1"`))
+		})
+	})
+
+	Context("when the SSH agent holds no identity", func() {
+		BeforeEach(func() {
+			serveIdentity = false
+		})
+
+		It("should skip the ssh-agent check if the agent is empty", func() {
+			serveSSHAgent(agent.NewKeyring())
+
+			report, err := runDoctor()
+			Expect(err).NotTo(HaveOccurred())
+
+			sshAgent := checkNamed(report, "ssh-agent")
+			Expect(sshAgent.Status).To(Equal(ssh.DoctorStatusSkip))
+			Expect(sshAgent.Message).To(Equal("the SSH agent holds no identity, which is not required as gardenctl ssh generates a temporary keypair"))
+		})
+
+		It("should skip the ssh-agent check if no agent is configured", func() {
+			GinkgoT().Setenv("SSH_AUTH_SOCK", "")
+
+			report, err := runDoctor()
+			Expect(err).NotTo(HaveOccurred())
+
+			sshAgent := checkNamed(report, "ssh-agent")
+			Expect(sshAgent.Status).To(Equal(ssh.DoctorStatusSkip))
+			Expect(sshAgent.Message).To(HavePrefix("no SSH agent is configured, SSH_AUTH_SOCK is not set"))
+		})
+
+		DescribeTable("should fail the ssh-agent check if the agent is required",
+			func(flags ...string) {
+				serveSSHAgent(agent.NewKeyring())
+				args = flags
+
+				report, err := runDoctor()
+				Expect(err).To(MatchError("1 of 6 SSH checks failed"))
+
+				sshAgent := checkNamed(report, "ssh-agent")
+				Expect(sshAgent.Status).To(Equal(ssh.DoctorStatusFail))
+				Expect(sshAgent.Message).To(Equal("the SSH agent holds no identity"))
+				Expect(sshAgent.Remediation).To(ContainSubstring("ssh-add"))
+			},
+			Entry("with --use-agent-key", "--use-agent-key", "my-key"),
+			Entry("with --node-agent-key", "--node-agent-key"),
+		)
+
+		It("should fail the ssh-agent check if the identity agent is not reachable", func() {
+			dir, err := os.MkdirTemp("", "agent")
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(os.RemoveAll, dir)
+
+			args = []string{"--identity-agent", filepath.Join(dir, "agent.sock")}
+
+			report, err := runDoctor()
+			Expect(err).To(MatchError("1 of 6 SSH checks failed"))
+
+			sshAgent := checkNamed(report, "ssh-agent")
+			Expect(sshAgent.Status).To(Equal(ssh.DoctorStatusFail))
+			Expect(sshAgent.Message).To(ContainSubstring("could not open SSH agent socket"))
+		})
+	})
+
+	Context("when the egress CIDRs cannot be detected", func() {
+		It("should fail the egress-cidr check", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprint(w, "not-an-ip")
+			}))
+			DeferCleanup(server.Close)
+
+			args = []string{"--ip-detection-url", server.URL}
+
+			report, err := runDoctor()
+			Expect(err).To(MatchError("1 of 6 SSH checks failed"))
+
+			egress := checkNamed(report, "egress-cidr")
+			Expect(egress.Status).To(Equal(ssh.DoctorStatusFail))
+			Expect(egress.Message).To(ContainSubstring(`API returned an invalid IP ("not-an-ip")`))
+			Expect(egress.Remediation).To(ContainSubstring("--cidr"))
+		})
+
+		It("should fail the egress-cidr check for an invalid CIDR", func() {
+			args = []string{"--cidr", "10.0.0.0/33"}
+
+			report, err := runDoctor()
+			Expect(err).To(MatchError("1 of 6 SSH checks failed"))
+
+			egress := checkNamed(report, "egress-cidr")
+			Expect(egress.Status).To(Equal(ssh.DoctorStatusFail))
+			Expect(egress.Message).To(ContainSubstring(`CIDR "10.0.0.0/33" is invalid`))
+		})
+
+		It("should pass with the given CIDRs", func() {
+			args = []string{"--cidr", "10.0.0.0/8"}
+
+			report, err := runDoctor()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checkNamed(report, "egress-cidr").Message).To(Equal("CIDRs are given: 10.0.0.0/8"))
+		})
+	})
+})
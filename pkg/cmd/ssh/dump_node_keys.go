@@ -0,0 +1,143 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ssh
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
+	"github.com/gardener/gardenctl-v2/pkg/flags"
+)
+
+// NewCmdDumpNodeKeys returns a new dump-node-keys command.
+func NewCmdDumpNodeKeys(f util.Factory, ioStreams util.IOStreams) *cobra.Command {
+	o := &DumpNodeKeysOptions{
+		Options: base.Options{
+			IOStreams: ioStreams,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:   "dump-node-keys",
+		Short: "Write the private SSH keys of the Shoot cluster nodes to a directory",
+		Long: `Write the private SSH keys of the Shoot cluster nodes to a directory, e.g. for offline debugging.
+
+No bastion is created. Besides the current key, the previous key is written as well if the keypair has been rotated recently.
+The files are named after the secrets the keys are read from and can only be read by the current user.`,
+		Example: `# Write the node private keys of the targeted shoot to the directory ./keys
+gardenctl ssh dump-node-keys --directory ./keys`,
+		Args: cobra.NoArgs,
+		RunE: base.WrapRunE(o, f),
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	f.TargetFlags().AddFlags(cmd.Flags())
+	flags.RegisterCompletionFuncsForTargetFlags(cmd, f, ioStreams, cmd.Flags())
+
+	return cmd
+}
+
+// DumpNodeKeysOptions is a struct to support the dump-node-keys command.
+type DumpNodeKeysOptions struct {
+	base.Options
+
+	// Directory is the directory to which the node private keys are written.
+	Directory string
+
+	// ConfirmAccessRestriction, when set to true, implies the user understands the access restrictions for the targeted shoot.
+	ConfirmAccessRestriction bool
+}
+
+// AddFlags adds command-line flags to the flag set.
+func (o *DumpNodeKeysOptions) AddFlags(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&o.Directory, "directory", o.Directory, "Directory to which the node private keys are written. It is created if it does not exist.")
+	flagSet.BoolVarP(&o.ConfirmAccessRestriction, "confirm-access-restriction", "y", o.ConfirmAccessRestriction, "Bypasses the need for confirmation of any access restrictions. Set this flag only if you are fully aware of the access restrictions.")
+}
+
+// Validate validates the provided options.
+func (o *DumpNodeKeysOptions) Validate() error {
+	if o.Directory == "" {
+		return errors.New("the directory is required")
+	}
+
+	return nil
+}
+
+// Run executes the command.
+func (o *DumpNodeKeysOptions) Run(f util.Factory) error {
+	ctx := f.Context()
+
+	manager, err := f.Manager()
+	if err != nil {
+		return err
+	}
+
+	currentTarget, err := manager.CurrentTarget()
+	if err != nil {
+		return err
+	}
+
+	gardenClient, err := manager.GardenClient(currentTarget.GardenName())
+	if err != nil {
+		return err
+	}
+
+	currentTarget, err = resolveShootTarget(ctx, gardenClient, currentTarget)
+	if err != nil {
+		return err
+	}
+
+	shoot, err := gardenClient.FindShoot(ctx, currentTarget.AsListOption())
+	if err != nil {
+		return err
+	}
+
+	ok, err := checkAccessRestrictions(o.IOStreams, o.ConfirmAccessRestriction, false, manager.Configuration(), currentTarget.GardenName(), f.TargetFlags(), shoot)
+	if err != nil {
+		return err
+	} else if !ok {
+		return nil // abort
+	}
+
+	nodePrivateKeys, err := getShootNodePrivateKeySecrets(ctx, gardenClient.RuntimeClient(), shoot)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(o.Directory, 0o700); err != nil {
+		return fmt.Errorf("failed to create directory for node private keys: %w", err)
+	}
+
+	for _, pk := range nodePrivateKeys {
+		filename := filepath.Join(o.Directory, pk.secretName)
+
+		if err := writePrivateKeyFile(filename, pk.data); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(o.IOStreams.Out, filename)
+	}
+
+	return nil
+}
+
+// writePrivateKeyFile writes the private key to the given file, which can only be
+// read and written by the current user, even if the file existed before.
+func writePrivateKeyFile(filename string, data []byte) error {
+	if err := util.WritePrivateFile(filename, data); err != nil {
+		return fmt.Errorf("failed to write node private key file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ssh_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clientmocks "github.com/gardener/gardenctl-v2/internal/client/mocks"
+	internalfake "github.com/gardener/gardenctl-v2/internal/fake"
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/ssh"
+	"github.com/gardener/gardenctl-v2/pkg/config"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+var _ = Describe("Dump Node Keys Command", func() {
+	const (
+		gardenName           = "mygarden"
+		gardenKubeconfigFile = "/not/a/real/kubeconfig"
+	)
+
+	var (
+		ctrl        *gomock.Controller
+		factory     *internalfake.Factory
+		streams     util.IOStreams
+		out         *util.SafeBytesBuffer
+		testProject *gardencorev1beta1.Project
+		testShoot   *gardencorev1beta1.Shoot
+		objects     []client.Object
+		directory   string
+		testTarget  target.Target
+	)
+
+	newKeypairSecret := func(name string, privateKey string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: *testProject.Spec.Namespace,
+			},
+			Data: map[string][]byte{
+				"id_rsa": []byte(privateKey),
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		testProject = &gardencorev1beta1.Project{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "prod1",
+			},
+			Spec: gardencorev1beta1.ProjectSpec{
+				Namespace: ptr.To("garden-prod1"),
+			},
+		}
+
+		testShoot = &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-shoot",
+				Namespace: *testProject.Spec.Namespace,
+			},
+		}
+
+		objects = []client.Object{testProject, testShoot}
+		testTarget = target.NewTarget(gardenName, testProject.Name, "", testShoot.Name)
+
+		var err error
+		directory, err = os.MkdirTemp("", "node-keys-*")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, directory)
+
+		// the directory is created by the command
+		directory = filepath.Join(directory, "keys")
+
+		streams, _, out, _ = util.NewTestIOStreams()
+	})
+
+	JustBeforeEach(func() {
+		cfg := &config.Config{
+			LinkKubeconfig: ptr.To(false),
+			Gardens: []config.Garden{{
+				Name:       gardenName,
+				Kubeconfig: gardenKubeconfigFile,
+			}},
+		}
+
+		ctrl = gomock.NewController(GinkgoT())
+		DeferCleanup(ctrl.Finish)
+
+		clientProvider := clientmocks.NewMockProvider(ctrl)
+		clientConfig, err := cfg.ClientConfig(gardenName)
+		Expect(err).NotTo(HaveOccurred())
+		clientProvider.EXPECT().FromClientConfig(gomock.Eq(clientConfig)).Return(internalfake.NewClientWithObjects(objects...), nil).AnyTimes()
+
+		targetProvider := internalfake.NewFakeTargetProvider(testTarget)
+		factory = internalfake.NewFakeFactory(cfg, nil, clientProvider, targetProvider)
+		factory.ContextImpl = context.Background()
+	})
+
+	Context("when the keypair has been rotated", func() {
+		BeforeEach(func() {
+			objects = append(objects,
+				newKeypairSecret("test-shoot.ssh-keypair", "current-key"),
+				newKeypairSecret("test-shoot.ssh-keypair.old", "old-key"),
+			)
+		})
+
+		It("should write the current and the old key", func() {
+			cmd := ssh.NewCmdDumpNodeKeys(factory, streams)
+			Expect(cmd.Flags().Set("directory", directory)).To(Succeed())
+
+			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+			currentKeyFile := filepath.Join(directory, "test-shoot.ssh-keypair")
+			oldKeyFile := filepath.Join(directory, "test-shoot.ssh-keypair.old")
+			Expect(out.String()).To(Equal(currentKeyFile + "\n" + oldKeyFile + "\n"))
+
+			for file, content := range map[string]string{currentKeyFile: "current-key", oldKeyFile: "old-key"} {
+				data, err := os.ReadFile(file)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(Equal(content))
+
+				info, err := os.Stat(file)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+			}
+		})
+	})
+
+	Context("when only the current keypair exists", func() {
+		BeforeEach(func() {
+			objects = append(objects, newKeypairSecret("test-shoot.ssh-keypair", "current-key"))
+		})
+
+		It("should only write the current key", func() {
+			cmd := ssh.NewCmdDumpNodeKeys(factory, streams)
+			Expect(cmd.Flags().Set("directory", directory)).To(Succeed())
+
+			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+			Expect(out.String()).To(Equal(filepath.Join(directory, "test-shoot.ssh-keypair") + "\n"))
+
+			_, err := os.Stat(filepath.Join(directory, "test-shoot.ssh-keypair.old"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		It("should restrict the permissions of an existing file", func() {
+			keyFile := filepath.Join(directory, "test-shoot.ssh-keypair")
+			Expect(os.MkdirAll(directory, 0o700)).To(Succeed())
+			Expect(os.WriteFile(keyFile, []byte("stale"), 0o644)).To(Succeed())
+
+			cmd := ssh.NewCmdDumpNodeKeys(factory, streams)
+			Expect(cmd.Flags().Set("directory", directory)).To(Succeed())
+
+			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+			data, err := os.ReadFile(keyFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("current-key"))
+
+			info, err := os.Stat(keyFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+		})
+	})
+
+	Context("when the shoot is identified by its seed and name", func() {
+		BeforeEach(func() {
+			testShoot.Spec.SeedName = ptr.To("test-seed")
+			testTarget = target.NewTarget(gardenName, "", "test-seed", testShoot.Name)
+
+			objects = append(objects, newKeypairSecret("test-shoot.ssh-keypair", "current-key"))
+		})
+
+		It("should find the shoot on the seed", func() {
+			cmd := ssh.NewCmdDumpNodeKeys(factory, streams)
+			Expect(cmd.Flags().Set("directory", directory)).To(Succeed())
+
+			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+			Expect(out.String()).To(Equal(filepath.Join(directory, "test-shoot.ssh-keypair") + "\n"))
+		})
+
+		Context("and shoots of several projects match", func() {
+			BeforeEach(func() {
+				objects = append(objects, &gardencorev1beta1.Shoot{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      testShoot.Name,
+						Namespace: "garden-prod2",
+					},
+					Spec: gardencorev1beta1.ShootSpec{
+						SeedName: ptr.To("test-seed"),
+					},
+				})
+			})
+
+			It("should fail with the namespaces of the shoots", func() {
+				cmd := ssh.NewCmdDumpNodeKeys(factory, streams)
+				Expect(cmd.Flags().Set("directory", directory)).To(Succeed())
+
+				Expect(cmd.RunE(cmd, nil)).To(MatchError(ContainSubstring("in the namespaces garden-prod1, garden-prod2")))
+			})
+		})
+	})
+
+	It("should fail if no keypair exists", func() {
+		cmd := ssh.NewCmdDumpNodeKeys(factory, streams)
+		Expect(cmd.Flags().Set("directory", directory)).To(Succeed())
+
+		Expect(cmd.RunE(cmd, nil)).To(MatchError("no SSH keypair is available for the shoot nodes"))
+
+		_, err := os.Stat(directory)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("should require a directory", func() {
+		cmd := ssh.NewCmdDumpNodeKeys(factory, streams)
+
+		Expect(cmd.RunE(cmd, nil)).To(MatchError("the directory is required"))
+	})
+})
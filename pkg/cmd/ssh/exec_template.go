@@ -0,0 +1,139 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+)
+
+// ExecTemplateData holds the resolved fields that are passed to the template of the --exec-template flag.
+type ExecTemplateData struct {
+	// BastionHost is the hostname or IP address of the bastion
+	BastionHost string
+	// BastionPort is the SSH port of the bastion
+	BastionPort string
+	// BastionUser is the name of the user on the bastion
+	BastionUser string
+	// BastionPrivateKeyFile is the private SSH key file for the bastion. It is empty if the key is provided by the SSH agent
+	BastionPrivateKeyFile string
+	// BastionUserKnownHostsFiles are the known hosts files for the bastion
+	BastionUserKnownHostsFiles []string
+	// BastionStrictHostKeyChecking is the strict host key checking behavior for the bastion
+	BastionStrictHostKeyChecking string
+	// ProxyCommand is the built-in command to connect to the node through the bastion, e.g. to be used as ssh ProxyCommand
+	ProxyCommand string
+	// NodeHostname is the hostname or IP address of the node
+	NodeHostname string
+	// NodePrivateKeyFiles are the private SSH key files for the node
+	NodePrivateKeyFiles []string
+	// NodeUserKnownHostsFiles are the known hosts files for the node
+	NodeUserKnownHostsFiles []string
+	// NodeStrictHostKeyChecking is the strict host key checking behavior for the node
+	NodeStrictHostKeyChecking string
+	// IdentityAgent is the socket of the SSH agent for the node. It is empty if SSH_AUTH_SOCK is used
+	IdentityAgent string
+	// User is the name of the user on the node
+	User string
+}
+
+// parseExecTemplate parses the template of the --exec-template flag. Missing keys are reported as error when the template is rendered.
+func parseExecTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("exec").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exec template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// renderExecTemplate renders the template of the --exec-template flag and returns the command and its arguments.
+// Each non-empty line of the rendered template is taken as one argument without any shell interpretation, the
+// first one is the command.
+func renderExecTemplate(text string, data *ExecTemplateData) (string, []string, error) {
+	tmpl, err := parseExecTemplate(text)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", nil, fmt.Errorf("failed to render exec template: %w", err)
+	}
+
+	var args []string
+
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if arg := strings.TrimSpace(line); arg != "" {
+			args = append(args, arg)
+		}
+	}
+
+	if len(args) == 0 {
+		return "", nil, errors.New("the rendered exec template is empty")
+	}
+
+	return args[0], args[1:], nil
+}
+
+// newExecTemplateData returns the fields for the template of the --exec-template flag.
+func newExecTemplateData(o *SSHOptions, bastionHost, nodeHostname string, nodePrivateKeyFiles []PrivateKeyFile) *ExecTemplateData {
+	proxyCmdArgs := sshProxyCmdArguments(
+		bastionHost,
+		o.BastionPort,
+		o.SSHPrivateKeyFile,
+		userKnownHostsFilesArgument(o.BastionUserKnownHostsFiles),
+		o.BastionStrictHostKeyChecking,
+		o.HashKnownHosts,
+		o.HTTPSProxy,
+	)
+
+	data := &ExecTemplateData{
+		BastionHost:                  bastionHost,
+		BastionPort:                  o.BastionPort,
+		BastionUser:                  SSHBastionUsername,
+		BastionPrivateKeyFile:        o.SSHPrivateKeyFile.String(),
+		BastionUserKnownHostsFiles:   o.BastionUserKnownHostsFiles,
+		BastionStrictHostKeyChecking: string(o.BastionStrictHostKeyChecking),
+		ProxyCommand:                 proxyCmdArgs.String(),
+		NodeHostname:                 nodeHostname,
+		NodeUserKnownHostsFiles:      o.NodeUserKnownHostsFiles,
+		NodeStrictHostKeyChecking:    string(o.NodeStrictHostKeyChecking),
+		IdentityAgent:                o.IdentityAgent,
+		User:                         o.User,
+	}
+
+	for _, file := range nodePrivateKeyFiles {
+		data.NodePrivateKeyFiles = append(data.NodePrivateKeyFiles, file.String())
+	}
+
+	return data
+}
+
+// execTemplateCommand runs the command rendered from the template of the --exec-template flag.
+func execTemplateCommand(ctx context.Context, ioStreams util.IOStreams, text string, data *ExecTemplateData) error {
+	command, args, err := renderExecTemplate(text, data)
+	if err != nil {
+		return err
+	}
+
+	escaped := []string{util.ShellEscape(command)}
+	for _, arg := range args {
+		escaped = append(escaped, util.ShellEscape(arg))
+	}
+
+	fmt.Fprintf(ioStreams.Out, "> You can open additional SSH sessions by running the following command in a separate terminal:\n\n")
+	fmt.Fprintf(ioStreams.Out, "%s\n\n", strings.Join(escaped, " "))
+
+	return execCommand(ctx, command, args, ioStreams)
+}
@@ -8,19 +8,39 @@ package ssh
 
 import (
 	"context"
+	"io"
 	"os"
 	"time"
 
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	operationsv1alpha1 "github.com/gardener/gardener/pkg/apis/operations/v1alpha1"
 	"golang.org/x/crypto/ssh"
+	"k8s.io/klog/v2"
 
 	"github.com/gardener/gardenctl-v2/internal/util"
 )
 
-func SetBastionAvailabilityChecker(f func(hostname string, port string, privateKey []byte, hostKeyCallback ssh.HostKeyCallback) error) {
+var CreateSSHKeypair = createSSHKeypair
+
+var GetNodeHostname = getNodeHostname
+
+var GetShootNodeByProviderID = getShootNodeByProviderID
+
+var GetShootNodeByPod = getShootNodeByPod
+
+var RenderExecTemplate = renderExecTemplate
+
+var KeepBastionAlive = keepBastionAlive
+
+var ResolveControlPlaneTarget = resolveControlPlaneTarget
+
+var CheckAccessRestrictions = checkAccessRestrictions
+
+func SetBastionAvailabilityChecker(f func(hostname string, port string, privateKey []byte, identityAgent string, hostKeyCallback ssh.HostKeyCallback, httpsProxy string) error) {
 	bastionAvailabilityChecker = f
 }
 
-func SetTempFileCreator(f func() (*os.File, error)) {
+func SetTempFileCreator(f func(dir string) (*os.File, error)) {
 	tempFileCreator = f
 }
 
@@ -40,6 +60,14 @@ func SetPollBastionStatusInterval(d time.Duration) {
 	pollBastionStatusInterval = d
 }
 
+func SetWaitForCleanupTimeout(d time.Duration) {
+	waitForCleanupTimeout = d
+}
+
+func SetReconnectDelay(d time.Duration) {
+	reconnectDelay = d
+}
+
 func SetKeepAliveInterval(d time.Duration) {
 	keepAliveIntervalMutex.Lock()
 	defer keepAliveIntervalMutex.Unlock()
@@ -47,6 +75,16 @@ func SetKeepAliveInterval(d time.Duration) {
 	keepAliveInterval = d
 }
 
+func SetIsTerminalWriter(f func(out io.Writer) bool) {
+	isTerminalWriter = f
+}
+
+func SetCreateRefreshSignalChannel(f func() (chan os.Signal, func())) {
+	createRefreshSignalChannel = f
+}
+
+var RefreshNodePrivateKeyFiles = refreshNodePrivateKeyFiles
+
 func SetWaitForSignal(f func(ctx context.Context, o *SSHOptions, signalChan <-chan struct{})) {
 	waitForSignal = f
 }
@@ -61,10 +99,14 @@ func SSHCommandArguments(
 	sshPrivateKeyFile PrivateKeyFile,
 	bastionUserKnownHostsFiles []string,
 	bastionStrictHostKeyChecking StrictHostKeyChecking,
+	httpsProxy string,
 	nodeUserKnownHostsFiles []string,
 	nodeStrictHostKeyChecking StrictHostKeyChecking,
+	hashKnownHosts bool,
 	nodeHostname string,
 	nodePrivateKeyFiles []PrivateKeyFile,
+	nodeAgentKeyOnly bool,
+	identityAgent string,
 	user string,
 ) TestArguments {
 	return TestArguments{
@@ -74,11 +116,82 @@ func SSHCommandArguments(
 			sshPrivateKeyFile,
 			bastionUserKnownHostsFiles,
 			bastionStrictHostKeyChecking,
+			httpsProxy,
 			nodeUserKnownHostsFiles,
 			nodeStrictHostKeyChecking,
+			hashKnownHosts,
 			nodeHostname,
 			nodePrivateKeyFiles,
+			nodeAgentKeyOnly,
+			identityAgent,
 			user,
 		),
 	}
 }
+
+func DirectSSHCommandArguments(
+	nodeUserKnownHostsFiles []string,
+	nodeStrictHostKeyChecking StrictHostKeyChecking,
+	hashKnownHosts bool,
+	nodeHostname string,
+	nodePrivateKeyFiles []PrivateKeyFile,
+	nodeAgentKeyOnly bool,
+	identityAgent string,
+	user string,
+) TestArguments {
+	return TestArguments{
+		directSSHCommandArguments(
+			nodeUserKnownHostsFiles,
+			nodeStrictHostKeyChecking,
+			hashKnownHosts,
+			nodeHostname,
+			nodePrivateKeyFiles,
+			nodeAgentKeyOnly,
+			identityAgent,
+			user,
+		),
+	}
+}
+
+func PortForwardArguments(
+	bastionHost string,
+	bastionPort string,
+	sshPrivateKeyFile PrivateKeyFile,
+	bastionUserKnownHostsFiles []string,
+	bastionStrictHostKeyChecking StrictHostKeyChecking,
+	hashKnownHosts bool,
+	httpsProxy string,
+	localPort int,
+	remoteHost string,
+	remotePort string,
+) TestArguments {
+	return TestArguments{
+		portForwardArguments(
+			bastionHost,
+			bastionPort,
+			sshPrivateKeyFile,
+			bastionUserKnownHostsFiles,
+			bastionStrictHostKeyChecking,
+			hashKnownHosts,
+			httpsProxy,
+			localPort,
+			remoteHost,
+			remotePort,
+		),
+	}
+}
+
+var (
+	APIServerAddress      = apiServerAddress
+	PortForwardKubeconfig = portForwardKubeconfig
+)
+
+var (
+	ParseHTTPSProxy = parseHTTPSProxy
+	ProxyAddress    = proxyAddress
+	DialHTTPConnect = dialHTTPConnect
+)
+
+func (o *SSHOptions) BastionIngressPolicies(logger klog.Logger, shoot *gardencorev1beta1.Shoot) ([]operationsv1alpha1.BastionIngressPolicy, error) {
+	return o.bastionIngressPolicies(logger, shoot)
+}
@@ -0,0 +1,60 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ssh
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// HealthStatusOK indicates that the bastion host became available.
+	HealthStatusOK = "OK"
+	// HealthStatusFail indicates that the bastion host did not become available.
+	HealthStatusFail = "FAIL"
+)
+
+// HealthResult holds the result of a bastion health check.
+type HealthResult struct {
+	// Bastion is the name of the Bastion resource.
+	Bastion string `json:"bastion"`
+	// Namespace is the namespace of the Bastion resource.
+	Namespace string `json:"namespace"`
+	// Status is either OK or FAIL.
+	Status string `json:"status"`
+	// Duration is the time it took until the bastion host became available or the check failed.
+	Duration string `json:"duration"`
+	// Error holds the reason why the check failed.
+	Error string `json:"error,omitempty"`
+}
+
+var _ fmt.Stringer = &HealthResult{}
+
+// NewHealthResult returns a new HealthResult for the given bastion. If err is not nil, the status is FAIL.
+func NewHealthResult(name, namespace string, duration time.Duration, err error) *HealthResult {
+	result := &HealthResult{
+		Bastion:   name,
+		Namespace: namespace,
+		Status:    HealthStatusOK,
+		Duration:  duration.Round(time.Millisecond).String(),
+	}
+
+	if err != nil {
+		result.Status = HealthStatusFail
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+func (r *HealthResult) String() string {
+	if r.Error != "" {
+		return fmt.Sprintf("%s bastion %s/%s (%s): %s\n", r.Status, r.Namespace, r.Bastion, r.Duration, r.Error)
+	}
+
+	return fmt.Sprintf("%s bastion %s/%s (%s)\n", r.Status, r.Namespace, r.Bastion, r.Duration)
+}
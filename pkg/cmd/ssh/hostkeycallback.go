@@ -18,7 +18,6 @@ import (
 
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/knownhosts"
-	"golang.org/x/term"
 
 	"github.com/gardener/gardenctl-v2/internal/util"
 )
@@ -111,12 +110,7 @@ func defaultGetUserHomeDirFunc() (string, error) {
 
 // defaultIsTerminalFunc checks if the io.Reader is connected to a terminal.
 func defaultIsTerminalFunc(in io.Reader) bool {
-	file, ok := in.(*os.File)
-	if !ok {
-		return false
-	}
-
-	return term.IsTerminal(int(file.Fd()))
+	return util.IsTerminal(in)
 }
 
 // newInteractiveHostKeyVerifier returns a HostKeyCallback that handles interactive verification.
@@ -0,0 +1,99 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ssh
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// BastionPhase is a phase of the bastion lifecycle whose duration is recorded.
+type BastionPhase string
+
+const (
+	// BastionPhaseCreate is the creation or update of the Bastion resource.
+	BastionPhaseCreate BastionPhase = "create"
+	// BastionPhaseWaitCondition is the time until the BastionReady condition of the Bastion resource is true.
+	BastionPhaseWaitCondition BastionPhase = "wait-condition"
+	// BastionPhaseAvailabilityCheck is the time from the bastion being ready until it accepts SSH connections.
+	BastionPhaseAvailabilityCheck BastionPhase = "availability-check"
+)
+
+// MetricsRecorder records the durations of the bastion lifecycle phases. Programs embedding the
+// ssh command can set SSHOptions.MetricsRecorder to collect the durations, e.g. as OpenTelemetry metrics.
+type MetricsRecorder interface {
+	// RecordBastionPhase is called once a phase of the bastion lifecycle has completed.
+	RecordBastionPhase(phase BastionPhase, duration time.Duration)
+}
+
+// PhaseDuration is the duration of a bastion lifecycle phase as written to the metrics file.
+type PhaseDuration struct {
+	// Phase is the name of the bastion lifecycle phase.
+	Phase BastionPhase `json:"phase"`
+	// DurationSeconds is the duration of the phase in seconds.
+	DurationSeconds float64 `json:"durationSeconds"`
+}
+
+// fileMetricsRecorder collects the phase durations to write them as JSON to a file.
+// The durations are passed on to the next recorder, if set.
+type fileMetricsRecorder struct {
+	mutex  sync.Mutex
+	path   string
+	next   MetricsRecorder
+	phases []PhaseDuration
+}
+
+var _ MetricsRecorder = &fileMetricsRecorder{}
+
+func newFileMetricsRecorder(path string, next MetricsRecorder) *fileMetricsRecorder {
+	return &fileMetricsRecorder{
+		path:   path,
+		next:   next,
+		phases: []PhaseDuration{},
+	}
+}
+
+func (r *fileMetricsRecorder) RecordBastionPhase(phase BastionPhase, duration time.Duration) {
+	r.mutex.Lock()
+	r.phases = append(r.phases, PhaseDuration{Phase: phase, DurationSeconds: duration.Seconds()})
+	r.mutex.Unlock()
+
+	if r.next != nil {
+		r.next.RecordBastionPhase(phase, duration)
+	}
+}
+
+// write writes the recorded phase durations to the metrics file.
+func (r *fileMetricsRecorder) write() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	data, err := json.MarshalIndent(struct {
+		Phases []PhaseDuration `json:"phases"`
+	}{r.phases}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal metrics: %w", err)
+	}
+
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+
+	return nil
+}
+
+// recordBastionPhase records the duration of the given phase, if a MetricsRecorder is set.
+func (o *SSHOptions) recordBastionPhase(phase BastionPhase, duration time.Duration) {
+	if o.MetricsRecorder == nil {
+		return
+	}
+
+	o.MetricsRecorder.RecordBastionPhase(phase, duration)
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package ssh
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
@@ -14,16 +15,19 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/fatih/color"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	corev1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	corev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
@@ -40,13 +44,19 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
 
+	clientgarden "github.com/gardener/gardenctl-v2/internal/client/garden"
 	"github.com/gardener/gardenctl-v2/internal/util"
 	"github.com/gardener/gardenctl-v2/pkg/ac"
 	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
@@ -61,6 +71,18 @@ const (
 	DefaultUsername = "gardener"
 	// SSHPort is the TCP port on a bastion instance that allows incoming SSH.
 	SSHPort = 22
+	// DefaultReconnectMax is the default maximum number of reconnect attempts.
+	DefaultReconnectMax = 3
+	// AnnotationKeepBastionTTL is the annotation of a bastion kept by --keep-bastion that holds the
+	// maximum duration the bastion is renewed by gardenctl, see --keep-bastion-ttl.
+	AnnotationKeepBastionTTL = "gardenctl.gardener.cloud/keep-bastion-ttl"
+
+	// sshConnectionErrorExitCode is the exit code of the ssh client if an error occurred,
+	// e.g. if the connection dropped. Errors of the remote command are reported with their own exit code.
+	sshConnectionErrorExitCode = 255
+
+	// maxBastionNameAttempts is the maximum number of generated bastion names that are checked for collisions.
+	maxBastionNameAttempts = 5
 )
 
 // wrappers used for unit tests only.
@@ -73,19 +95,30 @@ var (
 	// pollBastionStatusInterval is the time in-between status checks on the bastion object.
 	pollBastionStatusInterval = 5 * time.Second
 
-	// tempFileCreator creates and opens a temporary file.
-	tempFileCreator = func() (*os.File, error) {
-		return os.CreateTemp(os.TempDir(), "gctlv2*")
+	// waitForCleanupTimeout is the maximum time to wait for the bastion to be deleted
+	// during cleanup if WaitForCleanup is set.
+	waitForCleanupTimeout = 1 * time.Minute
+
+	// reconnectDelay is the time to wait before reconnecting to the node
+	// after the SSH connection dropped if Reconnect is set.
+	reconnectDelay = 5 * time.Second
+
+	// tempFileCreator creates and opens a temporary file in the given directory.
+	tempFileCreator = func(dir string) (*os.File, error) {
+		return os.CreateTemp(dir, "gctlv2*")
 	}
 
 	// bastionAvailabilityChecker returns nil if the given hostname allows incoming
 	// connections on the SSHPort and has a public key configured that matches the
-	// given private key.
+	// given private key, or one of the identities of the SSH agent at the given socket
+	// (SSH_AUTH_SOCK if empty) if no private key is given.
 	bastionAvailabilityChecker = func(
 		hostname string,
 		port string,
 		privateKey []byte,
+		identityAgent string,
 		hostKeyCallback ssh.HostKeyCallback,
+		httpsProxy string,
 	) error {
 		var authMethods []ssh.AuthMethod
 
@@ -96,7 +129,7 @@ var (
 			}
 
 			authMethods = append(authMethods, ssh.PublicKeys(signer))
-		} else if addr := os.Getenv("SSH_AUTH_SOCK"); len(addr) > 0 {
+		} else if addr := sshAgentSocket(identityAgent); len(addr) > 0 {
 			socket, dialErr := net.Dial("unix", addr)
 			if dialErr != nil {
 				return fmt.Errorf("could not open SSH agent socket %q: %w", addr, dialErr)
@@ -113,18 +146,40 @@ var (
 			return errors.New("neither private key nor the environment variable SSH_AUTH_SOCK are defined, cannot connect to bastion")
 		}
 
-		client, err := ssh.Dial("tcp", net.JoinHostPort(hostname, port), &ssh.ClientConfig{
+		addr := net.JoinHostPort(hostname, port)
+		config := &ssh.ClientConfig{
 			User:            SSHBastionUsername,
 			HostKeyCallback: hostKeyCallback,
 			Auth:            authMethods,
 			Timeout:         10 * time.Second,
-		})
+		}
 
-		if client != nil {
-			_ = client.Close()
+		if httpsProxy == "" {
+			client, err := ssh.Dial("tcp", addr, config)
+			if client != nil {
+				_ = client.Close()
+			}
+
+			return err
 		}
 
-		return err
+		proxy, err := parseHTTPSProxy(httpsProxy)
+		if err != nil {
+			return err
+		}
+
+		conn, err := dialHTTPConnect(proxy, addr, config.Timeout)
+		if err != nil {
+			return err
+		}
+
+		c, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+		if err != nil {
+			conn.Close()
+			return err
+		}
+
+		return ssh.NewClient(c, chans, reqs).Close()
 	}
 
 	// bastionNameProvider generates the name for a new bastion.
@@ -183,6 +238,13 @@ type SSHOptions struct {
 	// automatically generated.
 	BastionName string
 
+	// BastionLabels are the labels set on the bastion, e.g. for cost attribution.
+	BastionLabels map[string]string
+
+	// GeneratedBastionName is true if the BastionName has been generated, in which case
+	// another name is generated if a bastion with this name already exists.
+	GeneratedBastionName bool
+
 	// BastionHost overrides the hostname or IP address of the Bastion used for the SSH command.
 	// If not provided, the address will be determined from .status.ingress.ip or
 	// status.ingress.hostname of the Bastion.
@@ -223,7 +285,18 @@ type SSHOptions struct {
 	// instead of being provided by the user. This will then be used for the cleanup.
 	GeneratedSSHKeys bool
 
-	// WaitTimeout is the maximum time to wait for a bastion to become ready.
+	// UseAgentKey is the comment or SHA256 fingerprint of an identity of the user's SSH agent.
+	// If set, the public key of this identity is used for the bastion and the private key is provided by the agent.
+	UseAgentKey string
+
+	// WrittenAgentPublicKey is true if the public SSH key has been taken from the SSH agent
+	// and written to a temporary file. This will then be used for the cleanup.
+	WrittenAgentPublicKey bool
+
+	// ConditionTimeout is the maximum time to wait for the BastionReady condition of a bastion.
+	ConditionTimeout time.Duration
+
+	// WaitTimeout is the maximum time to wait for a ready bastion to accept SSH connections.
 	WaitTimeout time.Duration
 
 	// KeepBastion will control whether or not gardenctl deletes the created
@@ -231,6 +304,15 @@ type SSHOptions struct {
 	// keep it for debugging purposes.
 	KeepBastion bool
 
+	// KeepBastionTTL is the maximum duration a kept bastion is renewed. It is written to the
+	// bastion as AnnotationKeepBastionTTL and the keepalive stops once it elapsed, so that the
+	// bastion is garbage-collected afterwards. Zero keeps renewing the bastion until gardenctl exits.
+	KeepBastionTTL time.Duration
+
+	// WaitForCleanup controls whether gardenctl waits until the deleted bastion
+	// is gone before it exits.
+	WaitForCleanup bool
+
 	// SkipAvailabilityCheck determines whether to check for the availability of
 	// the bastion host.
 	SkipAvailabilityCheck bool
@@ -245,8 +327,143 @@ type SSHOptions struct {
 	// In this case, the access restriction banner is displayed without further confirmation.
 	ConfirmAccessRestriction bool
 
+	// ExplainAccessRestrictions prints which configured access restrictions match the shoot
+	// and the shoot fields that triggered them.
+	ExplainAccessRestrictions bool
+
+	// NodeAgentKey allows to access the node with an identity of the SSH agent if the node keypair secret
+	// of the shoot does not exist or cannot be read.
+	NodeAgentKey bool
+
+	// NodeAgentKeyOnly is true if no node private key is available and the node is accessed with an identity
+	// of the SSH agent. It is set by Run if NodeAgentKey is set.
+	NodeAgentKeyOnly bool
+
+	// RefreshNodeKeys rewrites the node private key files with the current node keypair secrets of the shoot
+	// whenever gardenctl receives SIGHUP while it keeps the bastion alive in non-interactive mode.
+	RefreshNodeKeys bool
+
+	// IdentityAgent is the socket of the SSH agent that is used instead of SSH_AUTH_SOCK, e.g. of a
+	// hardware token or password manager. It is set as IdentityAgent for the node and used to check
+	// the availability of the bastion.
+	IdentityAgent string
+
+	// PortForward is the local port that is forwarded through the bastion to the kube-apiserver of the shoot.
+	// A kubeconfig for the forwarded port is printed to stdout. Disabled if zero.
+	PortForward int
+
+	// BannerFile is the path to a file with additional text, e.g. a legal banner, that is displayed
+	// on stderr together with the access restrictions of the targeted shoot.
+	BannerFile string
+
 	// HostKeyCallbackFactory is used to create SSH host key callbacks based on the StrictHostKeyChecking setting.
 	HostKeyCallbackFactory HostKeyCallbackFactory
+
+	// Health controls whether the command only checks that the bastion host becomes available.
+	// The result is printed and no SSH connection is established. The command fails if the
+	// bastion does not become available.
+	Health bool
+
+	// Summary controls whether a summary of the bastion and the key files used by the session
+	// is printed after the session ended.
+	Summary bool
+
+	// PrintPrivateKeyPath controls whether the paths of the node private key files and the bastion
+	// private key file are printed to stderr in interactive mode, e.g. to configure external tools.
+	PrintPrivateKeyPath bool
+
+	// WaitShoot is the maximum duration to wait for the targeted shoot to appear, e.g. if it has just been created.
+	WaitShoot time.Duration
+
+	// TempDir is the directory for the generated SSH keypair, the node private key files and
+	// the known hosts files of the bastion. Defaults to the temporary directory of gardenctl.
+	TempDir string
+
+	// GeneratedBastionKnownHostsDir is the directory of the default known hosts file of the bastion,
+	// which is removed together with the bastion during the cleanup.
+	GeneratedBastionKnownHostsDir string
+
+	// PrintBastionYAML controls whether the bastion is printed as YAML to stderr if it does not
+	// become ready or available in time, e.g. to debug its status and conditions.
+	PrintBastionYAML bool
+
+	// AllowNodeCIDR controls whether the node network CIDR of the shoot is added to the
+	// ingress policies of the bastion, e.g. to allow access from the shoot nodes.
+	AllowNodeCIDR bool
+
+	// HTTPSProxy is the URL of an HTTP proxy supporting the CONNECT method. If set, the
+	// SSH connections to the bastion are tunneled through this proxy.
+	HTTPSProxy string
+
+	// Impersonate is the user to impersonate when accessing the seed and shoot clusters,
+	// e.g. to fetch the machines and nodes on behalf of a user.
+	Impersonate string
+
+	// ImpersonateGroups are the groups to impersonate when accessing the seed and shoot clusters.
+	ImpersonateGroups []string
+
+	// NodeAddressPreference is the ordered list of node address types used to determine
+	// the hostname of the node. If empty, defaultNodeAddressPreference is used.
+	NodeAddressPreference []string
+
+	// NodeIPFamily restricts the addresses used to determine the hostname of the node to IP addresses
+	// of the given family, either ipv4 or ipv6. If empty, addresses of any family are used.
+	NodeIPFamily string
+
+	// ExecTemplate is a Go template that renders the command to connect to the node in interactive mode,
+	// one argument per line. If empty, the built-in ssh command is used.
+	ExecTemplate string
+
+	// ProviderID is the provider ID of the Shoot cluster node that the user wants to connect to.
+	// If set, the node is determined by its .spec.providerID instead of its name.
+	ProviderID string
+
+	// Pod is the namespace and name of a pod in the Shoot cluster, in the format namespace/name.
+	// If set, the node the pod is scheduled on is connected to.
+	Pod string
+
+	// NoBastion controls whether the node is connected to directly, without creating a bastion.
+	// This requires that the node is reachable from the client, e.g. through a VPN.
+	NoBastion bool
+
+	// MetricsRecorder is called with the durations of the bastion lifecycle phases.
+	// If nil, the durations are not recorded.
+	MetricsRecorder MetricsRecorder
+
+	// MetricsFile is the path of a file to which the durations of the bastion
+	// lifecycle phases are written as JSON.
+	MetricsFile string
+
+	// HashKnownHosts adds the HashKnownHosts option to the SSH connections to the bastion and the node,
+	// so that host names and addresses are hashed when they are added to the known hosts files.
+	HashKnownHosts bool
+
+	// Reconnect opens the SSH connection to the node again if it dropped,
+	// as long as the bastion is still alive. Only used in interactive mode.
+	Reconnect bool
+
+	// ReconnectMax is the maximum number of reconnect attempts if Reconnect is set.
+	ReconnectMax int
+
+	// NodeLabelFilter is a label selector that restricts the node names suggested
+	// by the shell completion of NODE_NAME to the matching nodes.
+	NodeLabelFilter string
+
+	// Wide includes additional information like the zone, instance type and
+	// kubelet version when listing the nodes in non-interactive mode.
+	Wide bool
+
+	// NodeReadinessCheck checks the Ready condition of the node given by name before connecting
+	// and warns if the node is not ready.
+	NodeReadinessCheck bool
+
+	// RequireReady fails instead of warning if the node given by name is not ready.
+	// It implies NodeReadinessCheck.
+	RequireReady bool
+
+	// NoColor disables the colorized bastion status lines, which are otherwise used if stderr
+	// is a terminal and the NO_COLOR environment variable is not set.
+	NoColor bool
 }
 
 // NewSSHOptions returns initialized SSHOptions.
@@ -257,6 +474,7 @@ func NewSSHOptions(ioStreams util.IOStreams) *SSHOptions {
 			IOStreams: ioStreams,
 		},
 		Interactive:                  true,
+		ConditionTimeout:             10 * time.Minute,
 		WaitTimeout:                  10 * time.Minute,
 		KeepBastion:                  false,
 		SkipAvailabilityCheck:        false,
@@ -265,6 +483,7 @@ func NewSSHOptions(ioStreams util.IOStreams) *SSHOptions {
 		User:                         DefaultUsername,
 		BastionStrictHostKeyChecking: StrictHostKeyCheckingAsk,
 		NodeStrictHostKeyChecking:    StrictHostKeyCheckingAsk,
+		ReconnectMax:                 DefaultReconnectMax,
 		HostKeyCallbackFactory:       NewRealHostKeyCallbackFactory(),
 	}
 }
@@ -273,20 +492,56 @@ func NewSSHOptions(ioStreams util.IOStreams) *SSHOptions {
 func (o *SSHOptions) AddFlags(flagSet *pflag.FlagSet) {
 	flagSet.BoolVar(&o.Interactive, "interactive", o.Interactive, "Open an SSH connection instead of just providing the bastion host (only if NODE_NAME is provided).")
 	flagSet.Var(&o.SSHPublicKeyFile, "public-key-file", "Path to the file that contains a public SSH key. If not given, a temporary keypair will be generated.")
+	flagSet.StringVar(&o.UseAgentKey, "use-agent-key", o.UseAgentKey, "Comment or SHA256 fingerprint of an identity loaded into the SSH agent. Its public key is used for the bastion and the private key is provided by the agent. Cannot be combined with --public-key-file and --private-key-file.")
 	flagSet.Var(&o.SSHPrivateKeyFile, "private-key-file", "Path to the file that contains a private SSH key. Must be provided alongside the --public-key-file flag if you want to use a custom keypair. If not provided, gardenctl will either generate a temporary keypair or rely on the user's SSH agent for an available private key.")
-	flagSet.DurationVar(&o.WaitTimeout, "wait-timeout", o.WaitTimeout, "Maximum duration to wait for the bastion to become available.")
+	flagSet.DurationVar(&o.ConditionTimeout, "condition-timeout", o.ConditionTimeout, "Maximum duration to wait for the bastion to become ready.")
+	flagSet.DurationVar(&o.WaitTimeout, "wait-timeout", o.WaitTimeout, "Maximum duration to wait for the ready bastion to accept SSH connections. Overrides the waitTimeout of the SSH configuration.")
 	flagSet.BoolVar(&o.KeepBastion, "keep-bastion", o.KeepBastion, "Do not delete immediately when gardenctl exits (Bastions will be garbage-collected after some time)")
+	flagSet.DurationVar(&o.KeepBastionTTL, "keep-bastion-ttl", o.KeepBastionTTL, "Maximum duration the bastion kept by --keep-bastion is renewed, e.g. 2h. It is written to the bastion as annotation "+AnnotationKeepBastionTTL+" and the bastion is no longer kept alive once it elapsed, so that it is garbage-collected afterwards. Requires --keep-bastion.")
+	flagSet.BoolVar(&o.WaitForCleanup, "wait-for-cleanup", o.WaitForCleanup, "Wait until the bastion has been deleted before gardenctl exits. Cannot be combined with --keep-bastion.")
 	flagSet.BoolVar(&o.SkipAvailabilityCheck, "skip-availability-check", o.SkipAvailabilityCheck, "Skip checking for SSH bastion host availability.")
 	flagSet.BoolVar(&o.NoKeepalive, "no-keepalive", o.NoKeepalive, "Exit after the bastion host became available without keeping the bastion alive or establishing an SSH connection. Note that this flag requires the flags --interactive=false and --keep-bastion to be set")
 	flagSet.StringVar(&o.BastionName, "bastion-name", o.BastionName, "Name of the bastion. If a bastion with this name doesn't exist, it will be created. If it does exist, the provided public SSH key must match the one used during the bastion's creation.")
+	flagSet.StringToStringVar(&o.BastionLabels, "label-bastion", o.BastionLabels, "Label in the format key=value that is set on the bastion, e.g. for cost attribution. Can be repeated.")
 	flagSet.StringVar(&o.BastionHost, "bastion-host", o.BastionHost, "Override the hostname or IP address of the bastion used for the SSH client command. If not provided, the address will be automatically determined.")
 	flagSet.StringVar(&o.BastionPort, "bastion-port", o.BastionPort, "SSH port of the bastion used for the SSH client command. Defaults to port 22")
 	flagSet.StringSliceVar(&o.BastionUserKnownHostsFiles, "bastion-user-known-hosts-file", o.BastionUserKnownHostsFiles, "Path to a custom known hosts file for verifying remote hosts' public keys during SSH connection to the bastion. If not provided, defaults to <temp_dir>/garden/cache/<bastion_uid>/.ssh/known_hosts")
 	flagSet.Var(&o.BastionStrictHostKeyChecking, "bastion-strict-host-key-checking", "Specifies how the SSH client performs host key checking for the bastion host. Valid options are 'yes', 'no', or 'ask'.")
 	flagSet.StringSliceVar(&o.NodeUserKnownHostsFiles, "node-user-known-hosts-file", o.NodeUserKnownHostsFiles, "Path to a custom known hosts file for verifying remote hosts' public keys during SSH connection to the shoot node. If not provided, defaults to <garden_home_dir>/cache/<shoot_uid>/.ssh/known_hosts.")
+	flagSet.BoolVar(&o.HashKnownHosts, "hash-known-hosts", o.HashKnownHosts, "Hash host names and addresses when they are added to the known hosts files of the bastion and the shoot node (HashKnownHosts=yes).")
 	flagSet.Var(&o.NodeStrictHostKeyChecking, "node-strict-host-key-checking", "Specifies how the SSH client performs host key checking for the shoot node. Valid options are 'yes', 'no', or 'ask'.")
 	flagSet.BoolVarP(&o.ConfirmAccessRestriction, "confirm-access-restriction", "y", o.ConfirmAccessRestriction, "Bypasses the need for confirmation of any access restrictions. Set this flag only if you are fully aware of the access restrictions.")
+	flagSet.BoolVar(&o.NodeAgentKey, "node-agent-key", o.NodeAgentKey, "Access the node with an identity of the SSH agent if the node keypair secret of the shoot does not exist or cannot be read. The node private key is then omitted from the ssh command and IdentitiesOnly=yes is not set for the node.")
+	flagSet.BoolVar(&o.RefreshNodeKeys, "refresh-node-keys", o.RefreshNodeKeys, "Rewrite the node private key files with the current node keypair secrets of the shoot when gardenctl receives SIGHUP, e.g. after a key rotation during a long --keep-bastion session. The paths of the rewritten files are printed to stderr. Requires --interactive=false.")
+	flagSet.StringVar(&o.IdentityAgent, "identity-agent", o.IdentityAgent, "Path to the socket of the SSH agent to use instead of SSH_AUTH_SOCK, e.g. of a hardware token or password manager. It is set as IdentityAgent for the node and used to check the availability of the bastion.")
+	flagSet.BoolVar(&o.ExplainAccessRestrictions, "explain-access-restrictions", o.ExplainAccessRestrictions, "Print to stderr which configured access restrictions and options match the shoot, together with the shoot field and value that triggered them.")
+	flagSet.IntVar(&o.PortForward, "port-forward", o.PortForward, "Local port to forward through the bastion to the kube-apiserver of the shoot, e.g. on restricted networks. A kubeconfig for the forwarded port is printed to stdout and the port is forwarded until gardenctl is stopped.")
+	flagSet.StringVar(&o.BannerFile, "banner-file", o.BannerFile, "Path to a file with additional text, e.g. a legal banner, that is displayed on stderr together with the access restrictions before asking for confirmation.")
 	flagSet.StringVar(&o.User, "user", o.User, "user is the name of the Shoot cluster node ssh login username.")
+	flagSet.BoolVar(&o.Health, "health", o.Health, "Check that the bastion host becomes available, print the result including the elapsed time and exit. The command fails if the bastion is not reachable via SSH. The bastion is deleted afterwards unless --keep-bastion is set.")
+	flagSet.BoolVar(&o.NoColor, "no-color", o.NoColor, "Disable the colorized bastion status lines. Colors are only used if stderr is a terminal and the NO_COLOR environment variable is not set, and never for the json and yaml output.")
+	flagSet.BoolVar(&o.Summary, "summary", o.Summary, "Print a summary of the bastion, the node and the key files after the session ended. The summary is written to stderr, or in the selected output format to stdout if --output is set.")
+	flagSet.BoolVar(&o.PrintPrivateKeyPath, "print-private-key-path", o.PrintPrivateKeyPath, "Print the paths of the node private key files and the bastion private key file to stderr in interactive mode. Combine with --keep-bastion to keep the files after gardenctl exits.")
+	flagSet.DurationVar(&o.WaitShoot, "wait-shoot", o.WaitShoot, "Maximum duration to wait for the targeted shoot to appear, e.g. if it has just been created. By default the shoot must already exist.")
+	flagSet.StringVar(&o.TempDir, "temp-dir", o.TempDir, "Directory for the generated SSH keypair, the node private key files and the known hosts files of the bastion. Defaults to the temporary directory of gardenctl, e.g. ${TMPDIR}/garden.")
+	flagSet.BoolVar(&o.PrintBastionYAML, "print-bastion-yaml", o.PrintBastionYAML, "Print the bastion including its status and conditions as YAML to stderr if it does not become ready or available in time.")
+	flagSet.BoolVar(&o.AllowNodeCIDR, "allow-node-cidr", o.AllowNodeCIDR, "Additionally allow access to the bastion host from the node network CIDR of the shoot.")
+	flagSet.StringVar(&o.HTTPSProxy, "https-proxy", o.HTTPSProxy, "URL of an HTTP proxy supporting the CONNECT method, e.g. http://proxy.example.com:3128. If set, the SSH connections to the bastion are tunneled through this proxy. The generated SSH command requires nc (netcat) with proxy support. Proxies requiring credentials are not supported.")
+	flagSet.StringVar(&o.Impersonate, "as", o.Impersonate, "Username to impersonate when accessing the seed and shoot clusters, e.g. to list the machines and nodes.")
+	flagSet.StringArrayVar(&o.ImpersonateGroups, "as-group", o.ImpersonateGroups, "Group to impersonate when accessing the seed and shoot clusters, this flag can be repeated to specify multiple groups. Requires --as.")
+	flagSet.BoolVar(&o.NoBastion, "no-bastion", o.NoBastion, "Connect directly to the node without creating a bastion. The node must be reachable from your system, e.g. through a VPN. Requires NODE_NAME, which may also be the hostname or IP address of the node.")
+	flagSet.StringVar(&o.MetricsFile, "metrics-file", o.MetricsFile, "Path of a file to which the durations of the bastion creation, of waiting for the bastion to become ready and of the availability check are written as JSON.")
+	flagSet.BoolVar(&o.Reconnect, "reconnect", o.Reconnect, "Reconnect to the node if the SSH connection dropped, as long as the bastion is still alive. Only supported in interactive mode.")
+	flagSet.IntVar(&o.ReconnectMax, "reconnect-max", o.ReconnectMax, "Maximum number of reconnect attempts when using the --reconnect flag.")
+	flagSet.StringVar(&o.NodeLabelFilter, "node-label-filter", o.NodeLabelFilter, "Label selector to restrict the node names suggested by the shell completion of NODE_NAME, e.g. worker.gardener.cloud/pool=cpu-worker.")
+	flagSet.BoolVar(&o.Wide, "wide", o.Wide, "Include the zone, instance type and kubelet version of the nodes when listing them in non-interactive mode.")
+	flagSet.BoolVar(&o.NodeReadinessCheck, "node-readiness-check", o.NodeReadinessCheck, "Check the Ready condition of the node given by NODE_NAME before connecting and print a warning if it is not ready.")
+	flagSet.BoolVar(&o.RequireReady, "require-ready", o.RequireReady, "Fail instead of printing a warning if the node given by NODE_NAME is not ready. Implies --node-readiness-check.")
+	flagSet.StringVar(&o.NodeIPFamily, "node-ip-family", o.NodeIPFamily, "Only connect to an IP address of the given family of the node, either ipv4 or ipv6. Combined with --node-address-preference, e.g. to prefer the IPv6 internal address of a dual-stack node. DNS names are not used if set.")
+	flagSet.StringVar(&o.ExecTemplate, "exec-template", o.ExecTemplate, "Go template that renders the command to connect to the node in interactive mode instead of the built-in ssh command. Each non-empty line of the rendered template is one argument, the first one is the command. Available fields are .BastionHost, .BastionPort, .BastionUser, .BastionPrivateKeyFile, .BastionUserKnownHostsFiles, .BastionStrictHostKeyChecking, .ProxyCommand, .NodeHostname, .NodePrivateKeyFiles, .NodeUserKnownHostsFiles, .NodeStrictHostKeyChecking, .IdentityAgent and .User.")
+	flagSet.StringVar(&o.Pod, "pod", o.Pod, "Namespace and name of a pod in the format namespace/name. Connects to the node the pod is scheduled on. Cannot be combined with NODE_NAME or --provider-id.")
+	flagSet.StringVar(&o.ProviderID, "provider-id", o.ProviderID, "Provider ID of the node to connect to, as given in .spec.providerID of the node, e.g. aws:///eu-west-1a/i-0123456789abcdef0. Cannot be combined with NODE_NAME.")
+	flagSet.StringSliceVar(&o.NodeAddressPreference, "node-address-preference", o.NodeAddressPreference, "Ordered comma separated list of node address types used to determine the address of the node, e.g. ExternalIP,InternalIP. Valid types are InternalIP, InternalDNS, ExternalIP, ExternalDNS and Hostname. Defaults to InternalIP,InternalDNS,ExternalIP,ExternalDNS")
 	o.Options.AddFlags(flagSet)
 }
 
@@ -307,17 +562,94 @@ func (o *SSHOptions) RegisterCompletionFuncsForStrictHostKeyCheckings(cmd *cobra
 	}))
 }
 
+// RegisterCompletionFuncForNodeAddressPreference registers the completion of the node address types.
+func (o *SSHOptions) RegisterCompletionFuncForNodeAddressPreference(cmd *cobra.Command) {
+	utilruntime.Must(cmd.RegisterFlagCompletionFunc("node-address-preference", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		nodeAddressTypes := make([]string, 0, len(validNodeAddressTypes))
+		for _, t := range validNodeAddressTypes {
+			nodeAddressTypes = append(nodeAddressTypes, string(t))
+		}
+
+		return nodeAddressTypes, cobra.ShellCompDirectiveNoFileComp
+	}))
+	utilruntime.Must(cmd.RegisterFlagCompletionFunc("node-ip-family", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{nodeIPFamilyIPv4, nodeIPFamilyIPv6}, cobra.ShellCompDirectiveNoFileComp
+	}))
+}
+
 // Complete adapts from the command line args to the data required.
-func (o *SSHOptions) Complete(f util.Factory, cmd *cobra.Command, args []string) error {
+func (o *SSHOptions) Complete(f util.Factory, cmd *cobra.Command, args []string) (err error) {
 	ctx := f.Context()
 	logger := klog.FromContext(ctx)
 
+	// Run is not called if Complete fails, hence the key files written so far need to be removed here
+	defer func() {
+		if err != nil {
+			o.removeSSHKeyFiles(logger)
+		}
+	}()
+
+	if len(args) > 0 {
+		o.NodeName = strings.TrimSpace(args[0])
+	}
+
+	if o.TempDir == "" {
+		o.TempDir = f.GardenTempDir()
+	}
+
+	if o.TempDir != "" {
+		if err := os.MkdirAll(o.TempDir, 0o700); err != nil {
+			return fmt.Errorf("failed to create temporary directory %q: %w", o.TempDir, err)
+		}
+	}
+
+	if err := o.completeSSHConfig(f, cmd); err != nil {
+		return err
+	}
+
+	if o.NodeName == "" && o.ProviderID == "" && o.Pod == "" && o.Interactive {
+		logger.V(4).Info("no node name given, switching to non-interactive mode")
+
+		o.Interactive = false
+	}
+
+	if o.Health && o.Interactive {
+		logger.V(4).Info("health check requested, switching to non-interactive mode")
+
+		o.Interactive = false
+	}
+
+	if o.NoBastion {
+		// neither CIDRs nor a keypair for the bastion are required
+		return nil
+	}
+
+	return o.completeBastion(f, cmd, args)
+}
+
+// completeBastion completes the options that are only required to create a bastion.
+func (o *SSHOptions) completeBastion(f util.Factory, cmd *cobra.Command, args []string) error {
+	if o.MetricsFile != "" {
+		o.MetricsRecorder = newFileMetricsRecorder(o.MetricsFile, o.MetricsRecorder)
+	}
+
 	if err := o.AccessConfig.Complete(f, cmd, args); err != nil {
 		return err
 	}
 
+	// combining --use-agent-key with key files is rejected by Validate
+	if o.UseAgentKey != "" && len(o.SSHPublicKeyFile) == 0 && len(o.SSHPrivateKeyFile) == 0 {
+		publicKeyFile, err := writeSSHAgentPublicKey(o.TempDir, o.UseAgentKey, o.IdentityAgent)
+		if err != nil {
+			return err
+		}
+
+		o.SSHPublicKeyFile = publicKeyFile
+		o.WrittenAgentPublicKey = true
+	}
+
 	if len(o.SSHPublicKeyFile) == 0 {
-		privateKeyFile, publicKeyFile, err := createSSHKeypair("", "")
+		privateKeyFile, publicKeyFile, err := createSSHKeypair(o.TempDir, "")
 		if err != nil {
 			return fmt.Errorf("failed to generate SSH keypair: %w", err)
 		}
@@ -328,7 +660,7 @@ func (o *SSHOptions) Complete(f util.Factory, cmd *cobra.Command, args []string)
 	}
 
 	if len(o.SSHPrivateKeyFile) == 0 {
-		count, err := countSSHAgentSigners()
+		count, err := countSSHAgentSigners(o.IdentityAgent)
 		if err != nil {
 			return fmt.Errorf("failed to check SSH agent status: %w", err)
 		} else if count == 0 {
@@ -336,16 +668,6 @@ func (o *SSHOptions) Complete(f util.Factory, cmd *cobra.Command, args []string)
 		}
 	}
 
-	if len(args) > 0 {
-		o.NodeName = strings.TrimSpace(args[0])
-	}
-
-	if o.NodeName == "" && o.Interactive {
-		logger.V(4).Info("no node name given, switching to non-interactive mode")
-
-		o.Interactive = false
-	}
-
 	if o.BastionName == "" {
 		name, err := bastionNameProvider()
 		if err != nil {
@@ -353,6 +675,7 @@ func (o *SSHOptions) Complete(f util.Factory, cmd *cobra.Command, args []string)
 		}
 
 		o.BastionName = name
+		o.GeneratedBastionName = true
 	}
 
 	return nil
@@ -385,18 +708,62 @@ func ipToCIDR(address string) string {
 
 // Validate validates the provided SSHOptions.
 func (o *SSHOptions) Validate() error {
+	// Run is not called if Validate fails, hence the key files written by Complete need to be removed here
+	if err := o.validate(); err != nil {
+		o.removeSSHKeyFiles(klog.Background())
+		return err
+	}
+
+	return nil
+}
+
+func (o *SSHOptions) validate() error {
 	if err := o.Options.Validate(); err != nil {
 		return err
 	}
 
+	if o.WaitShoot < 0 {
+		return errors.New("--wait-shoot must not be negative")
+	}
+
+	if o.IdentityAgent != "" {
+		if err := validateIdentityAgent(o.IdentityAgent); err != nil {
+			return err
+		}
+	}
+
+	if o.NoBastion {
+		return o.validateNoBastion()
+	}
+
 	if err := o.AccessConfig.Validate(); err != nil {
 		return err
 	}
 
+	if o.ConditionTimeout == 0 {
+		return errors.New("the maximum duration to wait for the bastion to become ready must be non-zero")
+	}
+
 	if o.WaitTimeout == 0 {
 		return errors.New("the maximum wait duration must be non-zero")
 	}
 
+	if o.WaitForCleanup && o.KeepBastion {
+		return errors.New("--wait-for-cleanup cannot be combined with --keep-bastion")
+	}
+
+	if o.KeepBastionTTL < 0 {
+		return errors.New("--keep-bastion-ttl must not be negative")
+	}
+
+	if o.KeepBastionTTL > 0 && !o.KeepBastion {
+		return errors.New("set --keep-bastion when using --keep-bastion-ttl")
+	}
+
+	if o.RefreshNodeKeys && o.Interactive {
+		return errors.New("set --interactive=false when using --refresh-node-keys")
+	}
+
 	if o.NoKeepalive {
 		if o.Interactive {
 			return errors.New("set --interactive=false when disabling keepalive")
@@ -413,8 +780,57 @@ func (o *SSHOptions) Validate() error {
 		}
 	}
 
-	if o.User == "" {
-		return errors.New("user must not be empty")
+	if o.Wide && o.Interactive {
+		return errors.New("set --interactive=false when using the wide flag")
+	}
+
+	if o.Summary && o.Health {
+		return errors.New("--summary cannot be combined with --health")
+	}
+
+	if o.Reconnect {
+		if !o.Interactive || o.Output != "" {
+			return errors.New("--reconnect is only supported in interactive mode")
+		}
+
+		if o.ReconnectMax < 1 {
+			return errors.New("--reconnect-max must be at least 1")
+		}
+	}
+
+	if o.HTTPSProxy != "" {
+		if _, err := parseHTTPSProxy(o.HTTPSProxy); err != nil {
+			return err
+		}
+	}
+
+	if err := validateBastionLabels(o.BastionLabels); err != nil {
+		return err
+	}
+
+	if o.PortForward != 0 {
+		if err := o.validatePortForward(); err != nil {
+			return err
+		}
+	}
+
+	if o.ExecTemplate != "" {
+		if !o.Interactive || o.Output != "" {
+			return errors.New("--exec-template is only supported in interactive mode")
+		}
+
+		if _, err := parseExecTemplate(o.ExecTemplate); err != nil {
+			return err
+		}
+	}
+
+	if err := o.validateNodeAccess(); err != nil {
+		return err
+	}
+
+	// Complete only takes the public key from the SSH agent if no key files are given
+	if o.UseAgentKey != "" && !o.WrittenAgentPublicKey {
+		return errors.New("--use-agent-key cannot be combined with --public-key-file or --private-key-file")
 	}
 
 	content, err := os.ReadFile(o.SSHPublicKeyFile.String())
@@ -422,10 +838,161 @@ func (o *SSHOptions) Validate() error {
 		return fmt.Errorf("invalid SSH public key file: %w", err)
 	}
 
-	if _, _, _, _, err := ssh.ParseAuthorizedKey(content); err != nil {
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey(content)
+	if err != nil {
 		return fmt.Errorf("invalid SSH public key file: %w", err)
 	}
 
+	if len(o.SSHPrivateKeyFile) > 0 {
+		if err := validateKeyPair(publicKey, o.SSHPrivateKeyFile); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateBastionLabels checks that the keys and values of the bastion labels are valid label keys and values.
+func validateBastionLabels(bastionLabels map[string]string) error {
+	keys := make([]string, 0, len(bastionLabels))
+	for key := range bastionLabels {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid bastion label key %q: %s", key, strings.Join(errs, "; "))
+		}
+
+		if errs := validation.IsValidLabelValue(bastionLabels[key]); len(errs) > 0 {
+			return fmt.Errorf("invalid value of bastion label %q: %s", key, strings.Join(errs, "; "))
+		}
+	}
+
+	return nil
+}
+
+// validatePortForward validates the options for forwarding a local port to the kube-apiserver.
+func (o *SSHOptions) validatePortForward() error {
+	if o.PortForward < 1 || o.PortForward > 65535 {
+		return errors.New("--port-forward must be a port between 1 and 65535")
+	}
+
+	if o.NodeName != "" || o.ProviderID != "" || o.Pod != "" {
+		return errors.New("--port-forward cannot be combined with a node")
+	}
+
+	if o.Output != "" || o.Health || o.NoKeepalive {
+		return errors.New("--port-forward cannot be combined with --output, --health or --no-keepalive")
+	}
+
+	return nil
+}
+
+// noBastionExclusiveFlags are the flags that require a bastion and hence cannot be combined with --no-bastion.
+var noBastionExclusiveFlags = []string{
+	"pod",
+	"health",
+	"provider-id",
+	"exec-template",
+	"https-proxy",
+	"port-forward",
+	"label-bastion",
+	"keep-bastion-ttl",
+	"refresh-node-keys",
+	"wait-for-cleanup",
+	"metrics-file",
+	"reconnect",
+	"summary",
+}
+
+// validateNoBastion validates the options for a direct connection to a node. The flags that require a bastion
+// are rejected by cobra, see noBastionExclusiveFlags.
+func (o *SSHOptions) validateNoBastion() error {
+	if o.NodeName == "" {
+		return errors.New("--no-bastion requires a node name, hostname or IP address")
+	}
+
+	if !o.Interactive || o.Output != "" {
+		return errors.New("--no-bastion is only supported in interactive mode")
+	}
+
+	return o.validateNodeAccess()
+}
+
+// validateNodeAccess validates the options related to the access of the shoot node.
+func (o *SSHOptions) validateNodeAccess() error {
+	if o.User == "" {
+		return errors.New("user must not be empty")
+	}
+
+	if o.Impersonate == "" && len(o.ImpersonateGroups) > 0 {
+		return errors.New("--as-group requires --as to be set")
+	}
+
+	for _, group := range o.ImpersonateGroups {
+		if strings.TrimSpace(group) == "" {
+			return errors.New("--as-group must not be empty")
+		}
+	}
+
+	if _, err := parseNodeAddressPreference(o.NodeAddressPreference); err != nil {
+		return err
+	}
+
+	if o.ProviderID != "" && o.NodeName != "" {
+		return errors.New("--provider-id cannot be combined with a node name")
+	}
+
+	if o.Pod != "" {
+		if o.NodeName != "" || o.ProviderID != "" {
+			return errors.New("--pod cannot be combined with a node name or --provider-id")
+		}
+
+		if _, _, err := parsePodReference(o.Pod); err != nil {
+			return err
+		}
+	}
+
+	if o.NodeIPFamily != "" && o.NodeIPFamily != nodeIPFamilyIPv4 && o.NodeIPFamily != nodeIPFamilyIPv6 {
+		return fmt.Errorf("invalid node IP family %q, must be one of %q or %q", o.NodeIPFamily, nodeIPFamilyIPv4, nodeIPFamilyIPv6)
+	}
+
+	return nil
+}
+
+// validateKeyPair ensures that the public key can be derived from the given private key file.
+// If the private key is encrypted and does not carry its public key, the check is skipped.
+func validateKeyPair(publicKey ssh.PublicKey, privateKeyFile PrivateKeyFile) error {
+	content, err := os.ReadFile(privateKeyFile.String())
+	if err != nil {
+		return fmt.Errorf("invalid SSH private key file: %w", err)
+	}
+
+	var derivedPublicKey ssh.PublicKey
+
+	signer, err := ssh.ParsePrivateKey(content)
+	if err != nil {
+		var passphraseMissingErr *ssh.PassphraseMissingError
+		if !errors.As(err, &passphraseMissingErr) {
+			return fmt.Errorf("invalid SSH private key file: %w", err)
+		}
+
+		if passphraseMissingErr.PublicKey == nil {
+			return nil
+		}
+
+		derivedPublicKey = passphraseMissingErr.PublicKey
+	} else {
+		derivedPublicKey = signer.PublicKey()
+	}
+
+	if !bytes.Equal(derivedPublicKey.Marshal(), publicKey.Marshal()) {
+		return errors.New("provided public and private keys do not match")
+	}
+
 	return nil
 }
 
@@ -501,35 +1068,123 @@ func writeKeyFile(filename string, content []byte) error {
 	return nil
 }
 
-func countSSHAgentSigners() (int, error) {
-	addr := os.Getenv("SSH_AUTH_SOCK")
+// writeSSHAgentPublicKey writes the public key of the SSH agent identity with the given comment or
+// SHA256 fingerprint to a file in the given directory and returns the name of the file.
+func writeSSHAgentPublicKey(tempDir string, identity string, identityAgent string) (PublicKeyFile, error) {
+	addr := sshAgentSocket(identityAgent)
 	if len(addr) == 0 {
-		return 0, nil
+		return "", errors.New("--use-agent-key requires a running SSH agent, but the environment variable SSH_AUTH_SOCK is not defined")
 	}
 
 	socket, err := net.Dial("unix", addr)
 	if err != nil {
-		return 0, fmt.Errorf("could not open SSH agent socket %q: %w", addr, err)
+		return "", fmt.Errorf("could not open SSH agent socket %q: %w", addr, err)
 	}
 	defer socket.Close()
 
-	signers, err := agent.NewClient(socket).Signers()
+	keys, err := agent.NewClient(socket).List()
 	if err != nil {
-		return 0, fmt.Errorf("error when retrieving signers from SSH agent: %w", err)
+		return "", fmt.Errorf("error when listing the identities of the SSH agent: %w", err)
 	}
 
-	return len(signers), nil
-}
+	var publicKey ssh.PublicKey
 
-func (o *SSHOptions) Run(f util.Factory) error {
-	manager, err := f.Manager()
-	if err != nil {
-		return err
+	for _, key := range keys {
+		if key.Comment == identity || ssh.FingerprintSHA256(key) == identity {
+			publicKey = key
+
+			break
+		}
+	}
+
+	if publicKey == nil {
+		return "", fmt.Errorf("no identity %q found in the SSH agent", identity)
+	}
+
+	id, err := utils.GenerateRandomString(8)
+	if err != nil {
+		return "", fmt.Errorf("failed to create key name: %w", err)
+	}
+
+	if tempDir == "" {
+		tempDir = os.TempDir()
+	}
+
+	sshPublicKeyFile := PublicKeyFile(filepath.Join(tempDir, fmt.Sprintf("agent_id_%s.pub", strings.ToLower(id))))
+	if err := writeKeyFile(sshPublicKeyFile.String(), encodePublicKey(publicKey)); err != nil {
+		return "", fmt.Errorf("failed to write public key: %w", err)
+	}
+
+	return sshPublicKeyFile, nil
+}
+
+// sshAgentSocket returns the given socket of the SSH agent, or the one of the environment variable
+// SSH_AUTH_SOCK if empty.
+func sshAgentSocket(identityAgent string) string {
+	if identityAgent != "" {
+		return identityAgent
+	}
+
+	return os.Getenv("SSH_AUTH_SOCK")
+}
+
+// validateIdentityAgent checks that the given path of the --identity-agent flag is a socket.
+func validateIdentityAgent(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("invalid --identity-agent: %w", err)
+	}
+
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("invalid --identity-agent: %q is not a socket", path)
+	}
+
+	return nil
+}
+
+func countSSHAgentSigners(identityAgent string) (int, error) {
+	addr := sshAgentSocket(identityAgent)
+	if len(addr) == 0 {
+		return 0, nil
+	}
+
+	socket, err := net.Dial("unix", addr)
+	if err != nil {
+		return 0, fmt.Errorf("could not open SSH agent socket %q: %w", addr, err)
+	}
+	defer socket.Close()
+
+	signers, err := agent.NewClient(socket).Signers()
+	if err != nil {
+		return 0, fmt.Errorf("error when retrieving signers from SSH agent: %w", err)
+	}
+
+	return len(signers), nil
+}
+
+func (o *SSHOptions) Run(f util.Factory) error {
+	// the key files for the bastion are removed by cleanup, which is only registered once the bastion is about
+	// to be created. Remove them here if Run returns before.
+	cleanupRegistered := false
+
+	defer func() {
+		if !cleanupRegistered {
+			o.removeSSHKeyFiles(klog.FromContext(f.Context()))
+		}
+	}()
+
+	manager, err := f.Manager()
+	if err != nil {
+		return err
 	}
 
 	ctx := f.Context()
 	logger := klog.FromContext(ctx)
 
+	if o.Impersonate != "" {
+		ctx = target.WithImpersonation(ctx, target.Impersonation{User: o.Impersonate, Groups: o.ImpersonateGroups})
+	}
+
 	// currentTarget is the target used for the run method
 	currentTarget, err := manager.CurrentTarget()
 	if err != nil {
@@ -542,28 +1197,23 @@ func (o *SSHOptions) Run(f util.Factory) error {
 		return err
 	}
 
-	if currentTarget.ShootName() == "" && currentTarget.SeedName() != "" {
-		shoot, err := gardenClient.GetShootOfManagedSeed(ctx, currentTarget.SeedName())
-		if err != nil {
-			if apierrors.IsNotFound(err) {
-				return fmt.Errorf("cannot ssh to non-managed seeds: %w", err)
-			}
+	if currentTarget.ControlPlane() {
+		var apiServerNodeName string
 
+		currentTarget, apiServerNodeName, err = resolveControlPlaneTarget(ctx, manager, gardenClient, currentTarget)
+		if err != nil {
 			return err
 		}
 
-		logger.V(1).Info("using referred shoot of managed seed",
-			"shoot", klog.ObjectRef{
-				Namespace: "garden",
-				Name:      shoot.Name,
-			},
-			"seed", currentTarget.SeedName())
-
-		currentTarget = currentTarget.WithProjectName("garden").WithShootName(shoot.Name)
+		if o.NodeName == "" && o.ProviderID == "" && o.Pod == "" {
+			logger.V(1).Info("using seed node running the kube-apiserver of the shoot", "nodeName", apiServerNodeName)
+			o.NodeName = apiServerNodeName
+		}
 	}
 
-	if currentTarget.ShootName() == "" {
-		return target.ErrNoShootTargeted
+	currentTarget, err = resolveShootTarget(ctx, gardenClient, currentTarget)
+	if err != nil {
+		return err
 	}
 
 	printTargetInformation(logger, currentTarget)
@@ -572,7 +1222,7 @@ func (o *SSHOptions) Run(f util.Factory) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	shoot, err := gardenClient.FindShoot(ctx, currentTarget.AsListOption())
+	shoot, err := clientgarden.FindShootWithRetry(ctx, gardenClient, o.WaitShoot, currentTarget.AsListOption())
 	if err != nil {
 		return err
 	}
@@ -590,8 +1240,13 @@ func (o *SSHOptions) Run(f util.Factory) error {
 		return errors.New("node SSH access disabled, SSH not allowed")
 	}
 
+	// a hibernated shoot has no nodes, do not create a bastion in this case
+	if corev1beta1helper.HibernationIsEnabled(shoot) || shoot.Status.IsHibernated {
+		return errors.New("shoot is hibernated; wake it before SSH")
+	}
+
 	// fetch the SSH key(s) for the shoot nodes
-	nodePrivateKeys, err := getShootNodePrivateKeys(ctx, gardenClient.RuntimeClient(), shoot)
+	nodePrivateKeys, err := o.getNodePrivateKeys(ctx, gardenClient.RuntimeClient(), shoot)
 	if err != nil {
 		return err
 	}
@@ -600,7 +1255,7 @@ func (o *SSHOptions) Run(f util.Factory) error {
 	var nodePrivateKeyFiles []PrivateKeyFile
 
 	for _, pk := range nodePrivateKeys {
-		filename, err := writeToTemporaryFile(pk)
+		filename, err := writeToTemporaryFile(o.TempDir, pk)
 		if err != nil {
 			return err
 		}
@@ -615,10 +1270,45 @@ func (o *SSHOptions) Run(f util.Factory) error {
 
 	var nodeHostname string
 
-	if o.NodeName != "" {
+	if o.ProviderID != "" || o.Pod != "" {
+		var node *corev1.Node
+
+		if o.ProviderID != "" {
+			node, err = getShootNodeByProviderID(ctx, shootClient, o.ProviderID)
+		} else {
+			node, err = getShootNodeByPod(ctx, shootClient, o.Pod)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to determine hostname for node: %w", o.withImpersonationHint(err))
+		}
+
+		logger.V(1).Info("using node of the given provider ID or pod", "nodeName", node.Name, "providerID", o.ProviderID, "pod", o.Pod)
+
+		o.NodeName = node.Name
+
+		preference, err := parseNodeAddressPreference(o.NodeAddressPreference)
+		if err != nil {
+			return err
+		}
+
+		nodeHostname, err = getNodeHostname(node, preference, o.NodeIPFamily)
+		if err != nil {
+			return err
+		}
+	} else if o.NodeName != "" {
 		node, err := getShootNode(ctx, o, shootClient)
 		if err == nil { //nolint:gocritic // rewrite if-else to switch statement does not make sense as anonymous switch statements should never be cuddled
-			nodeHostname, err = getNodeHostname(node)
+			if err := o.checkNodeReadiness(logger, node); err != nil {
+				return err
+			}
+
+			preference, err := parseNodeAddressPreference(o.NodeAddressPreference)
+			if err != nil {
+				return err
+			}
+
+			nodeHostname, err = getNodeHostname(node, preference, o.NodeIPFamily)
 			if err != nil {
 				return err
 			}
@@ -626,12 +1316,32 @@ func (o *SSHOptions) Run(f util.Factory) error {
 			logger.Error(err, "Node not found. Wrong name provided or this node did not yet join the cluster, continuing anyways", "nodeName", o.NodeName)
 			nodeHostname = o.NodeName
 		} else {
-			return fmt.Errorf("failed to determine hostname for node: %w", err)
+			return fmt.Errorf("failed to determine hostname for node: %w", o.withImpersonationHint(err))
+		}
+	}
+
+	if len(o.NodeUserKnownHostsFiles) == 0 {
+		// Set the default known_hosts file for shoot nodes if none is provided.
+		// Known hosts for shoot nodes are stored in the persistent garden home directory
+		// because shoot nodes are typically longer-lived than bastions. This ensures that
+		// node keys are preserved across system restarts, avoiding repeated verification.
+		gardenHomeDir := f.GardenHomeDir()
+		knownHostsFile := filepath.Join(gardenHomeDir, "cache", string(shoot.UID), ".ssh", "known_hosts")
+
+		if err := os.MkdirAll(filepath.Dir(knownHostsFile), 0o700); err != nil {
+			return fmt.Errorf("failed to create directory for node known hosts file: %w", err)
 		}
+
+		o.NodeUserKnownHostsFiles = []string{knownHostsFile}
+		logger.Info("Using default known_hosts file for shoot node", "knownHostsFile", knownHostsFile)
+	}
+
+	if o.NoBastion {
+		return connectDirectly(ctx, o, nodeHostname, nodePrivateKeyFiles)
 	}
 
 	// prepare Bastion resource
-	policies, err := o.bastionIngressPolicies(logger, shoot.Spec.Provider.Type)
+	policies, err := o.bastionIngressPolicies(logger, shoot)
 	if err != nil {
 		return fmt.Errorf("failed to get bastion ingress policies: %w", err)
 	}
@@ -641,6 +1351,12 @@ func (o *SSHOptions) Run(f util.Factory) error {
 		return fmt.Errorf("failed to read SSH public key: %w", err)
 	}
 
+	if o.GeneratedBastionName {
+		if err := o.ensureUniqueBastionName(ctx, gardenClient.RuntimeClient(), shoot.Namespace); err != nil {
+			return err
+		}
+	}
+
 	bastionKey := client.ObjectKey{
 		Namespace: shoot.Namespace,
 		Name:      o.BastionName,
@@ -662,62 +1378,102 @@ func (o *SSHOptions) Run(f util.Factory) error {
 	}()
 
 	// do not use `ctx`, as it might be cancelled already when running the cleanup
-	defer cleanup(f.Context(), o, gardenClient.RuntimeClient(), bastionKey, nodePrivateKeyFiles)
+	cleanupRegistered = true
+	// the node private key files are read when returning, as they may have been refreshed
+	defer func() {
+		cleanup(f.Context(), o, gardenClient.RuntimeClient(), bastionKey, nodePrivateKeyFiles)
+	}()
+
+	if recorder, ok := o.MetricsRecorder.(*fileMetricsRecorder); ok {
+		defer func() {
+			if err := recorder.write(); err != nil {
+				logger.Error(err, "Failed to write metrics", "path", o.MetricsFile)
+			}
+		}()
+	}
+
+	createStart := f.Clock().Now()
 
-	bastion, err := createOrPatchBastion(ctx, gardenClient.RuntimeClient(), bastionKey, shoot, sshPublicKey, policies)
+	bastion, err := createOrPatchBastion(ctx, gardenClient.RuntimeClient(), bastionKey, shoot, sshPublicKey, policies, o.BastionLabels, o.bastionAnnotations())
 	if err != nil {
 		return err
 	}
 
+	o.recordBastionPhase(BastionPhaseCreate, f.Clock().Now().Sub(createStart))
+
+	if o.Summary {
+		defer func() {
+			summary := NewSessionSummary(bastion.Name, bastion.Namespace, f.Clock().Now().Sub(createStart), o.KeepBastion, o.NodeName, o.SSHPrivateKeyFile, nodePrivateKeyFiles)
+			if err := o.printSummary(summary); err != nil {
+				logger.Error(err, "Failed to print session summary")
+			}
+		}()
+	}
+
 	if len(o.BastionUserKnownHostsFiles) == 0 {
 		// Set the default known_hosts file for bastions if none is provided.
 		// Bastion host keys are stored in a temporary directory because they are
 		// short-lived and become irrelevant once the bastion is terminated.
 		// Additionally, since bastion public IPs can be reused, storing keys separately
 		// prevents unnecessary host key warnings.
-		tempDir := f.GardenTempDir()
-		knownHostsFile := filepath.Join(tempDir, "cache", string(bastion.UID), ".ssh", "known_hosts")
+		knownHostsDir := filepath.Join(o.TempDir, "cache", string(bastion.UID))
+		knownHostsFile := filepath.Join(knownHostsDir, ".ssh", "known_hosts")
 
 		if err := os.MkdirAll(filepath.Dir(knownHostsFile), 0o700); err != nil {
 			return fmt.Errorf("failed to create directory for bastion known hosts file: %w", err)
 		}
 
+		o.GeneratedBastionKnownHostsDir = knownHostsDir
+
 		o.BastionUserKnownHostsFiles = []string{knownHostsFile}
 		logger.Info("Using default known_hosts file for bastion", "knownHostsFile", knownHostsFile)
 	}
 
-	if len(o.NodeUserKnownHostsFiles) == 0 {
-		// Set the default known_hosts file for shoot nodes if none is provided.
-		// Known hosts for shoot nodes are stored in the persistent garden home directory
-		// because shoot nodes are typically longer-lived than bastions. This ensures that
-		// node keys are preserved across system restarts, avoiding repeated verification.
-		gardenHomeDir := f.GardenHomeDir()
-		knownHostsFile := filepath.Join(gardenHomeDir, "cache", string(shoot.UID), ".ssh", "known_hosts")
+	// continuously keep the bastion alive by renewing its annotation, at most for the TTL if given
+	keepaliveCtx := ctx
 
-		if err := os.MkdirAll(filepath.Dir(knownHostsFile), 0o700); err != nil {
-			return fmt.Errorf("failed to create directory for node known hosts file: %w", err)
-		}
+	if o.KeepBastionTTL > 0 {
+		var cancelKeepalive context.CancelFunc
 
-		o.NodeUserKnownHostsFiles = []string{knownHostsFile}
-		logger.Info("Using default known_hosts file for shoot node", "knownHostsFile", knownHostsFile)
+		keepaliveCtx, cancelKeepalive = context.WithTimeout(ctx, o.KeepBastionTTL)
+		defer cancelKeepalive()
 	}
 
-	// continuously keep the bastion alive by renewing its annotation
-	go keepBastionAlive(ctx, cancel, gardenClient.RuntimeClient(), bastion.DeepCopy())
+	go keepBastionAlive(keepaliveCtx, cancel, gardenClient.RuntimeClient(), bastion.DeepCopy())
+
+	o.logStatus(logger, color.FgYellow, "Waiting for bastion to be ready…", "conditionTimeout", o.ConditionTimeout, "waitTimeout", o.WaitTimeout)
+
+	start := f.Clock().Now()
 
-	logger.Info("Waiting for bastion to be ready…", "waitTimeout", o.WaitTimeout)
+	err = waitForBastion(ctx, o, f.Clock(), gardenClient.RuntimeClient(), bastion)
+
+	if o.Health {
+		result := NewHealthResult(bastion.Name, bastion.Namespace, f.Clock().Now().Sub(start), err)
+		if printErr := o.PrintObject(result); printErr != nil {
+			return printErr
+		}
+
+		if err != nil {
+			return fmt.Errorf("bastion health check failed: %w", err)
+		}
+
+		return nil
+	}
 
-	err = waitForBastion(ctx, o, gardenClient.RuntimeClient(), bastion)
 	if wait.Interrupted(err) {
 		return errors.New("timed out waiting for the bastion to be ready")
 	} else if err != nil {
 		return fmt.Errorf("an error occurred while waiting for the bastion to be ready: %w", err)
 	}
 
-	logger.Info("Bastion host became available.", "address", toAddress(bastion.Status.Ingress).String())
+	o.logStatus(logger, color.FgGreen, "Bastion host became available.", "address", toAddress(bastion.Status.Ingress).String())
 
 	bastionPreferredAddress := preferredBastionAddress(o.BastionHost, bastion)
 
+	if o.PortForward != 0 {
+		return o.portForward(ctx, manager, currentTarget, shoot, bastionPreferredAddress)
+	}
+
 	if !o.Interactive {
 		var nodes []corev1.Node
 
@@ -726,16 +1482,21 @@ func (o *SSHOptions) Run(f util.Factory) error {
 		if nodeHostname == "" {
 			nodes, err = getNodes(ctx, shootClient)
 			if err != nil {
-				return fmt.Errorf("failed to list shoot cluster nodes: %w", err)
+				return fmt.Errorf("failed to list shoot cluster nodes: %w", o.withImpersonationHint(err))
 			}
 
 			// We also want to determine if there are nodes that have not yet joined the cluster.
 			// This is an optional step, as only Gardener operators have access to the Seeds.
 			// Regular users will receive a 'Forbidden' error when trying to fetch the Machines.
 			// However, we do not want to log an error message in this case.
-			pendingNodeNames, err = getNodeNamesFromMachines(ctx, manager, currentTarget)
-			if err != nil && !apierrors.IsForbidden(err) {
-				logger.Info("failed to get shoot cluster node names from machines", "err", err)
+			pendingNodeNames, err = getNodeNamesFromMachines(ctx, manager, currentTarget, labels.Everything())
+			if err != nil {
+				switch {
+				case isUnreachableError(err):
+					logger.Info("seed cluster unreachable, cannot determine the nodes that have not yet joined the cluster", "err", err)
+				case !apierrors.IsForbidden(err) || o.Impersonate != "":
+					logger.Info("failed to get shoot cluster node names from machines", "err", o.withImpersonationHint(err))
+				}
 			}
 		}
 
@@ -745,8 +1506,10 @@ func (o *SSHOptions) Run(f util.Factory) error {
 			o.BastionPort,
 			o.BastionUserKnownHostsFiles,
 			o.BastionStrictHostKeyChecking,
+			o.HTTPSProxy,
 			o.NodeUserKnownHostsFiles,
 			o.NodeStrictHostKeyChecking,
+			o.HashKnownHosts,
 			nodeHostname,
 			o.SSHPublicKeyFile,
 			o.SSHPrivateKeyFile,
@@ -754,11 +1517,15 @@ func (o *SSHOptions) Run(f util.Factory) error {
 			nodes,
 			pendingNodeNames,
 			o.User,
+			o.Wide,
 		)
 		if err != nil {
 			return err
 		}
 
+		connectInformation.NodeAgentKeyOnly = o.NodeAgentKeyOnly
+		connectInformation.IdentityAgent = o.IdentityAgent
+
 		if err := o.PrintObject(connectInformation); err != nil {
 			return err
 		}
@@ -767,28 +1534,159 @@ func (o *SSHOptions) Run(f util.Factory) error {
 			return nil
 		}
 
+		if o.RefreshNodeKeys && !o.NodeAgentKeyOnly {
+			stopRefresh := o.refreshNodeKeysOnSignal(ctx, gardenClient.RuntimeClient(), shoot, &nodePrivateKeyFiles)
+			defer stopRefresh()
+		}
+
 		waitForSignal(ctx, o, ctx.Done())
 
 		return nil
 	}
 
-	return remoteShell(
-		ctx,
-		o.IOStreams,
-		bastionPreferredAddress,
-		o.BastionPort,
-		o.SSHPrivateKeyFile,
-		o.BastionUserKnownHostsFiles,
-		o.BastionStrictHostKeyChecking,
-		o.NodeUserKnownHostsFiles,
-		o.NodeStrictHostKeyChecking,
-		nodeHostname,
-		nodePrivateKeyFiles,
-		o.User,
-	)
+	if o.PrintPrivateKeyPath {
+		printPrivateKeyPaths(o.IOStreams.ErrOut, o.SSHPrivateKeyFile, nodePrivateKeyFiles)
+	}
+
+	shell := func() error {
+		if o.ExecTemplate != "" {
+			return execTemplateCommand(ctx, o.IOStreams, o.ExecTemplate, newExecTemplateData(o, bastionPreferredAddress, nodeHostname, nodePrivateKeyFiles))
+		}
+
+		return remoteShell(
+			ctx,
+			o.IOStreams,
+			bastionPreferredAddress,
+			o.BastionPort,
+			o.SSHPrivateKeyFile,
+			o.BastionUserKnownHostsFiles,
+			o.BastionStrictHostKeyChecking,
+			o.HTTPSProxy,
+			o.NodeUserKnownHostsFiles,
+			o.NodeStrictHostKeyChecking,
+			o.HashKnownHosts,
+			nodeHostname,
+			nodePrivateKeyFiles,
+			o.NodeAgentKeyOnly,
+			o.IdentityAgent,
+			o.User,
+		)
+	}
+
+	if o.Reconnect {
+		return reconnectOnConnectionDrop(ctx, o.ReconnectMax, shell)
+	}
+
+	return shell()
+}
+
+// reconnectOnConnectionDrop runs the given shell function and runs it again after reconnectDelay
+// if the SSH connection dropped, up to maxAttempts times. It stops as soon as the context is done,
+// e.g. because the user stopped gardenctl or the bastion could not be kept alive.
+func reconnectOnConnectionDrop(ctx context.Context, maxAttempts int, shell func() error) error {
+	logger := klog.FromContext(ctx)
+
+	err := shell()
+
+	for attempt := 1; attempt <= maxAttempts && isConnectionDropped(err) && ctx.Err() == nil; attempt++ {
+		logger.Info("SSH connection dropped, reconnecting…", "attempt", attempt, "maxAttempts", maxAttempts)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(reconnectDelay):
+		}
+
+		err = shell()
+	}
+
+	return err
 }
 
-func createOrPatchBastion(ctx context.Context, gardenClient client.Client, key client.ObjectKey, shoot *gardencorev1beta1.Shoot, sshPublicKey []byte, policies []operationsv1alpha1.BastionIngressPolicy) (*operationsv1alpha1.Bastion, error) {
+// isConnectionDropped returns true if the given error is caused by the ssh client exiting
+// with the exit code that it uses for connection errors.
+func isConnectionDropped(err error) bool {
+	var exitErr interface{ ExitCode() int }
+
+	return errors.As(err, &exitErr) && exitErr.ExitCode() == sshConnectionErrorExitCode
+}
+
+// withImpersonationHint adds the impersonated user to forbidden errors, so that it is
+// clear which identity lacks the required permissions.
+func (o *SSHOptions) withImpersonationHint(err error) error {
+	if o.Impersonate != "" && apierrors.IsForbidden(err) {
+		return fmt.Errorf("impersonated user %q is not allowed to perform this operation: %w", o.Impersonate, err)
+	}
+
+	return err
+}
+
+// printSummary prints the session summary to stderr, or to stdout in the selected output format if --output is set.
+func (o *SSHOptions) printSummary(summary *SessionSummary) error {
+	if o.Output != "" {
+		return o.PrintObject(summary)
+	}
+
+	_, err := fmt.Fprint(o.IOStreams.ErrOut, summary.String())
+
+	return err
+}
+
+// printPrivateKeyPaths prints the paths of the bastion and node private key files.
+func printPrivateKeyPaths(w io.Writer, sshPrivateKeyFile PrivateKeyFile, nodePrivateKeyFiles []PrivateKeyFile) {
+	if sshPrivateKeyFile != "" {
+		fmt.Fprintf(w, "> Bastion private key file: %s\n", sshPrivateKeyFile)
+	} else {
+		fmt.Fprintln(w, "> Bastion private key: provided by SSH agent")
+	}
+
+	for _, file := range nodePrivateKeyFiles {
+		fmt.Fprintf(w, "> Node private key file: %s\n", file)
+	}
+}
+
+// ensureUniqueBastionName generates a new bastion name if a bastion with the generated name already exists,
+// as createOrPatchBastion would otherwise patch a foreign bastion.
+func (o *SSHOptions) ensureUniqueBastionName(ctx context.Context, gardenClient client.Client, namespace string) error {
+	logger := klog.FromContext(ctx)
+
+	for attempt := 1; ; attempt++ {
+		key := client.ObjectKey{Namespace: namespace, Name: o.BastionName}
+
+		err := gardenClient.Get(ctx, key, &operationsv1alpha1.Bastion{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to check if bastion %s exists: %w", key, err)
+		}
+
+		if attempt == maxBastionNameAttempts {
+			return fmt.Errorf("failed to generate a unique bastion name after %d attempts", maxBastionNameAttempts)
+		}
+
+		logger.V(4).Info("Bastion with generated name already exists, generating a new name", "bastion", key, "attempt", attempt)
+
+		name, err := bastionNameProvider()
+		if err != nil {
+			return fmt.Errorf("failed to create bastion name: %w", err)
+		}
+
+		o.BastionName = name
+	}
+}
+
+// bastionAnnotations returns the annotations that are set on the bastion in addition to the keepalive annotation.
+func (o *SSHOptions) bastionAnnotations() map[string]string {
+	if o.KeepBastionTTL == 0 {
+		return nil
+	}
+
+	return map[string]string{AnnotationKeepBastionTTL: o.KeepBastionTTL.String()}
+}
+
+func createOrPatchBastion(ctx context.Context, gardenClient client.Client, key client.ObjectKey, shoot *gardencorev1beta1.Shoot, sshPublicKey []byte, policies []operationsv1alpha1.BastionIngressPolicy, bastionLabels, bastionAnnotations map[string]string) (*operationsv1alpha1.Bastion, error) {
 	logger := klog.FromContext(ctx)
 
 	bastion := &operationsv1alpha1.Bastion{
@@ -804,6 +1702,15 @@ func createOrPatchBastion(ctx context.Context, gardenClient client.Client, key c
 		}
 
 		bastion.Annotations[corev1beta1constants.GardenerOperation] = corev1beta1constants.GardenerOperationKeepalive
+
+		for key, value := range bastionAnnotations {
+			metav1.SetMetaDataAnnotation(&bastion.ObjectMeta, key, value)
+		}
+
+		for key, value := range bastionLabels {
+			metav1.SetMetaDataLabel(&bastion.ObjectMeta, key, value)
+		}
+
 		bastion.Spec.ShootRef = corev1.LocalObjectReference{
 			Name: shoot.Name,
 		}
@@ -821,9 +1728,11 @@ func createOrPatchBastion(ctx context.Context, gardenClient client.Client, key c
 	return bastion, nil
 }
 
-func (o *SSHOptions) bastionIngressPolicies(logger klog.Logger, providerType string) ([]operationsv1alpha1.BastionIngressPolicy, error) {
+func (o *SSHOptions) bastionIngressPolicies(logger klog.Logger, shoot *gardencorev1beta1.Shoot) ([]operationsv1alpha1.BastionIngressPolicy, error) {
 	var policies []operationsv1alpha1.BastionIngressPolicy
 
+	providerType := shoot.Spec.Provider.Type
+
 	for _, cidr := range o.CIDRs {
 		if providerType == "gcp" {
 			ip, _, err := net.ParseCIDR(cidr)
@@ -849,6 +1758,28 @@ func (o *SSHOptions) bastionIngressPolicies(logger klog.Logger, providerType str
 		})
 	}
 
+	if o.AllowNodeCIDR {
+		cidr, err := shootNodeCIDR(shoot)
+		if err != nil {
+			return nil, err
+		}
+
+		ip, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node CIDR %q of shoot %s: %w", cidr, shoot.Name, err)
+		}
+
+		if providerType == "gcp" && ip.To4() == nil {
+			logger.Info("GCP only supports IPv4, skipped node CIDR", "cidr", cidr)
+		} else {
+			policies = append(policies, operationsv1alpha1.BastionIngressPolicy{
+				IPBlock: networkingv1.IPBlock{
+					CIDR: cidr,
+				},
+			})
+		}
+	}
+
 	if len(policies) == 0 {
 		return nil, errors.New("no ingress policies left")
 	}
@@ -856,6 +1787,15 @@ func (o *SSHOptions) bastionIngressPolicies(logger klog.Logger, providerType str
 	return policies, nil
 }
 
+// shootNodeCIDR returns the node network CIDR from the networking spec of the given shoot.
+func shootNodeCIDR(shoot *gardencorev1beta1.Shoot) (string, error) {
+	if shoot.Spec.Networking == nil || ptr.Deref(shoot.Spec.Networking.Nodes, "") == "" {
+		return "", fmt.Errorf("shoot %s has no node network CIDR", shoot.Name)
+	}
+
+	return *shoot.Spec.Networking.Nodes, nil
+}
+
 func printTargetInformation(logger klog.Logger, t target.Target) {
 	var step string
 
@@ -883,15 +1823,15 @@ func cleanup(ctx context.Context, o *SSHOptions, gardenClient client.Client, bas
 		}
 		if err := gardenClient.Delete(ctx, bastion); client.IgnoreNotFound(err) != nil {
 			logger.Error(err, "Failed to delete bastion.", "bastion", klog.KObj(bastion))
+		} else if o.WaitForCleanup {
+			waitForBastionDeletion(ctx, gardenClient, bastionKey)
 		}
 
-		if o.GeneratedSSHKeys {
-			if err := os.Remove(o.SSHPublicKeyFile.String()); err != nil {
-				logger.Error(err, "Failed to delete SSH public key file", "path", o.SSHPublicKeyFile)
-			}
+		o.removeSSHKeyFiles(logger)
 
-			if err := os.Remove(o.SSHPrivateKeyFile.String()); err != nil {
-				logger.Error(err, "Failed to delete SSH private key file", "path", o.SSHPrivateKeyFile)
+		if o.GeneratedBastionKnownHostsDir != "" {
+			if err := os.RemoveAll(o.GeneratedBastionKnownHostsDir); err != nil {
+				logger.Error(err, "Failed to delete bastion known hosts directory", "path", o.GeneratedBastionKnownHostsDir)
 			}
 		}
 
@@ -910,11 +1850,62 @@ func cleanup(ctx context.Context, o *SSHOptions, gardenClient client.Client, bas
 			logger.Info("The SSH keypair for the bastion remain on disk", "publicKeyPath", o.SSHPublicKeyFile, "privateKeyPath", o.SSHPrivateKeyFile)
 		}
 
+		if o.WrittenAgentPublicKey {
+			logger.Info("The SSH public key for the bastion remains on disk", "publicKeyPath", o.SSHPublicKeyFile)
+		}
+
 		logger.Info("The private SSH keys for shoot nodes remain on disk", "paths", nodePrivateKeyFiles)
 	}
 }
 
-func getNodeNamesFromMachinesOrNodes(ctx context.Context, manager target.Manager) ([]string, error) {
+// removeSSHKeyFiles removes the SSH key files for the bastion that have been generated
+// or taken from the SSH agent. Key files provided by the user are never removed.
+func (o *SSHOptions) removeSSHKeyFiles(logger klog.Logger) {
+	if o.GeneratedSSHKeys {
+		if err := os.Remove(o.SSHPublicKeyFile.String()); err != nil {
+			logger.Error(err, "Failed to delete SSH public key file", "path", o.SSHPublicKeyFile)
+		}
+
+		if err := os.Remove(o.SSHPrivateKeyFile.String()); err != nil {
+			logger.Error(err, "Failed to delete SSH private key file", "path", o.SSHPrivateKeyFile)
+		}
+
+		o.GeneratedSSHKeys = false
+	}
+
+	if o.WrittenAgentPublicKey {
+		if err := os.Remove(o.SSHPublicKeyFile.String()); err != nil {
+			logger.Error(err, "Failed to delete SSH public key file", "path", o.SSHPublicKeyFile)
+		}
+
+		o.WrittenAgentPublicKey = false
+	}
+}
+
+// waitForBastionDeletion polls until the bastion is gone. If the bastion still exists
+// after waitForCleanupTimeout, the error is logged.
+func waitForBastionDeletion(ctx context.Context, gardenClient client.Client, bastionKey client.ObjectKey) {
+	logger := klog.FromContext(ctx)
+	logger.Info("Waiting for bastion to be deleted…", "bastion", klog.KRef(bastionKey.Namespace, bastionKey.Name))
+
+	err := wait.PollUntilContextTimeout(ctx, pollBastionStatusInterval, waitForCleanupTimeout, true, func(ctx context.Context) (bool, error) {
+		if err := gardenClient.Get(ctx, bastionKey, &operationsv1alpha1.Bastion{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+
+			return false, err
+		}
+
+		return false, nil
+	})
+	if err != nil {
+		logger.Error(err, "Timed out waiting for bastion to be deleted", "bastion", klog.KRef(bastionKey.Namespace, bastionKey.Name), "timeout", waitForCleanupTimeout)
+	}
+}
+
+// getNodeNamesFromMachinesOrNodes returns the names of the nodes whose labels match the given selector.
+func getNodeNamesFromMachinesOrNodes(ctx context.Context, manager target.Manager, selector labels.Selector) ([]string, error) {
 	logger := klog.FromContext(ctx)
 
 	currentTarget, err := manager.CurrentTarget()
@@ -926,21 +1917,32 @@ func getNodeNamesFromMachinesOrNodes(ctx context.Context, manager target.Manager
 		return nil, errors.New("no Shoot cluster targeted")
 	}
 
-	nodeNames, err := getNodeNamesFromMachines(ctx, manager, currentTarget)
+	nodeNames, err := getNodeNamesFromMachines(ctx, manager, currentTarget, selector)
 	if err != nil {
 		// Regular users do not have the permission to fetch the machines.
 		// However, in this case, we do not want to log an error message. Instead, we will fallback to read the node names.
-		if !apierrors.IsForbidden(err) {
+		switch {
+		case isUnreachableError(err):
+			logger.Info("seed cluster unreachable, falling back to the nodes of the shoot cluster", "err", err)
+		case !apierrors.IsForbidden(err):
 			logger.Info("failed to fetch node names from machine objects", "err", err)
 		}
 
-		return getNodeNamesFromNodes(ctx, manager, currentTarget)
+		return getNodeNamesFromNodes(ctx, manager, currentTarget, selector)
 	}
 
 	return nodeNames, nil
 }
 
-func getNodeNamesFromMachines(ctx context.Context, manager target.Manager, currentTarget target.Target) ([]string, error) {
+// isUnreachableError returns true if the error indicates that a cluster could not be reached,
+// e.g. because the connection was refused or timed out, as opposed to an error returned by the kube-apiserver.
+func isUnreachableError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// getNodeNamesFromMachines returns the names of the nodes of the machines whose node template labels match the given selector.
+func getNodeNamesFromMachines(ctx context.Context, manager target.Manager, currentTarget target.Target, selector labels.Selector) ([]string, error) {
 	gardenClient, err := manager.GardenClient(currentTarget.GardenName())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create garden cluster client: %w", err)
@@ -970,13 +1972,18 @@ func getNodeNamesFromMachines(ctx context.Context, manager target.Manager, curre
 			continue
 		}
 
+		if !selector.Matches(labels.Set(machine.Spec.NodeTemplateSpec.Labels)) {
+			continue
+		}
+
 		nodeNames = append(nodeNames, machine.Labels[machinev1alpha1.NodeLabelKey])
 	}
 
 	return nodeNames, nil
 }
 
-func getNodeNamesFromNodes(ctx context.Context, manager target.Manager, currentTarget target.Target) ([]string, error) {
+// getNodeNamesFromNodes returns the names of the nodes whose labels match the given selector.
+func getNodeNamesFromNodes(ctx context.Context, manager target.Manager, currentTarget target.Target, selector labels.Selector) ([]string, error) {
 	shootClient, err := manager.ShootClient(ctx, currentTarget)
 	if err != nil {
 		return nil, err
@@ -988,7 +1995,12 @@ func getNodeNamesFromNodes(ctx context.Context, manager target.Manager, currentT
 	}
 
 	var nodeNames []string
+
 	for _, node := range nodes {
+		if !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+
 		nodeNames = append(nodeNames, node.Name)
 	}
 
@@ -1018,7 +2030,7 @@ func preferredBastionAddress(bastionHostOverride string, bastion *operationsv1al
 	return ""
 }
 
-func waitForBastion(ctx context.Context, o *SSHOptions, gardenClient client.Client, bastion *operationsv1alpha1.Bastion) error {
+func waitForBastion(ctx context.Context, o *SSHOptions, clock util.Clock, gardenClient client.Client, bastion *operationsv1alpha1.Bastion) error {
 	var (
 		lastCheckErr    error
 		privateKeyBytes []byte
@@ -1039,9 +2051,12 @@ func waitForBastion(ctx context.Context, o *SSHOptions, gardenClient client.Clie
 		return fmt.Errorf("could not create hostkey callback: %w", err)
 	}
 
-	waitErr := wait.PollUntilContextTimeout(ctx, pollBastionStatusInterval, o.WaitTimeout, false, func(ctx context.Context) (bool, error) {
-		key := client.ObjectKeyFromObject(bastion)
+	start := clock.Now()
+	key := client.ObjectKeyFromObject(bastion)
 
+	// the BastionReady condition and the SSH availability are bounded independently,
+	// as the bastion may accept SSH connections long after the condition became true
+	waitErr := wait.PollUntilContextTimeout(ctx, pollBastionStatusInterval, o.ConditionTimeout, false, func(ctx context.Context) (bool, error) {
 		if err := gardenClient.Get(ctx, key, bastion); err != nil {
 			return false, err
 		}
@@ -1056,34 +2071,76 @@ func waitForBastion(ctx context.Context, o *SSHOptions, gardenClient client.Clie
 			return false, nil
 		}
 
-		if o.SkipAvailabilityCheck {
-			logger.Info("Bastion is ready, skipping availability check")
-			return true, nil
-		}
+		return true, nil
+	})
+
+	if wait.Interrupted(waitErr) {
+		o.printBastionYAML(bastion)
+		return fmt.Errorf("timed out waiting for the bastion to become ready: %w", lastCheckErr)
+	}
+
+	if waitErr != nil {
+		return waitErr
+	}
+
+	readyTime := clock.Now()
+	o.recordBastionPhase(BastionPhaseWaitCondition, readyTime.Sub(start))
+
+	if o.SkipAvailabilityCheck {
+		o.logStatus(logger, color.FgGreen, "Bastion is ready, skipping availability check")
+		return nil
+	}
 
-		bastionPreferredAddress := preferredBastionAddress(o.BastionHost, bastion)
+	bastionPreferredAddress := preferredBastionAddress(o.BastionHost, bastion)
 
+	waitErr = wait.PollUntilContextTimeout(ctx, pollBastionStatusInterval, o.WaitTimeout, true, func(ctx context.Context) (bool, error) {
 		lastCheckErr = bastionAvailabilityChecker(
 			bastionPreferredAddress,
 			o.BastionPort,
 			privateKeyBytes,
+			o.IdentityAgent,
 			hostKeyCallback,
+			o.HTTPSProxy,
 		)
 		if lastCheckErr != nil {
 			logger.Error(lastCheckErr, "Still waiting for bastion to accept SSH connection")
 			return false, nil
 		}
 
+		o.recordBastionPhase(BastionPhaseAvailabilityCheck, clock.Now().Sub(readyTime))
+
 		return true, nil
 	})
 
 	if wait.Interrupted(waitErr) {
-		return fmt.Errorf("timed out waiting for the bastion to become ready: %w", lastCheckErr)
+		o.printBastionYAML(bastion)
+		return fmt.Errorf("timed out waiting for the bastion to accept SSH connections: %w", lastCheckErr)
 	}
 
 	return waitErr
 }
 
+// printBastionYAML prints the last observed state of the bastion as YAML to stderr if the
+// --print-bastion-yaml flag is set. The managed fields are omitted, as they are not helpful for debugging.
+func (o *SSHOptions) printBastionYAML(bastion *operationsv1alpha1.Bastion) {
+	if !o.PrintBastionYAML {
+		return
+	}
+
+	b := bastion.DeepCopy()
+	b.APIVersion = operationsv1alpha1.SchemeGroupVersion.String()
+	b.Kind = "Bastion"
+	b.ManagedFields = nil
+
+	data, err := yaml.Marshal(b)
+	if err != nil {
+		fmt.Fprintf(o.IOStreams.ErrOut, "failed to print bastion %s/%s: %v\n", b.Namespace, b.Name, err)
+		return
+	}
+
+	fmt.Fprintf(o.IOStreams.ErrOut, "Bastion %s/%s:\n%s", b.Namespace, b.Name, data)
+}
+
 func getShootNode(ctx context.Context, o *SSHOptions, shootClient client.Client) (*corev1.Node, error) {
 	node := &corev1.Node{}
 	if err := shootClient.Get(ctx, types.NamespacedName{Name: o.NodeName}, node); err != nil {
@@ -1093,6 +2150,91 @@ func getShootNode(ctx context.Context, o *SSHOptions, shootClient client.Client)
 	return node, nil
 }
 
+// checkNodeReadiness warns if the node is not ready, or fails if RequireReady is set.
+// Nothing is checked unless NodeReadinessCheck or RequireReady is set.
+func (o *SSHOptions) checkNodeReadiness(logger klog.Logger, node *corev1.Node) error {
+	if !o.NodeReadinessCheck && !o.RequireReady {
+		return nil
+	}
+
+	if isNodeReady(*node) {
+		return nil
+	}
+
+	if o.RequireReady {
+		return fmt.Errorf("node %q is not ready", node.Name)
+	}
+
+	logger.Info("Node is not ready, connecting anyways", "nodeName", node.Name)
+
+	return nil
+}
+
+// parsePodReference splits a pod reference in the format namespace/name.
+func parsePodReference(pod string) (string, string, error) {
+	namespace, name, ok := strings.Cut(pod, "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid pod %q, must be in the format namespace/name", pod)
+	}
+
+	return namespace, name, nil
+}
+
+// getShootNodeByPod returns the node the given pod is scheduled on.
+func getShootNodeByPod(ctx context.Context, shootClient client.Client, pod string) (*corev1.Node, error) {
+	namespace, name, err := parsePodReference(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &corev1.Pod{}
+	if err := shootClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, p); err != nil {
+		return nil, fmt.Errorf("failed to get pod %q: %w", pod, err)
+	}
+
+	if p.Spec.NodeName == "" {
+		return nil, fmt.Errorf("pod %q is not scheduled on a node", pod)
+	}
+
+	node := &corev1.Node{}
+	if err := shootClient.Get(ctx, types.NamespacedName{Name: p.Spec.NodeName}, node); err != nil {
+		return nil, fmt.Errorf("failed to get node %q of pod %q: %w", p.Spec.NodeName, pod, err)
+	}
+
+	return node, nil
+}
+
+// getShootNodeByProviderID returns the node whose .spec.providerID equals the given provider ID.
+// An error is returned if no node or more than one node has this provider ID.
+func getShootNodeByProviderID(ctx context.Context, shootClient client.Client, providerID string) (*corev1.Node, error) {
+	nodes, err := getNodes(ctx, shootClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []corev1.Node
+
+	for _, node := range nodes {
+		if node.Spec.ProviderID == providerID {
+			matches = append(matches, node)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no node found with provider ID %q", providerID)
+	case 1:
+		return &matches[0], nil
+	default:
+		names := make([]string, 0, len(matches))
+		for _, node := range matches {
+			names = append(names, node.Name)
+		}
+
+		return nil, fmt.Errorf("provider ID %q is ambiguous, it matches the nodes %s", providerID, strings.Join(names, ", "))
+	}
+}
+
 func remoteShell(
 	ctx context.Context,
 	ioStreams util.IOStreams,
@@ -1101,10 +2243,14 @@ func remoteShell(
 	sshPrivateKeyFile PrivateKeyFile,
 	bastionUserKnownHostsFiles []string,
 	bastionStrictHostKeyChecking StrictHostKeyChecking,
+	httpsProxy string,
 	nodeUserKnownHostsFiles []string,
 	nodeStrictHostKeyChecking StrictHostKeyChecking,
+	hashKnownHosts bool,
 	nodeHostname string,
 	nodePrivateKeyFiles []PrivateKeyFile,
+	nodeAgentKeyOnly bool,
+	identityAgent string,
 	user string,
 ) error {
 	commandArgs := sshCommandArguments(
@@ -1113,13 +2259,48 @@ func remoteShell(
 		sshPrivateKeyFile,
 		bastionUserKnownHostsFiles,
 		bastionStrictHostKeyChecking,
+		httpsProxy,
 		nodeUserKnownHostsFiles,
 		nodeStrictHostKeyChecking,
+		hashKnownHosts,
 		nodeHostname,
 		nodePrivateKeyFiles,
+		nodeAgentKeyOnly,
+		identityAgent,
 		user,
 	)
 
+	return execSSH(ctx, ioStreams, commandArgs)
+}
+
+// connectDirectly opens an SSH connection to the node without a bastion. The node
+// private key files are removed afterwards.
+func connectDirectly(ctx context.Context, o *SSHOptions, nodeHostname string, nodePrivateKeyFiles []PrivateKeyFile) error {
+	logger := klog.FromContext(ctx)
+
+	defer func() {
+		for _, filename := range nodePrivateKeyFiles {
+			if err := os.Remove(filename.String()); err != nil {
+				logger.Error(err, "Failed to delete node private key", "path", filename)
+			}
+		}
+	}()
+
+	commandArgs := directSSHCommandArguments(
+		o.NodeUserKnownHostsFiles,
+		o.NodeStrictHostKeyChecking,
+		o.HashKnownHosts,
+		nodeHostname,
+		nodePrivateKeyFiles,
+		o.NodeAgentKeyOnly,
+		o.IdentityAgent,
+		o.User,
+	)
+
+	return execSSH(ctx, o.IOStreams, commandArgs)
+}
+
+func execSSH(ctx context.Context, ioStreams util.IOStreams, commandArgs arguments) error {
 	fmt.Fprintf(ioStreams.Out, "> You can open additional SSH sessions by running the following command in a separate terminal:\n\n")
 	fmt.Fprintf(ioStreams.Out, "ssh %s\n\n", commandArgs.String())
 
@@ -1151,43 +2332,174 @@ func keepBastionAlive(ctx context.Context, cancel context.CancelFunc, gardenClie
 			return
 
 		case <-ticker.C:
-			// re-fetch current bastion
-			key := types.NamespacedName{Name: bastion.Name, Namespace: bastion.Namespace}
+			// the patch is rejected if the bastion has been modified in the meantime, e.g. by the bastion
+			// controller, so it is retried with the re-fetched bastion instead of skipping a keepalive cycle
+			err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				return patchBastionKeepalive(ctx, gardenClient, bastion)
+			})
+			if apierrors.IsNotFound(err) {
+				logger.Error(err, "Can't keep bastion alive. Bastion is already gone.")
+				cancel()
 
-			// reset annotations so that we fetch the actual current state
-			bastion.Annotations = map[string]string{}
+				return
+			}
 
-			if err := gardenClient.Get(ctx, key, bastion); err != nil {
-				if apierrors.IsNotFound(err) {
-					logger.Error(err, "Can't keep bastion alive. Bastion is already gone.")
-					cancel()
+			if err != nil {
+				logger.Error(err, "Failed to keep bastion alive.")
+			}
+		}
+	}
+}
 
-					return
-				}
+// patchBastionKeepalive re-fetches the given bastion and adds the keepalive annotation. The patch contains the
+// resourceVersion of the re-fetched bastion and therefore fails with a conflict if the bastion has been modified since.
+func patchBastionKeepalive(ctx context.Context, gardenClient client.Client, bastion *operationsv1alpha1.Bastion) error {
+	// re-fetch current bastion
+	key := types.NamespacedName{Name: bastion.Name, Namespace: bastion.Namespace}
 
-				logger.Error(err, "Failed to keep bastion alive.")
+	// reset annotations so that we fetch the actual current state
+	bastion.Annotations = map[string]string{}
 
-				continue
-			}
+	if err := gardenClient.Get(ctx, key, bastion); err != nil {
+		return err
+	}
 
-			// add the keepalive annotation
-			oldBastion := bastion.DeepCopy()
+	// add the keepalive annotation
+	oldBastion := bastion.DeepCopy()
 
-			if bastion.Annotations == nil {
-				bastion.Annotations = map[string]string{}
-			}
+	if bastion.Annotations == nil {
+		bastion.Annotations = map[string]string{}
+	}
 
-			bastion.Annotations[corev1beta1constants.GardenerOperation] = corev1beta1constants.GardenerOperationKeepalive
+	bastion.Annotations[corev1beta1constants.GardenerOperation] = corev1beta1constants.GardenerOperationKeepalive
 
-			if err := gardenClient.Patch(ctx, bastion, client.MergeFrom(oldBastion)); err != nil {
-				logger.Error(err, "Failed to keep bastion alive.")
+	return gardenClient.Patch(ctx, bastion, client.MergeFromWithOptions(oldBastion, client.MergeFromWithOptimisticLock{}))
+}
+
+// resolveShootTarget returns the given target, or if a managed seed is targeted, the
+// target of the shoot referred by the managed seed. It fails if no shoot is targeted.
+func resolveShootTarget(ctx context.Context, gardenClient clientgarden.Client, currentTarget target.Target) (target.Target, error) {
+	logger := klog.FromContext(ctx)
+
+	if currentTarget.ShootName() == "" && currentTarget.SeedName() != "" {
+		shoot, err := gardenClient.GetShootOfManagedSeed(ctx, currentTarget.SeedName())
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("cannot ssh to non-managed seeds: %w", err)
 			}
+
+			return nil, err
+		}
+
+		logger.V(1).Info("using referred shoot of managed seed",
+			"shoot", klog.ObjectRef{
+				Namespace: "garden",
+				Name:      shoot.Name,
+			},
+			"seed", currentTarget.SeedName())
+
+		currentTarget = currentTarget.WithProjectName("garden").WithShootName(shoot.Name)
+	}
+
+	if currentTarget.ShootName() == "" {
+		return nil, target.ErrNoShootTargeted
+	}
+
+	return currentTarget, nil
+}
+
+// resolveControlPlaneTarget returns the target of the seed hosting the control plane of the targeted shoot,
+// along with the name of the seed node running the kube-apiserver of the shoot. As bastions are created for
+// shoots, only managed seeds are supported, see resolveShootTarget.
+func resolveControlPlaneTarget(ctx context.Context, manager target.Manager, gardenClient clientgarden.Client, currentTarget target.Target) (target.Target, string, error) {
+	if currentTarget.ShootName() == "" {
+		return nil, "", target.ErrNoShootTargeted
+	}
+
+	shoot, err := gardenClient.FindShoot(ctx, currentTarget.AsListOption())
+	if err != nil {
+		return nil, "", err
+	}
+
+	if shoot.Spec.SeedName == nil || *shoot.Spec.SeedName == "" {
+		return nil, "", fmt.Errorf("shoot %q has not yet been scheduled to a seed", shoot.Name)
+	}
+
+	seedTarget := target.NewTarget(currentTarget.GardenName(), "", *shoot.Spec.SeedName, "")
+
+	seedClient, err := manager.SeedClient(ctx, seedTarget)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create seed cluster client: %w", err)
+	}
+
+	podList := &corev1.PodList{}
+	if err := seedClient.List(ctx, podList,
+		client.InNamespace(shoot.Status.TechnicalID),
+		client.MatchingLabels{
+			corev1beta1constants.LabelApp:  corev1beta1constants.LabelKubernetes,
+			corev1beta1constants.LabelRole: corev1beta1constants.LabelAPIServer,
+		},
+	); err != nil {
+		return nil, "", fmt.Errorf("failed to list kube-apiserver pods of shoot %q: %w", shoot.Name, err)
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Spec.NodeName != "" {
+			return seedTarget, pod.Spec.NodeName, nil
 		}
 	}
+
+	return nil, "", fmt.Errorf("no kube-apiserver pod of shoot %q is scheduled on a node of seed %q", shoot.Name, *shoot.Spec.SeedName)
+}
+
+// shootNodePrivateKey is a private SSH key for the shoot nodes along with the name
+// of the secret it has been read from.
+type shootNodePrivateKey struct {
+	secretName string
+	data       []byte
+}
+
+// getNodePrivateKeys returns the private SSH keys for the shoot nodes. If NodeAgentKey is set and no node keypair
+// secret exists or it cannot be read, no key is returned and NodeAgentKeyOnly is set, provided that the
+// SSH agent holds at least one identity.
+func (o *SSHOptions) getNodePrivateKeys(ctx context.Context, gardenClient client.Client, shoot *gardencorev1beta1.Shoot) ([][]byte, error) {
+	nodePrivateKeys, err := getShootNodePrivateKeys(ctx, gardenClient, shoot)
+	if err == nil || !o.NodeAgentKey {
+		return nodePrivateKeys, err
+	}
+
+	count, agentErr := countSSHAgentSigners(o.IdentityAgent)
+	if agentErr != nil {
+		return nil, fmt.Errorf("%w and the SSH agent cannot be used instead: %w", err, agentErr)
+	} else if count == 0 {
+		return nil, fmt.Errorf("%w and the SSH agent holds no identity to be used instead", err)
+	}
+
+	klog.FromContext(ctx).Info("Using the identities of the SSH agent for the node", "reason", err.Error())
+
+	o.NodeAgentKeyOnly = true
+
+	return nil, nil
 }
 
 func getShootNodePrivateKeys(ctx context.Context, gardenClient client.Client, shoot *gardencorev1beta1.Shoot) ([][]byte, error) {
-	keys := [][]byte{}
+	nodePrivateKeys, err := getShootNodePrivateKeySecrets(ctx, gardenClient, shoot)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([][]byte, 0, len(nodePrivateKeys))
+	for _, pk := range nodePrivateKeys {
+		keys = append(keys, pk.data)
+	}
+
+	return keys, nil
+}
+
+// getShootNodePrivateKeySecrets returns the current and, if it exists, the previous
+// (.old) private SSH key of the shoot nodes.
+func getShootNodePrivateKeySecrets(ctx context.Context, gardenClient client.Client, shoot *gardencorev1beta1.Shoot) ([]shootNodePrivateKey, error) {
+	keys := []shootNodePrivateKey{}
 
 	// TODO: use ShootProjectSecretSuffixOldSSHKeypair once Gardener releases a version with it
 	for _, suffix := range []string{gutil.ShootProjectSecretSuffixSSHKeypair, "ssh-keypair.old"} {
@@ -1202,7 +2514,10 @@ func getShootNodePrivateKeys(ctx context.Context, gardenClient client.Client, sh
 		}
 
 		if secret.Name != "" {
-			keys = append(keys, secret.Data[secrets.DataKeyRSAPrivateKey])
+			keys = append(keys, shootNodePrivateKey{
+				secretName: secret.Name,
+				data:       secret.Data[secrets.DataKeyRSAPrivateKey],
+			})
 		}
 	}
 
@@ -1213,8 +2528,8 @@ func getShootNodePrivateKeys(ctx context.Context, gardenClient client.Client, sh
 	return keys, nil
 }
 
-func writeToTemporaryFile(key []byte) (string, error) {
-	f, err := tempFileCreator()
+func writeToTemporaryFile(dir string, key []byte) (string, error) {
+	f, err := tempFileCreator(dir)
 	if err != nil {
 		return "", err
 	}
@@ -1231,21 +2546,89 @@ func writeToTemporaryFile(key []byte) (string, error) {
 	return f.Name(), nil
 }
 
-func getNodeHostname(node *corev1.Node) (string, error) {
-	addresses := map[corev1.NodeAddressType]string{}
-	for _, addr := range node.Status.Addresses {
-		addresses[addr.Type] = addr.Address
+// As we connect via a jump host that's in the same network
+// as the shoot nodes, we prefer the internal IP/hostname by default.
+var defaultNodeAddressPreference = []corev1.NodeAddressType{
+	corev1.NodeInternalIP,
+	corev1.NodeInternalDNS,
+	corev1.NodeExternalIP,
+	corev1.NodeExternalDNS,
+}
+
+var validNodeAddressTypes = []corev1.NodeAddressType{
+	corev1.NodeInternalIP,
+	corev1.NodeInternalDNS,
+	corev1.NodeExternalIP,
+	corev1.NodeExternalDNS,
+	corev1.NodeHostName,
+}
+
+// parseNodeAddressPreference converts the given address types into an ordered list
+// of node address types. If no types are given, defaultNodeAddressPreference is returned.
+func parseNodeAddressPreference(types []string) ([]corev1.NodeAddressType, error) {
+	if len(types) == 0 {
+		return defaultNodeAddressPreference, nil
+	}
+
+	preference := make([]corev1.NodeAddressType, 0, len(types))
+
+	for _, t := range types {
+		addressType := corev1.NodeAddressType(strings.TrimSpace(t))
+		if !slices.Contains(validNodeAddressTypes, addressType) {
+			return nil, fmt.Errorf("invalid node address type %q, valid types are %v", t, validNodeAddressTypes)
+		}
+
+		if slices.Contains(preference, addressType) {
+			return nil, fmt.Errorf("duplicate node address type %q", t)
+		}
+
+		preference = append(preference, addressType)
 	}
 
-	// As we connect via a jump host that's in the same network
-	// as the shoot nodes, we prefer the internal IP/hostname.
-	for _, k := range []corev1.NodeAddressType{corev1.NodeInternalIP, corev1.NodeInternalDNS, corev1.NodeExternalIP, corev1.NodeExternalDNS} {
-		if addr := addresses[k]; addr != "" {
-			return addr, nil
+	return preference, nil
+}
+
+const (
+	nodeIPFamilyIPv4 = "ipv4"
+	nodeIPFamilyIPv6 = "ipv6"
+)
+
+// getNodeHostname returns the first address of the node by the given preference of address types.
+// If an IP family is given, only IP addresses of this family are considered.
+func getNodeHostname(node *corev1.Node, preference []corev1.NodeAddressType, ipFamily string) (string, error) {
+	for _, k := range preference {
+		for _, addr := range node.Status.Addresses {
+			if addr.Type != k || addr.Address == "" {
+				continue
+			}
+
+			if ipFamily != "" && !isIPOfFamily(addr.Address, ipFamily) {
+				continue
+			}
+
+			return addr.Address, nil
 		}
 	}
 
-	return "", errors.New("node has no internal or external names")
+	if ipFamily != "" {
+		return "", fmt.Errorf("node has no %s address of type %v", ipFamily, preference)
+	}
+
+	return "", fmt.Errorf("node has no address of type %v", preference)
+}
+
+// isIPOfFamily returns true if the given address is an IP address of the given family.
+func isIPOfFamily(address, ipFamily string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+
+	if ipFamily == nodeIPFamilyIPv4 {
+		return ip.To4() != nil
+	}
+
+	return ip.To4() == nil
 }
 
 func getNodes(ctx context.Context, c client.Client) ([]corev1.Node, error) {
@@ -1267,6 +2650,20 @@ func getMachines(ctx context.Context, c client.Client, namespace string) ([]mach
 }
 
 func (o *SSHOptions) checkAccessRestrictions(cfg *config.Config, gardenName string, tf target.TargetFlags, shoot *gardencorev1beta1.Shoot) (bool, error) {
+	if o.BannerFile != "" {
+		banner, err := os.ReadFile(o.BannerFile)
+		if err != nil {
+			return false, fmt.Errorf("failed to read banner file: %w", err)
+		}
+
+		// do not write the banner to stdout, otherwise it would break the output format
+		fmt.Fprintln(o.IOStreams.ErrOut, strings.TrimRight(string(banner), "\n"))
+	}
+
+	return checkAccessRestrictions(o.IOStreams, o.ConfirmAccessRestriction, o.ExplainAccessRestrictions, cfg, gardenName, tf, shoot)
+}
+
+func checkAccessRestrictions(ioStreams util.IOStreams, confirmed bool, explain bool, cfg *config.Config, gardenName string, tf target.TargetFlags, shoot *gardencorev1beta1.Shoot) (bool, error) {
 	if cfg == nil {
 		return false, errors.New("garden configuration is required")
 	}
@@ -1281,8 +2678,13 @@ func (o *SSHOptions) checkAccessRestrictions(cfg *config.Config, gardenName stri
 		return false, err
 	}
 
-	askForConfirmation := tf.ShootName() != "" && !o.ConfirmAccessRestriction
-	handler := ac.NewAccessRestrictionHandler(o.IOStreams.In, o.IOStreams.ErrOut, askForConfirmation) // do not write access restriction to stdout, otherwise it would break the output format
+	if explain {
+		// do not write the explanation to stdout, otherwise it would break the output format
+		ac.ExplainAccessRestrictions(garden.AccessRestrictions, shoot).Render(ioStreams.ErrOut)
+	}
+
+	askForConfirmation := tf.ShootName() != "" && !confirmed
+	handler := ac.NewAccessRestrictionHandler(ioStreams.In, ioStreams.ErrOut, askForConfirmation) // do not write access restriction to stdout, otherwise it would break the output format
 
 	return handler(ac.CheckAccessRestrictions(garden.AccessRestrictions, shoot)), nil
 }
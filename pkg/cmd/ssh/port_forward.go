@@ -0,0 +1,123 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/klog/v2"
+
+	clientgarden "github.com/gardener/gardenctl-v2/internal/client/garden"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+// apiServerAddress returns the host and port of the kube-apiserver of the shoot. Like the shoot kubeconfig,
+// it uses the first advertised address of the kube-apiserver.
+func apiServerAddress(shoot *gardencorev1beta1.Shoot) (string, string, error) {
+	for _, address := range shoot.Status.AdvertisedAddresses {
+		if address.Name != clientgarden.AdvertisedAddressExternal &&
+			address.Name != clientgarden.AdvertisedAddressInternal &&
+			address.Name != clientgarden.AdvertisedAddressUnmanaged {
+			continue
+		}
+
+		u, err := url.Parse(address.URL)
+		if err != nil {
+			return "", "", fmt.Errorf("could not parse shoot server url: %w", err)
+		}
+
+		port := u.Port()
+		if port == "" {
+			port = "443"
+		}
+
+		return u.Hostname(), port, nil
+	}
+
+	return "", "", errors.New("no advertised addresses listed in the Shoot status for the Shoot Kube API server")
+}
+
+// portForwardKubeconfig returns a copy of the current context of the given kubeconfig that points to the
+// forwarded local port. The server certificate is still verified against the host of the kube-apiserver.
+func portForwardKubeconfig(config clientcmdapi.Config, localPort int, serverName string) (*clientcmdapi.Config, error) {
+	kubeconfig := config.DeepCopy()
+	if err := clientcmdapi.MinifyConfig(kubeconfig); err != nil {
+		return nil, err
+	}
+
+	for _, cluster := range kubeconfig.Clusters {
+		cluster.Server = "https://" + net.JoinHostPort("127.0.0.1", strconv.Itoa(localPort))
+		cluster.TLSServerName = serverName
+	}
+
+	return kubeconfig, nil
+}
+
+// portForward prints a kubeconfig for the forwarded local port to stdout and forwards the port through
+// the bastion to the kube-apiserver of the shoot until gardenctl is stopped.
+func (o *SSHOptions) portForward(ctx context.Context, manager target.Manager, currentTarget target.Target, shoot *gardencorev1beta1.Shoot, bastionHost string) error {
+	logger := klog.FromContext(ctx)
+
+	host, port, err := apiServerAddress(shoot)
+	if err != nil {
+		return err
+	}
+
+	clientConfig, err := manager.ClientConfig(ctx, currentTarget)
+	if err != nil {
+		return err
+	}
+
+	rawConfig, err := clientConfig.RawConfig()
+	if err != nil {
+		return err
+	}
+
+	kubeconfig, err := portForwardKubeconfig(rawConfig, o.PortForward, host)
+	if err != nil {
+		return fmt.Errorf("failed to create kubeconfig for the forwarded port: %w", err)
+	}
+
+	data, err := clientcmd.Write(*kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to write kubeconfig for the forwarded port: %w", err)
+	}
+
+	if _, err := o.IOStreams.Out.Write(data); err != nil {
+		return err
+	}
+
+	commandArgs := portForwardArguments(
+		bastionHost,
+		o.BastionPort,
+		o.SSHPrivateKeyFile,
+		o.BastionUserKnownHostsFiles,
+		o.BastionStrictHostKeyChecking,
+		o.HashKnownHosts,
+		o.HTTPSProxy,
+		o.PortForward,
+		host,
+		port,
+	)
+
+	logger.Info("Forwarding local port to the kube-apiserver through the bastion, press Ctrl-C to stop", "localPort", o.PortForward, "apiServer", net.JoinHostPort(host, port))
+
+	var args []string
+	for _, arg := range commandArgs.list {
+		args = append(args, arg.value)
+	}
+
+	return execCommand(ctx, "ssh", args, o.IOStreams)
+}
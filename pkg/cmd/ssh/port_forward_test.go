@@ -0,0 +1,73 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ssh_test
+
+import (
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/gardener/gardenctl-v2/pkg/cmd/ssh"
+)
+
+var _ = Describe("Port forward", func() {
+	Describe("apiServerAddress", func() {
+		It("should return the first kube-apiserver address", func() {
+			shoot := &gardencorev1beta1.Shoot{
+				Status: gardencorev1beta1.ShootStatus{
+					AdvertisedAddresses: []gardencorev1beta1.ShootAdvertisedAddress{
+						{Name: "service-account-issuer", URL: "https://issuer.example.com"},
+						{Name: "internal", URL: "https://api.internal.example.com:8443"},
+						{Name: "external", URL: "https://api.example.com"},
+					},
+				},
+			}
+
+			host, port, err := ssh.APIServerAddress(shoot)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(host).To(Equal("api.internal.example.com"))
+			Expect(port).To(Equal("8443"))
+		})
+
+		It("should fail without advertised addresses", func() {
+			_, _, err := ssh.APIServerAddress(&gardencorev1beta1.Shoot{})
+			Expect(err).To(MatchError("no advertised addresses listed in the Shoot status for the Shoot Kube API server"))
+		})
+	})
+
+	Describe("portForwardKubeconfig", func() {
+		It("should only keep the current context and point it to the local port", func() {
+			config := clientcmdapi.NewConfig()
+			config.CurrentContext = "external"
+
+			for _, name := range []string{"external", "internal"} {
+				cluster := clientcmdapi.NewCluster()
+				cluster.Server = "https://api." + name + ".example.com"
+				config.Clusters[name] = cluster
+
+				context := clientcmdapi.NewContext()
+				context.Cluster = name
+				context.AuthInfo = "user"
+				config.Contexts[name] = context
+			}
+
+			config.AuthInfos["user"] = clientcmdapi.NewAuthInfo()
+
+			kubeconfig, err := ssh.PortForwardKubeconfig(*config, 16443, "api.external.example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kubeconfig.Contexts).To(HaveKey("external"))
+			Expect(kubeconfig.Contexts).To(HaveLen(1))
+			Expect(kubeconfig.Clusters).To(HaveLen(1))
+			Expect(kubeconfig.Clusters["external"].Server).To(Equal("https://127.0.0.1:16443"))
+			Expect(kubeconfig.Clusters["external"].TLSServerName).To(Equal("api.external.example.com"))
+
+			By("not modifying the given kubeconfig")
+			Expect(config.Clusters["external"].Server).To(Equal("https://api.external.example.com"))
+		})
+	})
+})
@@ -0,0 +1,112 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ssh
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// parseHTTPSProxy parses the given proxy URL. Only plain HTTP proxies supporting
+// the CONNECT method are supported, e.g. http://proxy.example.com:3128.
+// Credentials are rejected, as nc cannot pass them to the proxy in the ProxyCommand of the ssh client.
+func parseHTTPSProxy(proxy string) (*url.URL, error) {
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid https proxy %q: %w", proxy, err)
+	}
+
+	if u.Scheme != "http" {
+		return nil, fmt.Errorf("invalid https proxy %q: scheme must be http", proxy)
+	}
+
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid https proxy %q: host must not be empty", proxy)
+	}
+
+	if u.User != nil {
+		return nil, fmt.Errorf("invalid https proxy %q: credentials are not supported", u.Redacted())
+	}
+
+	return u, nil
+}
+
+// proxyAddress returns the host:port of the given proxy URL, defaulting to port 80.
+func proxyAddress(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = "80"
+	}
+
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// dialHTTPConnect opens a connection to addr that is tunneled through the given
+// HTTP proxy using the CONNECT method.
+func dialHTTPConnect(proxy *url.URL, addr string, timeout time.Duration) (net.Conn, error) {
+	conn, err := net.DialTimeout("tcp", proxyAddress(proxy), timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to https proxy: %w", err)
+	}
+
+	if timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(timeout))
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to send CONNECT request to https proxy: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read CONNECT response from https proxy: %w", err)
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, errors.New("https proxy refused CONNECT request: " + resp.Status)
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+
+	// the SSH server sends its banner first, which might already be buffered by the reader
+	return &bufferedConn{Conn: conn, reader: reader}, nil
+}
+
+// bufferedConn is a net.Conn which reads from a buffered reader wrapping the connection.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// httpsProxyCommand returns the ProxyCommand used by the ssh client to tunnel the
+// connection to the bastion through the given HTTP proxy.
+func httpsProxyCommand(proxy *url.URL) string {
+	return fmt.Sprintf("nc -X connect -x %s %%h %%p", proxyAddress(proxy))
+}
@@ -0,0 +1,117 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ssh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+)
+
+// publicIPsCacheTTL is the duration for which the detected public IPs are cached.
+var publicIPsCacheTTL = 5 * time.Minute
+
+// publicIPsCache is the on-disk cache of the detected public IPs.
+type publicIPsCache struct {
+	// Endpoint is the IP detection URL that was used to detect the addresses.
+	// It is empty if the default detection service was used.
+	Endpoint string `json:"endpoint,omitempty"`
+	// Addresses are the detected public IP addresses.
+	Addresses []string `json:"addresses"`
+	// Timestamp is the time the addresses were detected.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// publicIPs returns the public IPs of the user, either detected using the configured
+// IP detection URL or the default detection service. The result is cached in the
+// garden home directory for publicIPsCacheTTL.
+func (o *AccessConfig) publicIPs(ctx context.Context, f util.Factory) ([]string, error) {
+	logger := klog.FromContext(ctx)
+
+	home := f.GardenHomeDir()
+	if home == "" {
+		return o.detectPublicIPs(ctx, f)
+	}
+
+	cacheFile := filepath.Join(home, "cache", "public-ips.json")
+	now := f.Clock().Now()
+
+	if addresses, ok := readPublicIPsCache(cacheFile, o.IPDetectionURL, now); ok {
+		logger.V(4).Info("Using cached public IPs", "addresses", addresses)
+		return addresses, nil
+	}
+
+	addresses, err := o.detectPublicIPs(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writePublicIPsCache(cacheFile, publicIPsCache{Endpoint: o.IPDetectionURL, Addresses: addresses, Timestamp: now}); err != nil {
+		logger.V(4).Info("Failed to cache public IPs", "err", err)
+	}
+
+	return addresses, nil
+}
+
+// detectPublicIPs detects the public IPs using the IP detection URL, if set, or the default detection service.
+func (o *AccessConfig) detectPublicIPs(ctx context.Context, f util.Factory) ([]string, error) {
+	if o.IPDetectionURL == "" {
+		return f.PublicIPs(ctx)
+	}
+
+	ip, err := util.FetchPublicIP(ctx, o.IPDetectionURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{ip.String()}, nil
+}
+
+func readPublicIPsCache(filename, endpoint string, now time.Time) ([]string, bool) {
+	data, err := os.ReadFile(filename) // #nosec G304 -- The file is located in the garden home directory
+	if err != nil {
+		return nil, false
+	}
+
+	cache := publicIPsCache{}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, false
+	}
+
+	if cache.Endpoint != endpoint || len(cache.Addresses) == 0 || now.Sub(cache.Timestamp) > publicIPsCacheTTL || cache.Timestamp.After(now) {
+		return nil, false
+	}
+
+	for _, address := range cache.Addresses {
+		if net.ParseIP(address) == nil {
+			return nil, false
+		}
+	}
+
+	return cache.Addresses, true
+}
+
+func writePublicIPsCache(filename string, cache publicIPsCache) error {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("failed to marshal public IPs cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return os.WriteFile(filename, data, 0o600)
+}
@@ -0,0 +1,128 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+)
+
+// createRefreshSignalChannel returns a channel which receives the signal to refresh the node private key
+// files, and a function that stops relaying the signal to the channel.
+var createRefreshSignalChannel = func() (chan os.Signal, func()) {
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, syscall.SIGHUP)
+
+	return signalChan, func() { signal.Stop(signalChan) }
+}
+
+// refreshNodeKeysOnSignal rewrites the node private key files with the current node keypair secrets of the shoot
+// whenever gardenctl receives SIGHUP, e.g. after a key rotation during a long --keep-bastion session. The updated
+// paths are printed and stored in nodePrivateKeyFiles, which must not be accessed until the returned function,
+// which stops the refresh, has been called.
+func (o *SSHOptions) refreshNodeKeysOnSignal(
+	ctx context.Context,
+	gardenClient client.Client,
+	shoot *gardencorev1beta1.Shoot,
+	nodePrivateKeyFiles *[]PrivateKeyFile,
+) func() {
+	logger := klog.FromContext(ctx)
+
+	signalChan, stopNotify := createRefreshSignalChannel()
+
+	fmt.Fprintf(o.IOStreams.ErrOut, "> Send SIGHUP to gardenctl (PID %d) to refresh the node private key files.\n", os.Getpid())
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-signalChan:
+				files, err := refreshNodePrivateKeyFiles(ctx, gardenClient, shoot, o.TempDir, *nodePrivateKeyFiles)
+				if err != nil {
+					logger.Error(err, "Failed to refresh the node private keys")
+					continue
+				}
+
+				*nodePrivateKeyFiles = files
+
+				fmt.Fprintln(o.IOStreams.ErrOut, "> Refreshed the node private keys")
+
+				for _, file := range files {
+					fmt.Fprintf(o.IOStreams.ErrOut, "> Node private key file: %s\n", file)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		stopNotify()
+		close(done)
+		<-stopped
+	}
+}
+
+// refreshNodePrivateKeyFiles re-reads the node keypair secrets of the shoot and rewrites the given node private
+// key files in place, so that the printed ssh commands keep working. If the shoot has more keys than files now,
+// e.g. after the first rotation, additional files are created in the given directory. Surplus files are removed.
+func refreshNodePrivateKeyFiles(
+	ctx context.Context,
+	gardenClient client.Client,
+	shoot *gardencorev1beta1.Shoot,
+	dir string,
+	files []PrivateKeyFile,
+) ([]PrivateKeyFile, error) {
+	keys, err := getShootNodePrivateKeys(ctx, gardenClient, shoot)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshed := make([]PrivateKeyFile, 0, len(keys))
+
+	for i, key := range keys {
+		if i < len(files) {
+			if err := util.WritePrivateFile(files[i].String(), key); err != nil {
+				return nil, fmt.Errorf("failed to rewrite node private key file %q: %w", files[i], err)
+			}
+
+			refreshed = append(refreshed, files[i])
+
+			continue
+		}
+
+		filename, err := writeToTemporaryFile(dir, key)
+		if err != nil {
+			return nil, err
+		}
+
+		refreshed = append(refreshed, PrivateKeyFile(filename))
+	}
+
+	for _, file := range files[min(len(keys), len(files)):] {
+		if err := os.Remove(file.String()); err != nil {
+			return nil, fmt.Errorf("failed to remove node private key file %q: %w", file, err)
+		}
+	}
+
+	return refreshed, nil
+}
@@ -0,0 +1,104 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ssh_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	internalfake "github.com/gardener/gardenctl-v2/internal/fake"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/ssh"
+)
+
+var _ = Describe("refreshNodePrivateKeyFiles", func() {
+	var (
+		ctx       context.Context
+		testShoot *gardencorev1beta1.Shoot
+		directory string
+		files     []ssh.PrivateKeyFile
+	)
+
+	newKeypairSecret := func(name string, privateKey string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testShoot.Namespace,
+			},
+			Data: map[string][]byte{
+				"id_rsa": []byte(privateKey),
+			},
+		}
+	}
+
+	writeFile := func(name string, content string) ssh.PrivateKeyFile {
+		filename := filepath.Join(directory, name)
+		Expect(os.WriteFile(filename, []byte(content), 0o600)).To(Succeed())
+
+		return ssh.PrivateKeyFile(filename)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		testShoot = &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-shoot",
+				Namespace: "garden-prod1",
+			},
+		}
+		directory = GinkgoT().TempDir()
+		files = []ssh.PrivateKeyFile{writeFile("node-key", "current")}
+	})
+
+	It("should rewrite the node private key files in place", func() {
+		gardenClient := internalfake.NewClientWithObjects(newKeypairSecret("test-shoot.ssh-keypair", "rotated"))
+
+		refreshed, err := ssh.RefreshNodePrivateKeyFiles(ctx, gardenClient, testShoot, directory, files)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(refreshed).To(Equal(files))
+		Expect(os.ReadFile(files[0].String())).To(Equal([]byte("rotated")))
+	})
+
+	It("should add a file for the previous key after the first rotation", func() {
+		gardenClient := internalfake.NewClientWithObjects(
+			newKeypairSecret("test-shoot.ssh-keypair", "rotated"),
+			newKeypairSecret("test-shoot.ssh-keypair.old", "current"),
+		)
+
+		refreshed, err := ssh.RefreshNodePrivateKeyFiles(ctx, gardenClient, testShoot, directory, files)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(refreshed).To(HaveLen(2))
+		Expect(refreshed[0]).To(Equal(files[0]))
+		Expect(filepath.Dir(refreshed[1].String())).To(Equal(directory))
+		Expect(os.ReadFile(refreshed[0].String())).To(Equal([]byte("rotated")))
+		Expect(os.ReadFile(refreshed[1].String())).To(Equal([]byte("current")))
+	})
+
+	It("should remove the files of keys that no longer exist", func() {
+		files = append(files, writeFile("node-key.old", "previous"))
+		gardenClient := internalfake.NewClientWithObjects(newKeypairSecret("test-shoot.ssh-keypair", "rotated"))
+
+		refreshed, err := ssh.RefreshNodePrivateKeyFiles(ctx, gardenClient, testShoot, directory, files)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(refreshed).To(Equal(files[:1]))
+		Expect(files[1].String()).NotTo(BeAnExistingFile())
+	})
+
+	It("should keep the files if no keypair is available", func() {
+		gardenClient := internalfake.NewClientWithObjects()
+
+		_, err := ssh.RefreshNodePrivateKeyFiles(ctx, gardenClient, testShoot, directory, files)
+		Expect(err).To(MatchError("no SSH keypair is available for the shoot nodes"))
+		Expect(os.ReadFile(files[0].String())).To(Equal([]byte("current")))
+	})
+})
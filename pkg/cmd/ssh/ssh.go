@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/klog/v2"
 
 	"github.com/gardener/gardenctl-v2/internal/util"
@@ -27,13 +28,18 @@ func NewCmdSSH(f util.Factory, o *SSHOptions) *cobra.Command {
 A bastion is created to access the node and is automatically cleaned up afterwards.
 
 If a node name is not provided, gardenctl will display the hostnames/IPs of the Shoot worker nodes and the corresponding SSH command.
-To connect to a desired node, copy the printed SSH command, replace the target hostname accordingly, and execute the command.`,
+To connect to a desired node, copy the printed SSH command, replace the target hostname accordingly, and execute the command.
+
+A node that is named like a subcommand, i.e. config, doctor or dump-node-keys, must be given after "--", as the subcommand is run otherwise.`,
 		Example: `# Establish an SSH connection to a specific Shoot cluster node
 gardenctl ssh my-shoot-node-1
 
 # Establish an SSH connection with custom CIDRs to allow access to the bastion host
 gardenctl ssh my-shoot-node-1 --cidr 10.1.2.3/32
 
+# Establish an SSH connection to a node that is named like a subcommand
+gardenctl ssh -- dump-node-keys
+
 # Establish an SSH connection to any Shoot cluster node
 # Copy the printed SSH command, replace the 'IP_OR_HOSTNAME' placeholder for the target hostname/IP, and execute the command to connect to the desired node
 gardenctl ssh
@@ -43,6 +49,15 @@ gardenctl ssh --no-keepalive --keep-bastion --interactive=false --output json
 
 # Reuse a previously created bastion
 gardenctl ssh --keep-bastion --bastion-name cli-xxxxxxxx --public-key-file /path/to/ssh/key.pub --private-key-file /path/to/ssh/key
+
+# Connect directly to a node that is reachable from your system, e.g. through a VPN, without creating a bastion
+gardenctl ssh my-shoot-node-1 --no-bastion
+
+# Establish an SSH connection to the node a pod is scheduled on
+gardenctl ssh --pod kube-system/my-pod
+
+# Establish an SSH connection to the seed node running the kube-apiserver of the targeted shoot. The seed must be a managed seed
+gardenctl ssh --control-plane
 `,
 		Args: cobra.MaximumNArgs(1),
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -59,7 +74,13 @@ gardenctl ssh --keep-bastion --bastion-name cli-xxxxxxxx --public-key-file /path
 				return nil, cobra.ShellCompDirectiveNoFileComp
 			}
 
-			nodeNames, err := getNodeNamesFromMachinesOrNodes(ctx, manager)
+			selector, err := labels.Parse(o.NodeLabelFilter)
+			if err != nil {
+				logger.Error(err, "invalid node label filter")
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			nodeNames, err := getNodeNamesFromMachinesOrNodes(ctx, manager, selector)
 			if err != nil {
 				logger.Error(err, "could not get node names from shoot")
 				return nil, cobra.ShellCompDirectiveNoFileComp
@@ -79,7 +100,12 @@ gardenctl ssh --keep-bastion --bastion-name cli-xxxxxxxx --public-key-file /path
 
 	o.AddFlags(cmd.Flags())
 	o.RegisterCompletionsForOutputFlag(cmd)
+
+	for _, name := range noBastionExclusiveFlags {
+		cmd.MarkFlagsMutuallyExclusive("no-bastion", name)
+	}
 	o.RegisterCompletionFuncsForStrictHostKeyCheckings(cmd)
+	o.RegisterCompletionFuncForNodeAddressPreference(cmd)
 
 	o.AccessConfig.AddFlags(cmd.Flags())
 	RegisterCompletionFuncsForAccessConfigFlags(cmd, f)
@@ -87,5 +113,9 @@ gardenctl ssh --keep-bastion --bastion-name cli-xxxxxxxx --public-key-file /path
 	f.TargetFlags().AddFlags(cmd.Flags())
 	flags.RegisterCompletionFuncsForTargetFlags(cmd, f, o.IOStreams, cmd.Flags())
 
+	cmd.AddCommand(NewCmdDoctor(f, o.IOStreams))
+	cmd.AddCommand(NewCmdDumpNodeKeys(f, o.IOStreams))
+	cmd.AddCommand(NewCmdConfig(f, o.IOStreams))
+
 	return cmd
 }
@@ -8,13 +8,22 @@ package ssh_test
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
@@ -27,6 +36,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
 	cryptossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,11 +46,13 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	gardenclientmocks "github.com/gardener/gardenctl-v2/internal/client/garden/mocks"
 	clientmocks "github.com/gardener/gardenctl-v2/internal/client/mocks"
 	internalfake "github.com/gardener/gardenctl-v2/internal/fake"
 	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/ac"
 	"github.com/gardener/gardenctl-v2/pkg/cmd/ssh"
 	"github.com/gardener/gardenctl-v2/pkg/config"
 	"github.com/gardener/gardenctl-v2/pkg/target"
@@ -82,6 +94,25 @@ func waitForBastionThenSetBastionReady(ctx context.Context, gardenClient client.
 	})
 }
 
+type fakeMetricsRecorder struct {
+	mutex  sync.Mutex
+	phases []ssh.BastionPhase
+}
+
+func (r *fakeMetricsRecorder) RecordBastionPhase(phase ssh.BastionPhase, _ time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.phases = append(r.phases, phase)
+}
+
+func (r *fakeMetricsRecorder) Phases() []ssh.BastionPhase {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.phases
+}
+
 var _ = Describe("SSH Command", func() {
 	const (
 		gardenName           = "mygarden"
@@ -98,6 +129,7 @@ var _ = Describe("SSH Command", func() {
 		cfg                  *config.Config
 		streams              util.IOStreams
 		out                  *util.SafeBytesBuffer
+		errOut               *util.SafeBytesBuffer
 		factory              *internalfake.Factory
 		ctx                  context.Context
 		cancel               context.CancelFunc
@@ -115,6 +147,7 @@ var _ = Describe("SSH Command", func() {
 		shootClient          client.Client
 		seedClient           client.Client
 		nodePrivateKeyFile   string
+		nodePrivateKeyFiles  []string
 		logs                 *util.SafeBytesBuffer
 		signalChan           chan os.Signal
 		gardenHomeDir        string
@@ -127,16 +160,18 @@ var _ = Describe("SSH Command", func() {
 		klog.LogToStderr(false) // must set to false, otherwise klog will log to os.stderr instead of to our buffer
 
 		// all fake bastions are always immediately available
-		ssh.SetBastionAvailabilityChecker(func(hostname string, port string, privateKey []byte, hostKeyCallback cryptossh.HostKeyCallback) error {
+		ssh.SetBastionAvailabilityChecker(func(hostname string, port string, privateKey []byte, identityAgent string, hostKeyCallback cryptossh.HostKeyCallback, httpsProxy string) error {
 			return nil
 		})
 
 		// put the node SSH key into a known location
-		ssh.SetTempFileCreator(func() (*os.File, error) {
-			f, err := os.CreateTemp(os.TempDir(), "gctlv2*")
+		nodePrivateKeyFiles = nil
+		ssh.SetTempFileCreator(func(dir string) (*os.File, error) {
+			f, err := os.CreateTemp(dir, "gctlv2*")
 			Expect(err).ToNot(HaveOccurred())
 
 			nodePrivateKeyFile = f.Name()
+			nodePrivateKeyFiles = append(nodePrivateKeyFiles, f.Name())
 
 			return f, nil
 		})
@@ -264,12 +299,19 @@ var _ = Describe("SSH Command", func() {
 		testNode = &corev1.Node{
 			ObjectMeta: metav1.ObjectMeta{
 				Name: "node1",
+				Labels: map[string]string{
+					corev1.LabelTopologyZone:       "eu-west-1a",
+					corev1.LabelInstanceTypeStable: "m5.large",
+				},
 			},
 			Status: corev1.NodeStatus{
 				Addresses: []corev1.NodeAddress{{
 					Type:    corev1.NodeExternalDNS,
 					Address: nodeHostname,
 				}},
+				NodeInfo: corev1.NodeSystemInfo{
+					KubeletVersion: "v1.31.1",
+				},
 			},
 		}
 
@@ -279,6 +321,13 @@ var _ = Describe("SSH Command", func() {
 				Namespace: "shoot--prod1--test-shoot",
 				Labels:    map[string]string{machinev1alpha1.NodeLabelKey: "node1"},
 			},
+			Spec: machinev1alpha1.MachineSpec{
+				NodeTemplateSpec: machinev1alpha1.NodeTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{corev1.LabelTopologyZone: "eu-west-1a"},
+					},
+				},
+			},
 		}
 
 		pendingMachine = &machinev1alpha1.Machine{
@@ -287,12 +336,19 @@ var _ = Describe("SSH Command", func() {
 				Namespace: "shoot--prod1--test-shoot",
 				Labels:    map[string]string{machinev1alpha1.NodeLabelKey: "monitoring1"},
 			},
+			Spec: machinev1alpha1.MachineSpec{
+				NodeTemplateSpec: machinev1alpha1.NodeTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{corev1.LabelTopologyZone: "eu-west-1b"},
+					},
+				},
+			},
 		}
 
 		shootClient = internalfake.NewClientWithObjects(testNode)
 		seedClient = internalfake.NewClientWithObjects(testMachine, pendingMachine)
 
-		streams, _, out, _ = util.NewTestIOStreams()
+		streams, _, out, errOut = util.NewTestIOStreams()
 
 		ctrl = gomock.NewController(GinkgoT())
 
@@ -392,6 +448,161 @@ var _ = Describe("SSH Command", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
+		Context("wait-for-cleanup", func() {
+			var bastionKey client.ObjectKey
+
+			// addFinalizerThenInterrupt simulates a bastion controller that delays the
+			// deletion of the bastion by adding a finalizer.
+			addFinalizerThenInterrupt := func() {
+				defer GinkgoRecover()
+				defer func() {
+					signalChan <- os.Interrupt
+				}()
+
+				waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+				Eventually(func() bool {
+					return strings.Contains(logs.String(), bastionIP)
+				}).Should(BeTrue())
+
+				Eventually(func() error {
+					bastion := &operationsv1alpha1.Bastion{}
+					if err := gardenClient.Get(ctx, bastionKey, bastion); err != nil {
+						return err
+					}
+
+					patch := client.MergeFrom(bastion.DeepCopy())
+					bastion.Finalizers = []string{"test"}
+
+					return gardenClient.Patch(ctx, bastion, patch)
+				}).Should(Succeed())
+			}
+
+			BeforeEach(func() {
+				bastionKey = client.ObjectKey{Name: bastionName, Namespace: *testProject.Spec.Namespace}
+			})
+
+			It("should wait until the bastion has been deleted", func() {
+				options := ssh.NewSSHOptions(streams)
+				cmd := ssh.NewCmdSSH(factory, options)
+				Expect(cmd.Flags().Set("wait-for-cleanup", "true")).To(Succeed())
+
+				go addFinalizerThenInterrupt()
+
+				// simulate the bastion controller removing the finalizer once the bastion is deleted
+				go func() {
+					defer GinkgoRecover()
+
+					Eventually(func() error {
+						bastion := &operationsv1alpha1.Bastion{}
+						if err := gardenClient.Get(ctx, bastionKey, bastion); err != nil {
+							return err
+						}
+
+						if bastion.DeletionTimestamp == nil {
+							return errors.New("bastion not yet deleted")
+						}
+
+						patch := client.MergeFrom(bastion.DeepCopy())
+						bastion.Finalizers = nil
+
+						return gardenClient.Patch(ctx, bastion, patch)
+					}).WithTimeout(30 * time.Second).Should(Succeed())
+				}()
+
+				Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+				Expect(logs.String()).To(ContainSubstring("Waiting for bastion to be deleted"))
+				Expect(logs.String()).NotTo(ContainSubstring("Timed out waiting for bastion to be deleted"))
+
+				// assert that the bastion is gone
+				err := gardenClient.Get(ctx, bastionKey, &operationsv1alpha1.Bastion{})
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should log if waiting for the deletion times out", func() {
+				ssh.SetWaitForCleanupTimeout(2 * time.Second)
+				DeferCleanup(ssh.SetWaitForCleanupTimeout, time.Minute)
+
+				options := ssh.NewSSHOptions(streams)
+				cmd := ssh.NewCmdSSH(factory, options)
+				Expect(cmd.Flags().Set("wait-for-cleanup", "true")).To(Succeed())
+
+				go addFinalizerThenInterrupt()
+
+				Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+				Expect(logs.String()).To(ContainSubstring("Timed out waiting for bastion to be deleted"))
+
+				// assert that the bastion is still being deleted
+				bastion := &operationsv1alpha1.Bastion{}
+				Expect(gardenClient.Get(ctx, bastionKey, bastion)).To(Succeed())
+				Expect(bastion.DeletionTimestamp).NotTo(BeNil())
+			})
+		})
+
+		Context("metrics", func() {
+			// runAndInterrupt runs the ssh command and interrupts it once the bastion is available
+			runAndInterrupt := func(cmd *cobra.Command) {
+				go func() {
+					defer GinkgoRecover()
+					defer func() {
+						signalChan <- os.Interrupt
+					}()
+
+					waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+					Eventually(func() bool {
+						return strings.Contains(logs.String(), bastionIP)
+					}).Should(BeTrue())
+				}()
+
+				Expect(cmd.RunE(cmd, nil)).To(Succeed())
+			}
+
+			It("should record the durations of the bastion lifecycle phases", func() {
+				recorder := &fakeMetricsRecorder{}
+
+				options := ssh.NewSSHOptions(streams)
+				options.MetricsRecorder = recorder
+				cmd := ssh.NewCmdSSH(factory, options)
+
+				runAndInterrupt(cmd)
+
+				Expect(recorder.Phases()).To(Equal([]ssh.BastionPhase{
+					ssh.BastionPhaseCreate,
+					ssh.BastionPhaseWaitCondition,
+					ssh.BastionPhaseAvailabilityCheck,
+				}))
+			})
+
+			It("should write the durations to the metrics file", func() {
+				metricsFile := filepath.Join(gardenTempDir, "metrics.json")
+
+				options := ssh.NewSSHOptions(streams)
+				cmd := ssh.NewCmdSSH(factory, options)
+				Expect(cmd.Flags().Set("metrics-file", metricsFile)).To(Succeed())
+
+				runAndInterrupt(cmd)
+
+				data, err := os.ReadFile(metricsFile)
+				Expect(err).NotTo(HaveOccurred())
+
+				metrics := struct {
+					Phases []ssh.PhaseDuration `json:"phases"`
+				}{}
+				Expect(json.Unmarshal(data, &metrics)).To(Succeed())
+				Expect(metrics.Phases).To(HaveLen(3))
+				Expect(metrics.Phases[0].Phase).To(Equal(ssh.BastionPhaseCreate))
+				Expect(metrics.Phases[1].Phase).To(Equal(ssh.BastionPhaseWaitCondition))
+				Expect(metrics.Phases[2].Phase).To(Equal(ssh.BastionPhaseAvailabilityCheck))
+
+				for _, phase := range metrics.Phases {
+					Expect(phase.DurationSeconds).To(BeNumerically(">=", 0))
+				}
+			})
+		})
+
 		It("should connect to a given node", func() {
 			options := ssh.NewSSHOptions(streams)
 			cmd := ssh.NewCmdSSH(factory, options)
@@ -459,488 +670,2041 @@ var _ = Describe("SSH Command", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
-		It("should connect to a given node that has not yet joined the cluster", func() {
+		It("should place the temporary files in the configured directory and remove them", func() {
+			tempDir := filepath.Join(GinkgoT().TempDir(), "gardenctl")
+
 			options := ssh.NewSSHOptions(streams)
 			cmd := ssh.NewCmdSSH(factory, options)
-
-			nodeName := "unjoined-node"
+			Expect(cmd.Flags().Set("temp-dir", tempDir)).To(Succeed())
 
 			// simulate an external controller processing the bastion and proving a successful status
 			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
 
-			// Retrieve the bastion object to get its UID
-			bastionKey := client.ObjectKey{Name: bastionName, Namespace: *testProject.Spec.Namespace}
+			var bastionKnownHostsDir string
 
-			// do not actually execute any commands
-			executedCommands := 0
 			ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
 				defer func() {
 					signalChan <- os.Interrupt
 				}()
-				executedCommands++
 
 				bastion := &operationsv1alpha1.Bastion{}
-				Expect(gardenClient.Get(ctx, bastionKey, bastion)).To(Succeed())
-
-				bastionUID := string(bastion.UID)
-				shootUID := string(testShoot.UID)
-
-				defaultBastionKnownHostsFile := filepath.Join(gardenTempDir, "cache", bastionUID, ".ssh", "known_hosts")
-				defaultNodeKnownHostsFile := filepath.Join(gardenHomeDir, "cache", shootUID, ".ssh", "known_hosts")
+				Expect(gardenClient.Get(ctx, client.ObjectKey{Name: bastionName, Namespace: *testProject.Spec.Namespace}, bastion)).To(Succeed())
 
-				Expect(command).To(Equal("ssh"))
-				Expect(args).To(Equal([]string{
-					"-oIdentitiesOnly=yes",
-					"-oStrictHostKeyChecking=ask",
-					fmt.Sprintf("-oUserKnownHostsFile='%s'", defaultNodeKnownHostsFile),
-					fmt.Sprintf("-i%s", nodePrivateKeyFile),
-					fmt.Sprintf(
-						"-oProxyCommand=ssh -W%%h:%%p -oStrictHostKeyChecking=ask -oIdentitiesOnly=yes '-i%s' '-oUserKnownHostsFile='\"'\"'%s'\"'\"'' '%s@%s' '-p22'",
-						options.SSHPrivateKeyFile,
-						defaultBastionKnownHostsFile,
-						ssh.SSHBastionUsername,
-						bastionIP,
-					),
-					fmt.Sprintf("%s@%s", options.User, nodeName),
-				}))
+				bastionKnownHostsDir = filepath.Join(tempDir, "cache", string(bastion.UID))
+				Expect(filepath.Join(bastionKnownHostsDir, ".ssh")).To(BeADirectory())
+				Expect(filepath.Dir(options.SSHPrivateKeyFile.String())).To(Equal(tempDir))
+				Expect(filepath.Dir(nodePrivateKeyFile)).To(Equal(tempDir))
+				Expect(args).To(ContainElement(fmt.Sprintf("-i%s", nodePrivateKeyFile)))
 
 				return nil
 			})
 
-			// let the magic happen
-			Expect(cmd.RunE(cmd, []string{nodeName})).To(Succeed())
-
-			// assert output
-			Expect(executedCommands).To(Equal(1))
-			Expect(logs.String()).To(ContainSubstring(bastionName))
-			Expect(logs.String()).To(ContainSubstring(bastionHostname))
-			Expect(out.String()).To(ContainSubstring(bastionIP))
-			Expect(logs.String()).To(ContainSubstring("node did not yet join the cluster"))
+			Expect(cmd.RunE(cmd, []string{testNode.Name})).To(Succeed())
 
-			// assert that the bastion has been cleaned up
-			bastion := &operationsv1alpha1.Bastion{}
-			Expect(gardenClient.Get(ctx, bastionKey, bastion)).NotTo(Succeed())
+			// assert that the temporary files have been cleaned up
+			Expect(bastionKnownHostsDir).NotTo(BeEmpty())
+			Expect(bastionKnownHostsDir).NotTo(BeAnExistingFile())
+			Expect(options.SSHPrivateKeyFile.String()).NotTo(BeAnExistingFile())
+			Expect(options.SSHPublicKeyFile.String()).NotTo(BeAnExistingFile())
+			Expect(nodePrivateKeyFile).NotTo(BeAnExistingFile())
+		})
 
-			// assert that no temporary SSH keypair remained on disk
-			_, err := os.Stat(options.SSHPublicKeyFile.String())
-			Expect(err).To(HaveOccurred())
+		It("should use the given SSH agent for the availability check and the node", func() {
+			socketDir, err := os.MkdirTemp("", "agent")
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(os.RemoveAll, socketDir)
 
-			_, err = os.Stat(options.SSHPrivateKeyFile.String())
-			Expect(err).To(HaveOccurred())
-		})
+			socket := filepath.Join(socketDir, "agent.sock")
+			listener, err := net.Listen("unix", socket)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(listener.Close)
 
-		It("should keep the bastion alive", func() {
 			options := ssh.NewSSHOptions(streams)
-			options.KeepBastion = true // we need to assert its annotations later
-
 			cmd := ssh.NewCmdSSH(factory, options)
+			Expect(cmd.Flags().Set("identity-agent", socket)).To(Succeed())
 
-			ssh.SetKeepAliveInterval(50 * time.Millisecond)
+			var checkedIdentityAgent string
 
-			key := types.NamespacedName{Name: bastionName, Namespace: *testProject.Spec.Namespace}
+			ssh.SetBastionAvailabilityChecker(func(hostname string, port string, privateKey []byte, identityAgent string, hostKeyCallback cryptossh.HostKeyCallback, httpsProxy string) error {
+				checkedIdentityAgent = identityAgent
+				return nil
+			})
 
-			go func() {
-				GinkgoRecover()
+			// simulate an external controller processing the bastion and proving a successful status
+			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
 
+			ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
 				defer func() {
 					signalChan <- os.Interrupt
 				}()
 
-				// simulate an external controller processing the bastion and proving a successful status
-				waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+				Expect(args).To(ContainElement(fmt.Sprintf("-oIdentityAgent='%s'", socket)))
 
-				Eventually(func() bool {
-					bastion := &operationsv1alpha1.Bastion{}
-					if err := gardenClient.Get(ctx, key, bastion); apierrors.IsNotFound(err) {
-						return false
-					}
+				return nil
+			})
 
-					return bastion.Annotations != nil && bastion.Annotations[corev1beta1constants.GardenerOperation] == corev1beta1constants.GardenerOperationKeepalive
-				}).Should(BeTrue())
+			Expect(cmd.RunE(cmd, []string{testNode.Name})).To(Succeed())
+			Expect(checkedIdentityAgent).To(Equal(socket))
+		})
 
-				// delete the GardenerOperation annotation
-				bastion := &operationsv1alpha1.Bastion{}
-				Expect(gardenClient.Get(ctx, key, bastion)).To(Succeed())
-				delete(bastion.Annotations, corev1beta1constants.GardenerOperation)
-				patch := client.MergeFrom(bastion.DeepCopy())
-				Expect(gardenClient.Patch(ctx, bastion, patch)).To(Succeed())
+		Context("reconnect", func() {
+			BeforeEach(func() {
+				ssh.SetReconnectDelay(0)
+				DeferCleanup(ssh.SetReconnectDelay, 5*time.Second)
 
-				// expect that the keepalive annotation will be added again
-				Eventually(func() bool {
-					bastion := &operationsv1alpha1.Bastion{}
-					if err := gardenClient.Get(ctx, key, bastion); apierrors.IsNotFound(err) {
-						return false
+				// simulate an external controller processing the bastion and proving a successful status
+				go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+			})
+
+			It("should reconnect to the node if the connection dropped", func() {
+				options := ssh.NewSSHOptions(streams)
+				options.Reconnect = true
+				cmd := ssh.NewCmdSSH(factory, options)
+
+				executedCommands := 0
+				ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+					executedCommands++
+					if executedCommands < 3 {
+						return exitCodeError(255)
 					}
 
-					return bastion.Annotations != nil && bastion.Annotations[corev1beta1constants.GardenerOperation] == corev1beta1constants.GardenerOperationKeepalive
-				}).Should(BeTrue())
+					signalChan <- os.Interrupt
 
-				// wait until connect information is printed to be sure that the command ran through and is just waiting for the user to interrupt
-				Eventually(func() bool {
-					return strings.Contains(logs.String(), bastionIP)
-				}).Should(BeTrue())
-			}()
+					return nil
+				})
 
-			// let the magic happen
-			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+				Expect(cmd.RunE(cmd, []string{testNode.Name})).To(Succeed())
 
-			// Double check that the annotation was really set
-			bastion := &operationsv1alpha1.Bastion{}
-			Expect(gardenClient.Get(ctx, key, bastion)).To(Succeed())
-			Expect(bastion.Annotations).To(HaveKeyWithValue(corev1beta1constants.GardenerOperation, corev1beta1constants.GardenerOperationKeepalive))
-		})
+				Expect(executedCommands).To(Equal(3))
+				Expect(logs.String()).To(ContainSubstring("SSH connection dropped, reconnecting"))
 
-		It("should stop keepalive when bastion is deleted ", func() {
-			options := ssh.NewSSHOptions(streams)
-			options.KeepBastion = true // we need to assert its annotations later
+				// assert that the bastion has been cleaned up
+				bastionKey := client.ObjectKey{Name: bastionName, Namespace: *testProject.Spec.Namespace}
+				Expect(gardenClient.Get(ctx, bastionKey, &operationsv1alpha1.Bastion{})).NotTo(Succeed())
+			})
 
-			cmd := ssh.NewCmdSSH(factory, options)
+			It("should give up after the maximum number of attempts", func() {
+				options := ssh.NewSSHOptions(streams)
+				options.Reconnect = true
+				options.ReconnectMax = 2
+				cmd := ssh.NewCmdSSH(factory, options)
 
-			// simulate an external controller processing the bastion and proving a successful status
-			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+				executedCommands := 0
+				ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+					executedCommands++
+					return exitCodeError(255)
+				})
 
-			// end the test after a couple of seconds (enough seconds for the keep-alive
-			// goroutine to do its thing)
-			ssh.SetKeepAliveInterval(100 * time.Millisecond)
-			signalChan := make(chan os.Signal, 1)
-			ssh.SetCreateSignalChannel(func() chan os.Signal {
-				return signalChan
+				Expect(cmd.RunE(cmd, []string{testNode.Name})).To(MatchError(exitCodeError(255)))
+				Expect(executedCommands).To(Equal(3))
 			})
 
-			// Once the waitForSignal function is called we delete the bastion
-			ssh.SetWaitForSignal(func(ctx context.Context, o *ssh.SSHOptions, signalChan <-chan struct{}) {
-				By("deleting bastion")
-				bastion := &operationsv1alpha1.Bastion{}
-				key := types.NamespacedName{Name: bastionName, Namespace: *testProject.Spec.Namespace}
-				Expect(gardenClient.Get(ctx, key, bastion)).To(Succeed())
+			It("should not reconnect if the remote command failed", func() {
+				options := ssh.NewSSHOptions(streams)
+				options.Reconnect = true
+				cmd := ssh.NewCmdSSH(factory, options)
 
-				Expect(gardenClient.Delete(ctx, bastion)).To(Succeed())
+				executedCommands := 0
+				ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+					executedCommands++
+					return exitCodeError(1)
+				})
 
-				<-signalChan
+				Expect(cmd.RunE(cmd, []string{testNode.Name})).To(MatchError(exitCodeError(1)))
+				Expect(executedCommands).To(Equal(1))
 			})
+		})
 
-			// let the magic happen
-			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+		Context("with --node-agent-key", func() {
+			BeforeEach(func() {
+				// the node keypair is not managed by the shoot
+				Expect(gardenClient.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s.ssh-keypair", testShoot.Name),
+					Namespace: *testProject.Spec.Namespace,
+				}})).To(Succeed())
+			})
 
-			Expect(logs.String()).To(ContainSubstring("Can't keep bastion alive. Bastion is already gone."))
-		})
+			It("should access the node with an identity of the SSH agent", func() {
+				_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+				Expect(err).NotTo(HaveOccurred())
 
-		It("should skip the availability check", func() {
-			options := ssh.NewSSHOptions(streams)
-			options.SkipAvailabilityCheck = true
+				keyring := agent.NewKeyring()
+				Expect(keyring.Add(agent.AddedKey{PrivateKey: privateKey})).To(Succeed())
+				serveSSHAgent(keyring)
 
-			cmd := ssh.NewCmdSSH(factory, options)
+				options := ssh.NewSSHOptions(streams)
+				cmd := ssh.NewCmdSSH(factory, options)
+				Expect(cmd.Flags().Set("node-agent-key", "true")).To(Succeed())
 
-			ssh.SetBastionAvailabilityChecker(func(hostname string, port string, privateKey []byte, hostKeyCallback cryptossh.HostKeyCallback) error {
-				err := errors.New("this function should not be executed as of SkipAvailabilityCheck = true")
-				Fail(err.Error())
-				return err
-			})
+				// simulate an external controller processing the bastion and proving a successful status
+				go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
 
-			// simulate an external controller processing the bastion and proving a successful status
-			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+				// do not actually execute any commands
+				var nodeArgs []string
+				ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+					defer func() {
+						signalChan <- os.Interrupt
+					}()
 
-			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+					for _, arg := range args {
+						if strings.HasPrefix(arg, "-oProxyCommand=") {
+							break
+						}
 
-			Expect(logs.String()).To(ContainSubstring("Bastion is ready, skipping availability check"))
-		})
+						nodeArgs = append(nodeArgs, arg)
+					}
 
-		It("should not keep alive the bastion", func() {
-			options := ssh.NewSSHOptions(streams)
-			options.NoKeepalive = true
-			options.KeepBastion = true
-			options.Interactive = false
+					return nil
+				})
 
-			cmd := ssh.NewCmdSSH(factory, options)
+				Expect(cmd.RunE(cmd, []string{testNode.Name})).To(Succeed())
 
-			ssh.SetWaitForSignal(func(ctx context.Context, o *ssh.SSHOptions, signalChan <-chan struct{}) {
-				Fail("this function should not be executed as of NoKeepalive = true")
-			})
-			ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
-				err := errors.New("this function should not be executed as of NoKeepalive = true")
-				Fail(err.Error())
-				return err
+				Expect(options.NodeAgentKeyOnly).To(BeTrue())
+				Expect(nodePrivateKeyFiles).To(BeEmpty())
+				Expect(nodeArgs).To(Equal([]string{
+					"-oStrictHostKeyChecking=ask",
+					fmt.Sprintf("-oUserKnownHostsFile='%s'", options.NodeUserKnownHostsFiles[0]),
+				}))
 			})
 
-			// simulate an external controller processing the bastion and proving a successful status
-			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+			It("should fail if the SSH agent holds no identity", func() {
+				serveSSHAgent(agent.NewKeyring())
 
-			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+				options := ssh.NewSSHOptions(streams)
+				cmd := ssh.NewCmdSSH(factory, options)
+				Expect(cmd.Flags().Set("node-agent-key", "true")).To(Succeed())
 
-			Expect(logs.String()).To(ContainSubstring("Bastion host became available."))
+				Expect(cmd.RunE(cmd, []string{testNode.Name})).To(MatchError("no SSH keypair is available for the shoot nodes and the SSH agent holds no identity to be used instead"))
+			})
 		})
 
-		It("should output as json", func() {
+		It("should offer all node private keys when connecting to a given node", func() {
 			options := ssh.NewSSHOptions(streams)
-			options.NoKeepalive = true
-			options.KeepBastion = true
-			options.Interactive = false
-
-			options.Output = "json"
-
 			cmd := ssh.NewCmdSSH(factory, options)
 
-			ssh.SetWaitForSignal(func(ctx context.Context, o *ssh.SSHOptions, signalChan <-chan struct{}) {
-				Fail("this function should not be executed as of NoKeepalive = true")
-			})
-			ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
-				err := errors.New("this function should not be executed as of NoKeepalive = true")
-				Fail(err.Error())
-				return err
-			})
+			// simulate a recent rotation of the shoot ssh keypair
+			oldKeypair := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s.ssh-keypair.old", testShoot.Name),
+					Namespace: *testProject.Spec.Namespace,
+				},
+				Data: map[string][]byte{
+					"data": []byte("not-used"),
+				},
+			}
+			Expect(gardenClient.Create(ctx, oldKeypair)).To(Succeed())
 
 			// simulate an external controller processing the bastion and proving a successful status
 			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
 
-			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+			// do not actually execute any commands
+			var identities []string
+			ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+				defer func() {
+					signalChan <- os.Interrupt
+				}()
 
-			var info ssh.ConnectInformation
-			Expect(json.Unmarshal([]byte(out.String()), &info)).To(Succeed())
-			Expect(info.Bastion.Name).To(Equal(bastionName))
-			Expect(info.Bastion.PreferredAddress).To(Equal("0.0.0.0"))
-			Expect(info.Bastion.SSHPrivateKeyFile).To(Equal(options.SSHPrivateKeyFile))
-			Expect(info.Bastion.SSHPublicKeyFile).To(Equal(options.SSHPublicKeyFile))
-			Expect(info.Nodes).To(ConsistOf([]ssh.Node{
-				{
-					Name:   pendingMachine.Labels[machinev1alpha1.NodeLabelKey],
-					Status: "Unknown",
-				},
-				{
-					Name:   testNode.Name,
-					Status: "Not Ready",
-					Address: ssh.Address{
-						Hostname: nodeHostname,
-					},
-				},
-			}))
-			Expect(info.NodePrivateKeyFiles).NotTo(BeEmpty())
-		})
+				for _, arg := range args {
+					if strings.HasPrefix(arg, "-i") {
+						identities = append(identities, arg)
+					}
+				}
 
-		It("should return an error when SSHAccess is disabled", func() {
-			options := ssh.NewSSHOptions(streams)
-			cmd := ssh.NewCmdSSH(factory, options)
+				return nil
+			})
 
-			testShootBase := testShoot.DeepCopy()
-			testShoot.Spec.Provider.WorkersSettings.SSHAccess.Enabled = false
-			Expect(gardenClient.Patch(ctx, testShoot, client.MergeFrom(testShootBase))).To(Succeed())
+			// let the magic happen
+			Expect(cmd.RunE(cmd, []string{testNode.Name})).To(Succeed())
 
-			Expect(cmd.RunE(cmd, nil)).To(MatchError("node SSH access disabled, SSH not allowed"))
+			// assert that one identity is offered per node key
+			Expect(nodePrivateKeyFiles).To(HaveLen(2))
+			Expect(identities).To(Equal([]string{
+				fmt.Sprintf("-i%s", nodePrivateKeyFiles[0]),
+				fmt.Sprintf("-i%s", nodePrivateKeyFiles[1]),
+			}))
 		})
 
-		It("should use custom known hosts files when provided", func() {
+		It("should connect to the node address of the preferred type", func() {
 			options := ssh.NewSSHOptions(streams)
 			cmd := ssh.NewCmdSSH(factory, options)
+			Expect(cmd.Flags().Set("node-address-preference", "ExternalIP,InternalIP")).To(Succeed())
 
-			// Set custom known hosts files
-			options.BastionUserKnownHostsFiles = []string{"/custom/bastion/known_hosts"}
-			options.NodeUserKnownHostsFiles = []string{"/custom/node/known_hosts"}
+			testNode.Status.Addresses = []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "10.250.0.5"},
+				{Type: corev1.NodeExternalIP, Address: "203.0.113.5"},
+			}
+			Expect(shootClient.Status().Update(ctx, testNode)).To(Succeed())
 
-			// Simulate the bastion being ready
+			// simulate an external controller processing the bastion and proving a successful status
 			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
 
 			// do not actually execute any commands
-			executedCommands := 0
+			var destination string
 			ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
 				defer func() {
 					signalChan <- os.Interrupt
 				}()
-				executedCommands++
 
-				Expect(command).To(Equal("ssh"))
-				Expect(args).To(Equal([]string{
-					"-oIdentitiesOnly=yes",
-					"-oStrictHostKeyChecking=ask",
-					"-oUserKnownHostsFile='/custom/node/known_hosts'",
-					fmt.Sprintf("-i%s", nodePrivateKeyFile),
-					fmt.Sprintf(
-						"-oProxyCommand=ssh -W%%h:%%p -oStrictHostKeyChecking=ask -oIdentitiesOnly=yes '-i%s' '-oUserKnownHostsFile='\"'\"'/custom/bastion/known_hosts'\"'\"'' '%s@%s' '-p22'",
-						options.SSHPrivateKeyFile,
-						ssh.SSHBastionUsername,
-						bastionIP,
-					),
-					fmt.Sprintf("%s@%s", options.User, nodeHostname),
-				}))
+				destination = args[len(args)-1]
 
 				return nil
 			})
 
-			// Execute the command
+			// let the magic happen
 			Expect(cmd.RunE(cmd, []string{testNode.Name})).To(Succeed())
 
-			// assert output
-			Expect(executedCommands).To(Equal(1))
-			Expect(logs.String()).To(ContainSubstring(bastionName))
-			Expect(logs.String()).To(ContainSubstring(bastionHostname))
-			Expect(out.String()).To(ContainSubstring(bastionIP))
+			Expect(destination).To(Equal(fmt.Sprintf("%s@%s", options.User, "203.0.113.5")))
+		})
 
-			// assert that the bastion has been cleaned up
-			key := types.NamespacedName{Name: bastionName, Namespace: *testProject.Spec.Namespace}
-			bastion := &operationsv1alpha1.Bastion{}
+		Context("with the node readiness check", func() {
+			connect := func(options *ssh.SSHOptions) {
+				cmd := ssh.NewCmdSSH(factory, options)
+				Expect(cmd.Flags().Set("node-readiness-check", "true")).To(Succeed())
 
-			Expect(gardenClient.Get(ctx, key, bastion)).NotTo(Succeed())
+				// simulate an external controller processing the bastion and proving a successful status
+				go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
 
-			// assert that no temporary SSH keypair remained on disk
-			_, err := os.Stat(options.SSHPublicKeyFile.String())
-			Expect(err).To(HaveOccurred())
+				// do not actually execute any commands
+				ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+					signalChan <- os.Interrupt
 
-			_, err = os.Stat(options.SSHPrivateKeyFile.String())
-			Expect(err).To(HaveOccurred())
-		})
-	})
+					return nil
+				})
 
-	Describe("ValidArgsFunction", func() {
-		var (
-			manager *targetmocks.MockManager
-			client  *gardenclientmocks.MockClient
-		)
+				Expect(cmd.RunE(cmd, []string{testNode.Name})).To(Succeed())
+				klog.Flush()
+			}
 
-		BeforeEach(func() {
-			manager = targetmocks.NewMockManager(ctrl)
-			client = gardenclientmocks.NewMockClient(ctrl)
+			It("should not warn if the node is ready", func() {
+				testNode.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}
+				Expect(shootClient.Status().Update(ctx, testNode)).To(Succeed())
 
-			factory.ManagerImpl = manager
-			manager.EXPECT().CurrentTarget().Return(currentTarget, nil)
-			manager.EXPECT().GardenClient(currentTarget.GardenName()).Return(client, nil)
+				connect(ssh.NewSSHOptions(streams))
 
-			client.EXPECT().FindShoot(ctx, currentTarget.AsListOption()).Return(testShoot, nil)
-		})
+				Expect(logs.String()).NotTo(ContainSubstring("Node is not ready"))
+			})
 
-		It("should return all names based on machine objects", func() {
-			manager.EXPECT().SeedClient(ctx, gomock.Any()).Return(seedClient, nil)
+			It("should warn if the node is not ready", func() {
+				testNode.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}}
+				Expect(shootClient.Status().Update(ctx, testNode)).To(Succeed())
 
-			options := ssh.NewSSHOptions(streams)
-			cmd := ssh.NewCmdSSH(factory, options)
+				connect(ssh.NewSSHOptions(streams))
 
-			suggestions, directive := cmd.ValidArgsFunction(cmd, nil, "")
-			Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
-			Expect(suggestions).To(HaveLen(2))
-			Expect(suggestions).To(Equal([]string{"node1", "monitoring1"}))
-		})
+				Expect(logs.String()).To(ContainSubstring("Node is not ready, connecting anyways"))
+			})
 
-		It("should return all names based on node objects", func() {
-			errForbidden := &apierrors.StatusError{ErrStatus: metav1.Status{Reason: metav1.StatusReasonForbidden}}
-			manager.EXPECT().SeedClient(ctx, gomock.Any()).Return(nil, errForbidden)
-			manager.EXPECT().ShootClient(ctx, currentTarget).Return(shootClient, nil)
+			It("should fail if the node is not ready and --require-ready is set", func() {
+				options := ssh.NewSSHOptions(streams)
+				cmd := ssh.NewCmdSSH(factory, options)
+				Expect(cmd.Flags().Set("require-ready", "true")).To(Succeed())
 
-			options := ssh.NewSSHOptions(streams)
-			cmd := ssh.NewCmdSSH(factory, options)
+				Expect(cmd.RunE(cmd, []string{testNode.Name})).To(MatchError(`node "node1" is not ready`))
 
-			suggestions, directive := cmd.ValidArgsFunction(cmd, nil, "")
-			Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
-			Expect(suggestions).To(HaveLen(1))
-			Expect(suggestions).To(Equal([]string{"node1"}))
-		})
+				// assert that no bastion has been created
+				bastions := &operationsv1alpha1.BastionList{}
+				Expect(gardenClient.List(ctx, bastions)).To(Succeed())
+				Expect(bastions.Items).To(BeEmpty())
+			})
 
-		It("should find nodes based on their prefix from machine objects", func() {
-			manager.EXPECT().SeedClient(ctx, gomock.Any()).Return(seedClient, nil)
+			It("should print the error as json if the node is not ready and the output is json", func() {
+				options := ssh.NewSSHOptions(streams)
+				cmd := ssh.NewCmdSSH(factory, options)
+				Expect(cmd.Flags().Set("require-ready", "true")).To(Succeed())
+				Expect(cmd.Flags().Set("interactive", "false")).To(Succeed())
+				Expect(cmd.Flags().Set("output", "json")).To(Succeed())
 
-			options := ssh.NewSSHOptions(streams)
-			cmd := ssh.NewCmdSSH(factory, options)
+				Expect(cmd.RunE(cmd, []string{testNode.Name})).To(MatchError(`node "node1" is not ready`))
 
-			suggestions, directive := cmd.ValidArgsFunction(cmd, nil, "mon")
-			Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
-			Expect(suggestions).To(HaveLen(1))
-			Expect(suggestions).To(Equal([]string{"monitoring1"}))
+				var result map[string]interface{}
+				Expect(json.Unmarshal([]byte(out.String()), &result)).To(Succeed())
+				Expect(result).To(Equal(map[string]interface{}{"error": `node "node1" is not ready`}))
+			})
 		})
-	})
-})
 
-var _ = Describe("SSH Options", func() {
-	var (
-		streams util.IOStreams
-		o       *ssh.SSHOptions
-	)
+		It("should connect to the node with the given provider ID", func() {
+			options := ssh.NewSSHOptions(streams)
+			cmd := ssh.NewCmdSSH(factory, options)
+			Expect(cmd.Flags().Set("provider-id", "aws:///eu-west-1a/i-0123456789abcdef0")).To(Succeed())
 
-	BeforeEach(func() {
-		streams, _, _, _ = util.NewTestIOStreams()
+			testNode.Spec.ProviderID = "aws:///eu-west-1a/i-0123456789abcdef0"
+			Expect(shootClient.Update(ctx, testNode)).To(Succeed())
 
-		o = ssh.NewSSHOptions(streams)
-	})
+			// simulate an external controller processing the bastion and proving a successful status
+			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
 
-	Describe("Complete", func() {
-		var factory *internalfake.Factory
-		BeforeEach(func() {
-			factory = internalfake.NewFakeFactory(nil, nil, nil, nil)
-		})
+			// do not actually execute any commands
+			var destination string
+			ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+				defer func() {
+					signalChan <- os.Interrupt
+				}()
 
-		AfterEach(func() {
-			Expect(os.Remove(o.SSHPublicKeyFile.String())).To(Succeed())
-			Expect(os.Remove(o.SSHPrivateKeyFile.String())).To(Succeed())
-		})
+				destination = args[len(args)-1]
 
-		It("should complete node name", func() {
-			Expect(o.Complete(factory, nil, []string{"my-node"})).To(Succeed())
+				return nil
+			})
 
-			Expect(o.NodeName).To(Equal("my-node"))
+			// let the magic happen
+			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+			Expect(options.NodeName).To(Equal(testNode.Name))
+			Expect(destination).To(Equal(fmt.Sprintf("%s@%s", options.User, nodeHostname)))
 		})
 
-		It("should complete public and private key", func() {
-			Expect(o.Complete(factory, nil, nil)).To(Succeed())
+		It("should connect to the node of the given pod", func() {
+			options := ssh.NewSSHOptions(streams)
+			cmd := ssh.NewCmdSSH(factory, options)
+			Expect(cmd.Flags().Set("pod", "kube-system/misbehaving-pod")).To(Succeed())
 
-			Expect(o.SSHPublicKeyFile).NotTo(BeEmpty())
-			Expect(o.SSHPrivateKeyFile).NotTo(BeEmpty())
-			Expect(o.GeneratedSSHKeys).To(BeTrue())
-		})
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "misbehaving-pod", Namespace: "kube-system"},
+				Spec:       corev1.PodSpec{NodeName: testNode.Name},
+			}
+			Expect(shootClient.Create(ctx, pod)).To(Succeed())
 
-		It("should complete bastion name", func() {
-			Expect(o.Complete(factory, nil, nil)).To(Succeed())
+			// simulate an external controller processing the bastion and proving a successful status
+			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
 
-			Expect(o.BastionName).To(Not(BeEmpty()))
-		})
+			// do not actually execute any commands
+			var destination string
+			ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+				defer func() {
+					signalChan <- os.Interrupt
+				}()
 
-		It("should not touch bastion name if set", func() {
-			o.BastionName = "cli-xxxxxx"
+				destination = args[len(args)-1]
 
-			Expect(o.Complete(factory, nil, nil)).To(Succeed())
+				return nil
+			})
 
-			Expect(o.BastionName).To(Equal("cli-xxxxxx"))
-		})
+			// let the magic happen
+			Expect(cmd.RunE(cmd, nil)).To(Succeed())
 
-		It("should switch to non-interactive mode if no node name given", func() {
-			o.Interactive = true
+			Expect(options.NodeName).To(Equal(testNode.Name))
+			Expect(destination).To(Equal(fmt.Sprintf("%s@%s", options.User, nodeHostname)))
+		})
 
-			Expect(o.Complete(factory, nil, []string{""})).To(Succeed())
+		It("should fail if the pod does not exist", func() {
+			options := ssh.NewSSHOptions(streams)
+			cmd := ssh.NewCmdSSH(factory, options)
+			Expect(cmd.Flags().Set("pod", "kube-system/unknown")).To(Succeed())
 
-			Expect(o.Interactive).To(BeFalse())
+			Expect(cmd.RunE(cmd, nil)).To(MatchError(ContainSubstring(`failed to get pod "kube-system/unknown"`)))
 		})
-	})
 
-	Describe("Validate", func() {
-		var publicSSHKeyFile ssh.PublicKeyFile
+		It("should connect with the command rendered from the exec template", func() {
+			options := ssh.NewSSHOptions(streams)
+			cmd := ssh.NewCmdSSH(factory, options)
+			Expect(cmd.Flags().Set("exec-template", "mosh\n--ssh=ssh -oProxyCommand={{ printf \"%q\" .ProxyCommand }}\n{{ .User }}@{{ .NodeHostname }}\n")).To(Succeed())
 
-		BeforeEach(func() {
-			tmpFile, err := os.CreateTemp("", "")
-			Expect(err).NotTo(HaveOccurred())
-			defer tmpFile.Close()
+			// simulate an external controller processing the bastion and proving a successful status
+			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
 
-			// write dummy SSH public key
-			_, err = io.WriteString(tmpFile, "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDouNkxsNuApuKVIfgL6Yz3Ep+DqX84Yde9DArwLBSWgLnl/pH9AbbcDcAmdB2CPVXAATo4qxK7xprvyyZp52SQRCcAZpAy4D6gAWwAG3OfzrRbxRiB5pQDaaWATSzNbLtoy0ecVwFeTJe2w71q+wxbI7tfxbvo9XbXIN4I0cQy2KLICzkYkQmygGnHztv1Mvi338+sgcG7Gwq2tdSyggDaAggwDIuT39S4/L7QpR27tWH79J4Ls8tTHud2eRbkOcF98vXlQAIzb6w8iHBXylOjMM/oODwoA7V4mtRL9o13AoocvZSsD1UvfOjGxDHuLrCfFXN+/rEw0hEiYo0cnj7F")
-			Expect(err).NotTo(HaveOccurred())
+			// do not actually execute any commands
+			var (
+				executedCommand string
+				executedArgs    []string
+			)
+			ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+				defer func() {
+					signalChan <- os.Interrupt
+				}()
 
-			publicSSHKeyFile = ssh.PublicKeyFile(tmpFile.Name())
+				executedCommand = command
+				executedArgs = args
 
-			o.CIDRs = []string{"8.8.8.8/32"}
-			o.SSHPublicKeyFile = publicSSHKeyFile
-		})
+				return nil
+			})
 
-		AfterEach(func() {
-			Expect(os.Remove(publicSSHKeyFile.String())).To(Succeed())
-		})
+			// let the magic happen
+			Expect(cmd.RunE(cmd, []string{testNode.Name})).To(Succeed())
 
-		It("should validate", func() {
-			Expect(o.Validate()).To(Succeed())
+			Expect(executedCommand).To(Equal("mosh"))
+			Expect(executedArgs).To(HaveLen(2))
+			Expect(executedArgs[0]).To(HavePrefix(`--ssh=ssh -oProxyCommand="ssh -W%h:%p`))
+			Expect(executedArgs[1]).To(Equal(fmt.Sprintf("%s@%s", options.User, nodeHostname)))
+			Expect(out.String()).To(ContainSubstring("'mosh' '--ssh=ssh -oProxyCommand="))
 		})
 
-		It("should require a non-zero wait time", func() {
-			o.WaitTimeout = 0
+		It("should fail if no node has the given provider ID", func() {
+			options := ssh.NewSSHOptions(streams)
+			cmd := ssh.NewCmdSSH(factory, options)
+			Expect(cmd.Flags().Set("provider-id", "aws:///eu-west-1a/i-unknown")).To(Succeed())
 
-			Expect(o.Validate()).NotTo(Succeed())
+			Expect(cmd.RunE(cmd, nil)).To(MatchError(ContainSubstring(`no node found with provider ID "aws:///eu-west-1a/i-unknown"`)))
 		})
 
-		Context("no-keepalive", func() {
-			BeforeEach(func() {
-				o.NoKeepalive = true
-
+		It("should connect directly to a given node without a bastion", func() {
+			options := ssh.NewSSHOptions(streams)
+			cmd := ssh.NewCmdSSH(factory, options)
+			Expect(cmd.Flags().Set("no-bastion", "true")).To(Succeed())
+
+			ssh.SetBastionNameProvider(func() (string, error) {
+				err := errors.New("no bastion name must be generated with --no-bastion")
+				Fail(err.Error())
+				return "", err
+			})
+
+			// do not actually execute any commands
+			var (
+				executedCommands int
+				sshArgs          []string
+			)
+			ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+				executedCommands++
+				sshArgs = args
+
+				return nil
+			})
+
+			// let the magic happen
+			Expect(cmd.RunE(cmd, []string{testNode.Name})).To(Succeed())
+
+			Expect(executedCommands).To(Equal(1))
+			Expect(sshArgs).To(HaveLen(5))
+			Expect(sshArgs).NotTo(ContainElement(HavePrefix("-oProxyCommand")))
+			Expect(sshArgs[len(sshArgs)-1]).To(Equal(fmt.Sprintf("%s@%s", options.User, nodeHostname)))
+
+			// assert that no bastion has been created
+			bastions := &operationsv1alpha1.BastionList{}
+			Expect(gardenClient.List(ctx, bastions)).To(Succeed())
+			Expect(bastions.Items).To(BeEmpty())
+
+			// assert that the node private keys have been cleaned up
+			for _, file := range nodePrivateKeyFiles {
+				_, err := os.Stat(file)
+				Expect(err).To(HaveOccurred())
+			}
+		})
+
+		It("should print the private key paths when connecting to a given node", func() {
+			options := ssh.NewSSHOptions(streams)
+			cmd := ssh.NewCmdSSH(factory, options)
+			Expect(cmd.Flags().Set("print-private-key-path", "true")).To(Succeed())
+
+			// simulate an external controller processing the bastion and proving a successful status
+			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+			// do not actually execute any commands
+			ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+				defer func() {
+					signalChan <- os.Interrupt
+				}()
+
+				return nil
+			})
+
+			// let the magic happen
+			Expect(cmd.RunE(cmd, []string{testNode.Name})).To(Succeed())
+
+			// assert output
+			Expect(errOut.String()).To(ContainSubstring(fmt.Sprintf("> Bastion private key file: %s\n", options.SSHPrivateKeyFile)))
+			Expect(errOut.String()).To(ContainSubstring(fmt.Sprintf("> Node private key file: %s\n", nodePrivateKeyFile)))
+		})
+
+		It("should connect to a given node that has not yet joined the cluster", func() {
+			options := ssh.NewSSHOptions(streams)
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			nodeName := "unjoined-node"
+
+			// simulate an external controller processing the bastion and proving a successful status
+			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+			// Retrieve the bastion object to get its UID
+			bastionKey := client.ObjectKey{Name: bastionName, Namespace: *testProject.Spec.Namespace}
+
+			// do not actually execute any commands
+			executedCommands := 0
+			ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+				defer func() {
+					signalChan <- os.Interrupt
+				}()
+				executedCommands++
+
+				bastion := &operationsv1alpha1.Bastion{}
+				Expect(gardenClient.Get(ctx, bastionKey, bastion)).To(Succeed())
+
+				bastionUID := string(bastion.UID)
+				shootUID := string(testShoot.UID)
+
+				defaultBastionKnownHostsFile := filepath.Join(gardenTempDir, "cache", bastionUID, ".ssh", "known_hosts")
+				defaultNodeKnownHostsFile := filepath.Join(gardenHomeDir, "cache", shootUID, ".ssh", "known_hosts")
+
+				Expect(command).To(Equal("ssh"))
+				Expect(args).To(Equal([]string{
+					"-oIdentitiesOnly=yes",
+					"-oStrictHostKeyChecking=ask",
+					fmt.Sprintf("-oUserKnownHostsFile='%s'", defaultNodeKnownHostsFile),
+					fmt.Sprintf("-i%s", nodePrivateKeyFile),
+					fmt.Sprintf(
+						"-oProxyCommand=ssh -W%%h:%%p -oStrictHostKeyChecking=ask -oIdentitiesOnly=yes '-i%s' '-oUserKnownHostsFile='\"'\"'%s'\"'\"'' '%s@%s' '-p22'",
+						options.SSHPrivateKeyFile,
+						defaultBastionKnownHostsFile,
+						ssh.SSHBastionUsername,
+						bastionIP,
+					),
+					fmt.Sprintf("%s@%s", options.User, nodeName),
+				}))
+
+				return nil
+			})
+
+			// let the magic happen
+			Expect(cmd.RunE(cmd, []string{nodeName})).To(Succeed())
+
+			// assert output
+			Expect(executedCommands).To(Equal(1))
+			Expect(logs.String()).To(ContainSubstring(bastionName))
+			Expect(logs.String()).To(ContainSubstring(bastionHostname))
+			Expect(out.String()).To(ContainSubstring(bastionIP))
+			Expect(logs.String()).To(ContainSubstring("node did not yet join the cluster"))
+
+			// assert that the bastion has been cleaned up
+			bastion := &operationsv1alpha1.Bastion{}
+			Expect(gardenClient.Get(ctx, bastionKey, bastion)).NotTo(Succeed())
+
+			// assert that no temporary SSH keypair remained on disk
+			_, err := os.Stat(options.SSHPublicKeyFile.String())
+			Expect(err).To(HaveOccurred())
+
+			_, err = os.Stat(options.SSHPrivateKeyFile.String())
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should keep the bastion alive", func() {
+			options := ssh.NewSSHOptions(streams)
+			options.KeepBastion = true // we need to assert its annotations later
+
+			cmd := ssh.NewCmdSSH(factory, options)
+			Expect(cmd.Flags().Set("label-bastion", "cost-center=12345")).To(Succeed())
+			Expect(cmd.Flags().Set("label-bastion", "example.com/team=ops")).To(Succeed())
+
+			ssh.SetKeepAliveInterval(50 * time.Millisecond)
+
+			key := types.NamespacedName{Name: bastionName, Namespace: *testProject.Spec.Namespace}
+
+			go func() {
+				GinkgoRecover()
+
+				defer func() {
+					signalChan <- os.Interrupt
+				}()
+
+				// simulate an external controller processing the bastion and proving a successful status
+				waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+				Eventually(func() bool {
+					bastion := &operationsv1alpha1.Bastion{}
+					if err := gardenClient.Get(ctx, key, bastion); apierrors.IsNotFound(err) {
+						return false
+					}
+
+					return bastion.Annotations != nil && bastion.Annotations[corev1beta1constants.GardenerOperation] == corev1beta1constants.GardenerOperationKeepalive
+				}).Should(BeTrue())
+
+				// delete the GardenerOperation annotation
+				bastion := &operationsv1alpha1.Bastion{}
+				Expect(gardenClient.Get(ctx, key, bastion)).To(Succeed())
+				delete(bastion.Annotations, corev1beta1constants.GardenerOperation)
+				patch := client.MergeFrom(bastion.DeepCopy())
+				Expect(gardenClient.Patch(ctx, bastion, patch)).To(Succeed())
+
+				// expect that the keepalive annotation will be added again
+				Eventually(func() bool {
+					bastion := &operationsv1alpha1.Bastion{}
+					if err := gardenClient.Get(ctx, key, bastion); apierrors.IsNotFound(err) {
+						return false
+					}
+
+					return bastion.Annotations != nil && bastion.Annotations[corev1beta1constants.GardenerOperation] == corev1beta1constants.GardenerOperationKeepalive
+				}).Should(BeTrue())
+
+				// wait until connect information is printed to be sure that the command ran through and is just waiting for the user to interrupt
+				Eventually(func() bool {
+					return strings.Contains(logs.String(), bastionIP)
+				}).Should(BeTrue())
+			}()
+
+			// let the magic happen
+			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+			// Double check that the annotation was really set
+			bastion := &operationsv1alpha1.Bastion{}
+			Expect(gardenClient.Get(ctx, key, bastion)).To(Succeed())
+			Expect(bastion.Annotations).To(HaveKeyWithValue(corev1beta1constants.GardenerOperation, corev1beta1constants.GardenerOperationKeepalive))
+
+			// the labels must persist across the keepalive patches
+			Expect(bastion.Labels).To(Equal(map[string]string{"cost-center": "12345", "example.com/team": "ops"}))
+		})
+
+		It("should annotate the kept bastion with the TTL", func() {
+			options := ssh.NewSSHOptions(streams)
+			options.KeepBastion = true // we need to assert its annotations later
+
+			cmd := ssh.NewCmdSSH(factory, options)
+			Expect(cmd.Flags().Set("keep-bastion-ttl", "2h")).To(Succeed())
+
+			go func() {
+				GinkgoRecover()
+
+				defer func() {
+					signalChan <- os.Interrupt
+				}()
+
+				// simulate an external controller processing the bastion and proving a successful status
+				waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+				// wait until connect information is printed to be sure that the command ran through and is just waiting for the user to interrupt
+				Eventually(func() bool {
+					return strings.Contains(logs.String(), bastionIP)
+				}).Should(BeTrue())
+			}()
+
+			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+			bastion := &operationsv1alpha1.Bastion{}
+			Expect(gardenClient.Get(ctx, types.NamespacedName{Name: bastionName, Namespace: *testProject.Spec.Namespace}, bastion)).To(Succeed())
+			Expect(bastion.Annotations).To(HaveKeyWithValue(ssh.AnnotationKeepBastionTTL, "2h0m0s"))
+		})
+
+		It("should stop keepalive when bastion is deleted ", func() {
+			options := ssh.NewSSHOptions(streams)
+			options.KeepBastion = true // we need to assert its annotations later
+
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			// simulate an external controller processing the bastion and proving a successful status
+			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+			// end the test after a couple of seconds (enough seconds for the keep-alive
+			// goroutine to do its thing)
+			ssh.SetKeepAliveInterval(100 * time.Millisecond)
+			signalChan := make(chan os.Signal, 1)
+			ssh.SetCreateSignalChannel(func() chan os.Signal {
+				return signalChan
+			})
+
+			// Once the waitForSignal function is called we delete the bastion
+			ssh.SetWaitForSignal(func(ctx context.Context, o *ssh.SSHOptions, signalChan <-chan struct{}) {
+				By("deleting bastion")
+				bastion := &operationsv1alpha1.Bastion{}
+				key := types.NamespacedName{Name: bastionName, Namespace: *testProject.Spec.Namespace}
+				Expect(gardenClient.Get(ctx, key, bastion)).To(Succeed())
+
+				Expect(gardenClient.Delete(ctx, bastion)).To(Succeed())
+
+				<-signalChan
+			})
+
+			// let the magic happen
+			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+			Expect(logs.String()).To(ContainSubstring("Can't keep bastion alive. Bastion is already gone."))
+		})
+
+		It("should skip the availability check", func() {
+			options := ssh.NewSSHOptions(streams)
+			options.SkipAvailabilityCheck = true
+
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			ssh.SetBastionAvailabilityChecker(func(hostname string, port string, privateKey []byte, identityAgent string, hostKeyCallback cryptossh.HostKeyCallback, httpsProxy string) error {
+				err := errors.New("this function should not be executed as of SkipAvailabilityCheck = true")
+				Fail(err.Error())
+				return err
+			})
+
+			// simulate an external controller processing the bastion and proving a successful status
+			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+			Expect(logs.String()).To(ContainSubstring("Bastion is ready, skipping availability check"))
+		})
+
+		It("should refresh the node private key files on SIGHUP", func() {
+			options := ssh.NewSSHOptions(streams)
+			options.Interactive = false
+			options.RefreshNodeKeys = true
+
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			refreshChan := make(chan os.Signal, 1)
+			ssh.SetCreateRefreshSignalChannel(func() (chan os.Signal, func()) {
+				return refreshChan, func() {}
+			})
+
+			ssh.SetWaitForSignal(func(ctx context.Context, o *ssh.SSHOptions, signalChan <-chan struct{}) {
+				// simulate a rotation of the shoot ssh keypair
+				keypair := &corev1.Secret{}
+				Expect(gardenClient.Get(ctx, client.ObjectKey{Name: fmt.Sprintf("%s.ssh-keypair", testShoot.Name), Namespace: *testProject.Spec.Namespace}, keypair)).To(Succeed())
+				keypair.Data = map[string][]byte{"id_rsa": []byte("rotated")}
+				Expect(gardenClient.Update(ctx, keypair)).To(Succeed())
+
+				refreshChan <- syscall.SIGHUP
+
+				Eventually(func() ([]byte, error) { return os.ReadFile(nodePrivateKeyFile) }).Should(Equal([]byte("rotated")))
+				Eventually(errOut.String).Should(ContainSubstring(fmt.Sprintf("> Node private key file: %s", nodePrivateKeyFile)))
+			})
+
+			// simulate an external controller processing the bastion and proving a successful status
+			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+			Expect(errOut.String()).To(ContainSubstring("> Refreshed the node private keys"))
+			Expect(nodePrivateKeyFile).NotTo(BeAnExistingFile())
+		})
+
+		It("should not keep alive the bastion", func() {
+			options := ssh.NewSSHOptions(streams)
+			options.NoKeepalive = true
+			options.KeepBastion = true
+			options.Interactive = false
+
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			ssh.SetWaitForSignal(func(ctx context.Context, o *ssh.SSHOptions, signalChan <-chan struct{}) {
+				Fail("this function should not be executed as of NoKeepalive = true")
+			})
+			ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+				err := errors.New("this function should not be executed as of NoKeepalive = true")
+				Fail(err.Error())
+				return err
+			})
+
+			// simulate an external controller processing the bastion and proving a successful status
+			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+			Expect(logs.String()).To(ContainSubstring("Bastion host became available."))
+		})
+
+		Context("with colorized output", func() {
+			var options *ssh.SSHOptions
+
+			run := func() {
+				cmd := ssh.NewCmdSSH(factory, options)
+
+				// simulate an external controller processing the bastion and proving a successful status
+				go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+				Expect(cmd.RunE(cmd, nil)).To(Succeed())
+			}
+
+			BeforeEach(func() {
+				options = ssh.NewSSHOptions(streams)
+				options.NoKeepalive = true
+				options.KeepBastion = true
+				options.Interactive = false
+
+				ssh.SetIsTerminalWriter(func(io.Writer) bool { return true })
+				DeferCleanup(ssh.SetIsTerminalWriter, func(io.Writer) bool { return false })
+			})
+
+			It("should colorize the bastion status on a terminal", func() {
+				run()
+
+				Expect(errOut.String()).To(ContainSubstring("\x1b[33m> Waiting for bastion to be ready… conditionTimeout=10m0s waitTimeout=10m0s\x1b[0m\n"))
+				Expect(errOut.String()).To(ContainSubstring("\x1b[32m> Bastion host became available. address=\"" + bastionIP + " (" + bastionHostname + ")\"\x1b[0m\n"))
+			})
+
+			It("should not colorize the output if it is not a terminal", func() {
+				ssh.SetIsTerminalWriter(func(io.Writer) bool { return false })
+				run()
+
+				klog.Flush()
+				Expect(logs.String()).To(ContainSubstring("Bastion host became available."))
+				Expect(logs.String()).NotTo(ContainSubstring("\x1b["))
+				Expect(errOut.String()).NotTo(ContainSubstring("\x1b["))
+				Expect(out.String()).NotTo(ContainSubstring("\x1b["))
+			})
+
+			It("should not colorize the output with --no-color", func() {
+				options.NoColor = true
+				run()
+
+				Expect(errOut.String()).NotTo(ContainSubstring("\x1b["))
+				Expect(out.String()).NotTo(ContainSubstring("\x1b["))
+			})
+
+			It("should not colorize the output if NO_COLOR is set", func() {
+				Expect(os.Setenv("NO_COLOR", "1")).To(Succeed())
+				DeferCleanup(os.Unsetenv, "NO_COLOR")
+				run()
+
+				Expect(errOut.String()).NotTo(ContainSubstring("\x1b["))
+				Expect(out.String()).NotTo(ContainSubstring("\x1b["))
+			})
+
+			It("should never colorize the json output", func() {
+				options.Output = "json"
+				run()
+
+				Expect(errOut.String()).NotTo(ContainSubstring("\x1b["))
+				Expect(out.String()).NotTo(ContainSubstring("\x1b["))
+
+				var info ssh.ConnectInformation
+				Expect(json.Unmarshal([]byte(out.String()), &info)).To(Succeed())
+			})
+		})
+
+		It("should output as json", func() {
+			options := ssh.NewSSHOptions(streams)
+			options.NoKeepalive = true
+			options.KeepBastion = true
+			options.Interactive = false
+
+			options.Output = "json"
+
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			ssh.SetWaitForSignal(func(ctx context.Context, o *ssh.SSHOptions, signalChan <-chan struct{}) {
+				Fail("this function should not be executed as of NoKeepalive = true")
+			})
+			ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+				err := errors.New("this function should not be executed as of NoKeepalive = true")
+				Fail(err.Error())
+				return err
+			})
+
+			// simulate an external controller processing the bastion and proving a successful status
+			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+			var info ssh.ConnectInformation
+			Expect(json.Unmarshal([]byte(out.String()), &info)).To(Succeed())
+			Expect(info.Bastion.Name).To(Equal(bastionName))
+			Expect(info.Bastion.PreferredAddress).To(Equal("0.0.0.0"))
+			Expect(info.Bastion.IP).To(Equal(bastionIP))
+			Expect(info.Bastion.Hostname).To(Equal(bastionHostname))
+			Expect(info.Bastion.SSHPrivateKeyFile).To(Equal(options.SSHPrivateKeyFile))
+			Expect(info.Bastion.SSHPublicKeyFile).To(Equal(options.SSHPublicKeyFile))
+			Expect(info.Nodes).To(ConsistOf([]ssh.Node{
+				{
+					Name:   pendingMachine.Labels[machinev1alpha1.NodeLabelKey],
+					Status: "Unknown",
+				},
+				{
+					Name:   testNode.Name,
+					Status: "Not Ready",
+					Address: ssh.Address{
+						Hostname: nodeHostname,
+					},
+				},
+			}))
+			Expect(info.NodePrivateKeyFiles).NotTo(BeEmpty())
+
+			var raw map[string]interface{}
+			Expect(json.Unmarshal([]byte(out.String()), &raw)).To(Succeed())
+			Expect(raw).To(HaveKeyWithValue("bastion", SatisfyAll(
+				HaveKeyWithValue("ip", bastionIP),
+				HaveKeyWithValue("hostname", bastionHostname),
+				HaveKeyWithValue("preferredAddress", "0.0.0.0"),
+			)))
+		})
+
+		It("should forward a local port to the kube-apiserver and print a kubeconfig", func() {
+			options := ssh.NewSSHOptions(streams)
+			options.PortForward = 16443
+
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			var (
+				executedCommand string
+				sshArgs         []string
+			)
+			ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+				executedCommand = command
+				sshArgs = args
+
+				return nil
+			})
+
+			// simulate an external controller processing the bastion and proving a successful status
+			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+			Expect(executedCommand).To(Equal("ssh"))
+			Expect(sshArgs).To(HaveExactElements(
+				"-N",
+				"-L127.0.0.1:16443:api.bar.baz:443",
+				"-oExitOnForwardFailure=yes",
+				"-oStrictHostKeyChecking=ask",
+				"-oIdentitiesOnly=yes",
+				"-i"+options.SSHPrivateKeyFile.String(),
+				ContainSubstring("-oUserKnownHostsFile="),
+				"gardener@"+bastionIP,
+				"-p22",
+			))
+
+			kubeconfig, err := clientcmd.Load([]byte(out.String()))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kubeconfig.Contexts).To(HaveLen(1))
+			Expect(kubeconfig.Clusters).To(HaveLen(1))
+
+			for _, cluster := range kubeconfig.Clusters {
+				Expect(cluster.Server).To(Equal("https://127.0.0.1:16443"))
+				Expect(cluster.TLSServerName).To(Equal("api.bar.baz"))
+			}
+		})
+
+		It("should display the banner on stderr without affecting the output", func() {
+			bannerFile := filepath.Join(GinkgoT().TempDir(), "banner.txt")
+			Expect(os.WriteFile(bannerFile, []byte("Authorized use only.\n"), 0o600)).To(Succeed())
+
+			options := ssh.NewSSHOptions(streams)
+			options.NoKeepalive = true
+			options.KeepBastion = true
+			options.Interactive = false
+			options.Output = "json"
+			options.BannerFile = bannerFile
+
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+				err := errors.New("this function should not be executed as of NoKeepalive = true")
+				Fail(err.Error())
+				return err
+			})
+
+			// simulate an external controller processing the bastion and proving a successful status
+			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+			Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+			Expect(errOut.String()).To(ContainSubstring("Authorized use only.\n"))
+			Expect(out.String()).NotTo(ContainSubstring("Authorized use only."))
+
+			var info ssh.ConnectInformation
+			Expect(json.Unmarshal([]byte(out.String()), &info)).To(Succeed())
+		})
+
+		It("should fail if the banner file cannot be read", func() {
+			options := ssh.NewSSHOptions(streams)
+			options.BannerFile = filepath.Join(GinkgoT().TempDir(), "missing.txt")
+
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			Expect(cmd.RunE(cmd, nil)).To(MatchError(HavePrefix("failed to read banner file:")))
+		})
+
+		Context("bastion name collision", func() {
+			var foreignBastion *operationsv1alpha1.Bastion
+
+			BeforeEach(func() {
+				foreignBastion = &operationsv1alpha1.Bastion{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cli-foreign",
+						Namespace: *testProject.Spec.Namespace,
+					},
+					Spec: operationsv1alpha1.BastionSpec{
+						ShootRef:     corev1.LocalObjectReference{Name: "other-shoot"},
+						SSHPublicKey: "foreign-key",
+					},
+				}
+				Expect(gardenClient.Create(ctx, foreignBastion)).To(Succeed())
+			})
+
+			newOptions := func() *ssh.SSHOptions {
+				options := ssh.NewSSHOptions(streams)
+				options.NoKeepalive = true
+				options.KeepBastion = true
+				options.Interactive = false
+
+				ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+					err := errors.New("this function should not be executed as of NoKeepalive = true")
+					Fail(err.Error())
+					return err
+				})
+
+				return options
+			}
+
+			It("should generate a new name if a bastion with the generated name already exists", func() {
+				names := []string{foreignBastion.Name, bastionName}
+				ssh.SetBastionNameProvider(func() (string, error) {
+					name := names[0]
+					names = names[1:]
+
+					return name, nil
+				})
+
+				options := newOptions()
+				cmd := ssh.NewCmdSSH(factory, options)
+
+				// simulate an external controller processing the bastion and proving a successful status
+				go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+				Expect(cmd.RunE(cmd, nil)).To(Succeed())
+				Expect(options.BastionName).To(Equal(bastionName))
+				Expect(names).To(BeEmpty())
+
+				bastion := &operationsv1alpha1.Bastion{}
+				Expect(gardenClient.Get(ctx, client.ObjectKeyFromObject(foreignBastion), bastion)).To(Succeed())
+				Expect(bastion.Spec.ShootRef.Name).To(Equal("other-shoot"))
+				Expect(bastion.Spec.SSHPublicKey).To(Equal("foreign-key"))
+			})
+
+			It("should fail if all generated names already exist", func() {
+				ssh.SetBastionNameProvider(func() (string, error) {
+					return foreignBastion.Name, nil
+				})
+
+				cmd := ssh.NewCmdSSH(factory, newOptions())
+
+				Expect(cmd.RunE(cmd, nil)).To(MatchError("failed to generate a unique bastion name after 5 attempts"))
+			})
+
+			It("should not generate a new name if the bastion name has been provided", func() {
+				options := newOptions()
+				options.BastionName = foreignBastion.Name
+				cmd := ssh.NewCmdSSH(factory, options)
+
+				// simulate an external controller processing the bastion and proving a successful status
+				go waitForBastionThenSetBastionReady(ctx, gardenClient, foreignBastion.Name, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+				Expect(cmd.RunE(cmd, nil)).To(Succeed())
+				Expect(options.BastionName).To(Equal(foreignBastion.Name))
+				Expect(options.GeneratedBastionName).To(BeFalse())
+			})
+		})
+
+		Context("summary", func() {
+			BeforeEach(func() {
+				// simulate an external controller processing the bastion and proving a successful status
+				go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+			})
+
+			It("should print the summary to stderr after an interactive session", func() {
+				options := ssh.NewSSHOptions(streams)
+				options.Summary = true
+				cmd := ssh.NewCmdSSH(factory, options)
+
+				ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+					return nil
+				})
+
+				Expect(cmd.RunE(cmd, []string{testNode.Name})).To(Succeed())
+
+				Expect(errOut.String()).To(ContainSubstring("> Bastion: " + *testProject.Spec.Namespace + "/" + bastionName + "\n"))
+				Expect(errOut.String()).To(ContainSubstring("> Kept: no\n"))
+				Expect(errOut.String()).To(ContainSubstring("> Node: " + testNode.Name + "\n"))
+				Expect(errOut.String()).To(ContainSubstring("> Node private key file: " + nodePrivateKeyFile + "\n"))
+			})
+
+			It("should print the summary in the output format in non-interactive mode", func() {
+				options := ssh.NewSSHOptions(streams)
+				options.Summary = true
+				options.Interactive = false
+				options.NoKeepalive = true
+				options.KeepBastion = true
+				options.Output = "json"
+				cmd := ssh.NewCmdSSH(factory, options)
+
+				Expect(cmd.RunE(cmd, []string{testNode.Name})).To(Succeed())
+
+				// the connect information is followed by the summary
+				decoder := json.NewDecoder(strings.NewReader(out.String()))
+				Expect(decoder.Decode(&ssh.ConnectInformation{})).To(Succeed())
+
+				var summary ssh.SessionSummary
+				Expect(decoder.Decode(&summary)).To(Succeed())
+				Expect(summary.Bastion).To(Equal(bastionName))
+				Expect(summary.Kept).To(BeTrue())
+				Expect(summary.Node).To(Equal(testNode.Name))
+			})
+		})
+
+		Context("wide output", func() {
+			BeforeEach(func() {
+				ssh.SetWaitForSignal(func(ctx context.Context, o *ssh.SSHOptions, signalChan <-chan struct{}) {
+					Fail("this function should not be executed as of NoKeepalive = true")
+				})
+				ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+					err := errors.New("this function should not be executed as of NoKeepalive = true")
+					Fail(err.Error())
+					return err
+				})
+
+				// simulate an external controller processing the bastion and proving a successful status
+				go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+			})
+
+			It("should include the zone, instance type and kubelet version in the json output", func() {
+				options := ssh.NewSSHOptions(streams)
+				options.NoKeepalive = true
+				options.KeepBastion = true
+				options.Interactive = false
+				options.Wide = true
+				options.Output = "json"
+
+				cmd := ssh.NewCmdSSH(factory, options)
+
+				Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+				var info ssh.ConnectInformation
+				Expect(json.Unmarshal([]byte(out.String()), &info)).To(Succeed())
+				Expect(info.Nodes).To(ConsistOf([]ssh.Node{
+					{
+						Name:   pendingMachine.Labels[machinev1alpha1.NodeLabelKey],
+						Status: "Unknown",
+					},
+					{
+						Name:   testNode.Name,
+						Status: "Not Ready",
+						Address: ssh.Address{
+							Hostname: nodeHostname,
+						},
+						Zone:           "eu-west-1a",
+						InstanceType:   "m5.large",
+						KubeletVersion: "v1.31.1",
+					},
+				}))
+			})
+
+			It("should include the additional columns in the node table", func() {
+				options := ssh.NewSSHOptions(streams)
+				options.NoKeepalive = true
+				options.KeepBastion = true
+				options.Interactive = false
+				options.Wide = true
+
+				cmd := ssh.NewCmdSSH(factory, options)
+
+				Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+				Expect(out.String()).To(MatchRegexp(`NODE NAME\s+STATUS\s+IP\s+HOSTNAME\s+ZONE\s+INSTANCE TYPE\s+KUBELET VERSION\n`))
+				Expect(out.String()).To(MatchRegexp(`node1\s+Not Ready\s+` + nodeHostname + `\s+eu-west-1a\s+m5.large\s+v1.31.1\n`))
+			})
+
+			It("should keep the node table compact by default", func() {
+				options := ssh.NewSSHOptions(streams)
+				options.NoKeepalive = true
+				options.KeepBastion = true
+				options.Interactive = false
+
+				cmd := ssh.NewCmdSSH(factory, options)
+
+				Expect(cmd.RunE(cmd, nil)).To(Succeed())
+
+				Expect(out.String()).To(MatchRegexp(`NODE NAME\s+STATUS\s+IP\s+HOSTNAME\n`))
+				Expect(out.String()).NotTo(ContainSubstring("eu-west-1a"))
+			})
+		})
+
+		Context("health check", func() {
+			BeforeEach(func() {
+				ssh.SetWaitForSignal(func(ctx context.Context, o *ssh.SSHOptions, signalChan <-chan struct{}) {
+					Fail("this function should not be executed as of Health = true")
+				})
+				ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+					err := errors.New("this function should not be executed as of Health = true")
+					Fail(err.Error())
+					return err
+				})
+			})
+
+			It("should report a reachable bastion", func() {
+				options := ssh.NewSSHOptions(streams)
+				cmd := ssh.NewCmdSSH(factory, options)
+				Expect(cmd.Flags().Set("health", "true")).To(Succeed())
+				Expect(cmd.Flags().Set("output", "json")).To(Succeed())
+
+				// simulate an external controller processing the bastion and proving a successful status
+				go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+				Expect(cmd.RunE(cmd, []string{testNode.Name})).To(Succeed())
+
+				var result ssh.HealthResult
+				Expect(json.Unmarshal([]byte(out.String()), &result)).To(Succeed())
+				Expect(result.Bastion).To(Equal(bastionName))
+				Expect(result.Status).To(Equal(ssh.HealthStatusOK))
+				Expect(result.Duration).NotTo(BeEmpty())
+				Expect(result.Error).To(BeEmpty())
+
+				// assert that the bastion has been cleaned up
+				key := types.NamespacedName{Name: bastionName, Namespace: *testProject.Spec.Namespace}
+				Expect(gardenClient.Get(ctx, key, &operationsv1alpha1.Bastion{})).NotTo(Succeed())
+			})
+
+			It("should report an unreachable bastion and keep it", func() {
+				ssh.SetBastionAvailabilityChecker(func(hostname string, port string, privateKey []byte, identityAgent string, hostKeyCallback cryptossh.HostKeyCallback, httpsProxy string) error {
+					return errors.New("connection refused")
+				})
+
+				options := ssh.NewSSHOptions(streams)
+				cmd := ssh.NewCmdSSH(factory, options)
+				Expect(cmd.Flags().Set("health", "true")).To(Succeed())
+				Expect(cmd.Flags().Set("keep-bastion", "true")).To(Succeed())
+				Expect(cmd.Flags().Set("wait-timeout", "2s")).To(Succeed())
+
+				// simulate an external controller processing the bastion and proving a successful status
+				go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+				Expect(cmd.RunE(cmd, nil)).To(MatchError(ContainSubstring("bastion health check failed")))
+				Expect(out.String()).To(HavePrefix(ssh.HealthStatusFail + " bastion " + *testProject.Spec.Namespace + "/" + bastionName))
+				Expect(out.String()).To(ContainSubstring("connection refused"))
+
+				// assert that the bastion has been kept
+				key := types.NamespacedName{Name: bastionName, Namespace: *testProject.Spec.Namespace}
+				Expect(gardenClient.Get(ctx, key, &operationsv1alpha1.Bastion{})).To(Succeed())
+			})
+
+			It("should bound the ready condition and the availability independently", func() {
+				var checks atomic.Int32
+
+				// the bastion accepts SSH connections only on the fourth attempt
+				ssh.SetBastionAvailabilityChecker(func(hostname string, port string, privateKey []byte, identityAgent string, hostKeyCallback cryptossh.HostKeyCallback, httpsProxy string) error {
+					if checks.Add(1) < 4 {
+						return errors.New("connection refused")
+					}
+
+					return nil
+				})
+
+				options := ssh.NewSSHOptions(streams)
+				cmd := ssh.NewCmdSSH(factory, options)
+				Expect(cmd.Flags().Set("health", "true")).To(Succeed())
+				Expect(cmd.Flags().Set("output", "json")).To(Succeed())
+				Expect(cmd.Flags().Set("condition-timeout", "2s")).To(Succeed())
+				Expect(cmd.Flags().Set("wait-timeout", "10s")).To(Succeed())
+
+				// simulate an external controller processing the bastion and proving a successful status
+				go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+				Expect(cmd.RunE(cmd, nil)).To(Succeed())
+				Expect(checks.Load()).To(BeEquivalentTo(4))
+
+				var result ssh.HealthResult
+				Expect(json.Unmarshal([]byte(out.String()), &result)).To(Succeed())
+				Expect(result.Status).To(Equal(ssh.HealthStatusOK))
+			})
+
+			It("should time out waiting for the ready condition", func() {
+				ssh.SetBastionAvailabilityChecker(func(hostname string, port string, privateKey []byte, identityAgent string, hostKeyCallback cryptossh.HostKeyCallback, httpsProxy string) error {
+					err := errors.New("the availability must not be checked before the bastion is ready")
+					Fail(err.Error())
+					return err
+				})
+
+				options := ssh.NewSSHOptions(streams)
+				cmd := ssh.NewCmdSSH(factory, options)
+				Expect(cmd.Flags().Set("health", "true")).To(Succeed())
+				Expect(cmd.Flags().Set("condition-timeout", "2s")).To(Succeed())
+
+				Expect(cmd.RunE(cmd, nil)).To(MatchError(ContainSubstring("timed out waiting for the bastion to become ready")))
+				Expect(errOut.String()).NotTo(ContainSubstring("kind: Bastion"))
+			})
+
+			It("should print the bastion as YAML on timeout", func() {
+				options := ssh.NewSSHOptions(streams)
+				cmd := ssh.NewCmdSSH(factory, options)
+				Expect(cmd.Flags().Set("health", "true")).To(Succeed())
+				Expect(cmd.Flags().Set("condition-timeout", "2s")).To(Succeed())
+				Expect(cmd.Flags().Set("print-bastion-yaml", "true")).To(Succeed())
+
+				Expect(cmd.RunE(cmd, nil)).To(MatchError(ContainSubstring("timed out waiting for the bastion to become ready")))
+				Expect(errOut.String()).To(ContainSubstring("Bastion " + *testProject.Spec.Namespace + "/" + bastionName + ":\n"))
+				Expect(errOut.String()).To(ContainSubstring("kind: Bastion\n"))
+				Expect(errOut.String()).To(ContainSubstring("  name: " + bastionName + "\n"))
+				Expect(errOut.String()).NotTo(ContainSubstring("managedFields"))
+			})
+
+			It("should time out waiting for the availability of a ready bastion", func() {
+				ssh.SetBastionAvailabilityChecker(func(hostname string, port string, privateKey []byte, identityAgent string, hostKeyCallback cryptossh.HostKeyCallback, httpsProxy string) error {
+					return errors.New("connection refused")
+				})
+
+				options := ssh.NewSSHOptions(streams)
+				cmd := ssh.NewCmdSSH(factory, options)
+				Expect(cmd.Flags().Set("health", "true")).To(Succeed())
+				Expect(cmd.Flags().Set("wait-timeout", "2s")).To(Succeed())
+
+				// simulate an external controller processing the bastion and proving a successful status
+				go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+				Expect(cmd.RunE(cmd, nil)).To(MatchError(ContainSubstring("timed out waiting for the bastion to accept SSH connections: connection refused")))
+			})
+		})
+
+		It("should return an error when SSHAccess is disabled", func() {
+			options := ssh.NewSSHOptions(streams)
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			testShootBase := testShoot.DeepCopy()
+			testShoot.Spec.Provider.WorkersSettings.SSHAccess.Enabled = false
+			Expect(gardenClient.Patch(ctx, testShoot, client.MergeFrom(testShootBase))).To(Succeed())
+
+			Expect(cmd.RunE(cmd, nil)).To(MatchError("node SSH access disabled, SSH not allowed"))
+
+			// assert that the generated keypair has been removed
+			Expect(options.SSHPublicKeyFile.String()).NotTo(BeAnExistingFile())
+			Expect(options.SSHPrivateKeyFile.String()).NotTo(BeAnExistingFile())
+		})
+
+		DescribeTable("should return an error when the shoot is hibernated",
+			func(mutate func(shoot *gardencorev1beta1.Shoot)) {
+				options := ssh.NewSSHOptions(streams)
+				cmd := ssh.NewCmdSSH(factory, options)
+
+				testShootBase := testShoot.DeepCopy()
+				mutate(testShoot)
+				Expect(gardenClient.Patch(ctx, testShoot, client.MergeFrom(testShootBase))).To(Succeed())
+
+				Expect(cmd.RunE(cmd, nil)).To(MatchError("shoot is hibernated; wake it before SSH"))
+
+				// assert that no bastion has been created
+				bastions := &operationsv1alpha1.BastionList{}
+				Expect(gardenClient.List(ctx, bastions)).To(Succeed())
+				Expect(bastions.Items).To(BeEmpty())
+			},
+			Entry("when hibernation is enabled", func(shoot *gardencorev1beta1.Shoot) {
+				shoot.Spec.Hibernation = &gardencorev1beta1.Hibernation{Enabled: ptr.To(true)}
+			}),
+			Entry("when the shoot is still hibernated", func(shoot *gardencorev1beta1.Shoot) {
+				shoot.Status.IsHibernated = true
+			}),
+		)
+
+		It("should use custom known hosts files when provided", func() {
+			options := ssh.NewSSHOptions(streams)
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			// Set custom known hosts files
+			options.BastionUserKnownHostsFiles = []string{"/custom/bastion/known_hosts"}
+			options.NodeUserKnownHostsFiles = []string{"/custom/node/known_hosts"}
+
+			// Simulate the bastion being ready
+			go waitForBastionThenSetBastionReady(ctx, gardenClient, bastionName, *testProject.Spec.Namespace, bastionHostname, bastionIP)
+
+			// do not actually execute any commands
+			executedCommands := 0
+			ssh.SetExecCommand(func(ctx context.Context, command string, args []string, ioStreams util.IOStreams) error {
+				defer func() {
+					signalChan <- os.Interrupt
+				}()
+				executedCommands++
+
+				Expect(command).To(Equal("ssh"))
+				Expect(args).To(Equal([]string{
+					"-oIdentitiesOnly=yes",
+					"-oStrictHostKeyChecking=ask",
+					"-oUserKnownHostsFile='/custom/node/known_hosts'",
+					fmt.Sprintf("-i%s", nodePrivateKeyFile),
+					fmt.Sprintf(
+						"-oProxyCommand=ssh -W%%h:%%p -oStrictHostKeyChecking=ask -oIdentitiesOnly=yes '-i%s' '-oUserKnownHostsFile='\"'\"'/custom/bastion/known_hosts'\"'\"'' '%s@%s' '-p22'",
+						options.SSHPrivateKeyFile,
+						ssh.SSHBastionUsername,
+						bastionIP,
+					),
+					fmt.Sprintf("%s@%s", options.User, nodeHostname),
+				}))
+
+				return nil
+			})
+
+			// Execute the command
+			Expect(cmd.RunE(cmd, []string{testNode.Name})).To(Succeed())
+
+			// assert output
+			Expect(executedCommands).To(Equal(1))
+			Expect(logs.String()).To(ContainSubstring(bastionName))
+			Expect(logs.String()).To(ContainSubstring(bastionHostname))
+			Expect(out.String()).To(ContainSubstring(bastionIP))
+
+			// assert that the bastion has been cleaned up
+			key := types.NamespacedName{Name: bastionName, Namespace: *testProject.Spec.Namespace}
+			bastion := &operationsv1alpha1.Bastion{}
+
+			Expect(gardenClient.Get(ctx, key, bastion)).NotTo(Succeed())
+
+			// assert that no temporary SSH keypair remained on disk
+			_, err := os.Stat(options.SSHPublicKeyFile.String())
+			Expect(err).To(HaveOccurred())
+
+			_, err = os.Stat(options.SSHPrivateKeyFile.String())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("node names shadowed by subcommands", func() {
+		It("should run the subcommand for a bare name", func() {
+			cmd := ssh.NewCmdSSH(factory, ssh.NewSSHOptions(streams))
+
+			found, _, err := cmd.Find([]string{"dump-node-keys"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Name()).To(Equal("dump-node-keys"))
+		})
+
+		DescribeTable("should pass a name given after -- as the node name",
+			func(nodeName string) {
+				cmd := ssh.NewCmdSSH(factory, ssh.NewSSHOptions(streams))
+
+				found, args, err := cmd.Find([]string{"--", nodeName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeIdenticalTo(cmd))
+				Expect(args).To(ContainElement(nodeName))
+			},
+			Entry("config", "config"),
+			Entry("doctor", "doctor"),
+			Entry("dump-node-keys", "dump-node-keys"),
+		)
+	})
+
+	Describe("ValidArgsFunction", func() {
+		var (
+			manager *targetmocks.MockManager
+			client  *gardenclientmocks.MockClient
+		)
+
+		BeforeEach(func() {
+			manager = targetmocks.NewMockManager(ctrl)
+			client = gardenclientmocks.NewMockClient(ctrl)
+
+			factory.ManagerImpl = manager
+			manager.EXPECT().CurrentTarget().Return(currentTarget, nil)
+			manager.EXPECT().GardenClient(currentTarget.GardenName()).Return(client, nil)
+
+			client.EXPECT().FindShoot(ctx, currentTarget.AsListOption()).Return(testShoot, nil)
+		})
+
+		It("should return all names based on machine objects", func() {
+			manager.EXPECT().SeedClient(ctx, gomock.Any()).Return(seedClient, nil)
+
+			options := ssh.NewSSHOptions(streams)
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			suggestions, directive := cmd.ValidArgsFunction(cmd, nil, "")
+			Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
+			Expect(suggestions).To(HaveLen(2))
+			Expect(suggestions).To(Equal([]string{"node1", "monitoring1"}))
+		})
+
+		It("should return all names based on node objects", func() {
+			errForbidden := &apierrors.StatusError{ErrStatus: metav1.Status{Reason: metav1.StatusReasonForbidden}}
+			manager.EXPECT().SeedClient(ctx, gomock.Any()).Return(nil, errForbidden)
+			manager.EXPECT().ShootClient(ctx, currentTarget).Return(shootClient, nil)
+
+			options := ssh.NewSSHOptions(streams)
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			suggestions, directive := cmd.ValidArgsFunction(cmd, nil, "")
+			Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
+			Expect(suggestions).To(HaveLen(1))
+			Expect(suggestions).To(Equal([]string{"node1"}))
+		})
+
+		It("should not log if the machines are forbidden", func() {
+			errForbidden := &apierrors.StatusError{ErrStatus: metav1.Status{Reason: metav1.StatusReasonForbidden}}
+			manager.EXPECT().SeedClient(ctx, gomock.Any()).Return(nil, errForbidden)
+			manager.EXPECT().ShootClient(ctx, currentTarget).Return(shootClient, nil)
+
+			options := ssh.NewSSHOptions(streams)
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			suggestions, _ := cmd.ValidArgsFunction(cmd, nil, "")
+			Expect(suggestions).To(Equal([]string{"node1"}))
+			klog.Flush()
+			Expect(logs.String()).NotTo(ContainSubstring("seed cluster unreachable"))
+			Expect(logs.String()).NotTo(ContainSubstring("failed to fetch node names from machine objects"))
+		})
+
+		It("should warn if the seed cluster is unreachable and return all names based on node objects", func() {
+			errUnreachable := &url.Error{
+				Op:  "Get",
+				URL: "https://api.seed.example.com",
+				Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED},
+			}
+			manager.EXPECT().SeedClient(ctx, gomock.Any()).Return(nil, fmt.Errorf("failed to create seed client: %w", errUnreachable))
+			manager.EXPECT().ShootClient(ctx, currentTarget).Return(shootClient, nil)
+
+			options := ssh.NewSSHOptions(streams)
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			suggestions, _ := cmd.ValidArgsFunction(cmd, nil, "")
+			Expect(suggestions).To(Equal([]string{"node1"}))
+			klog.Flush()
+			Expect(logs.String()).To(ContainSubstring("seed cluster unreachable, falling back to the nodes of the shoot cluster"))
+		})
+
+		It("should find nodes based on their prefix from machine objects", func() {
+			manager.EXPECT().SeedClient(ctx, gomock.Any()).Return(seedClient, nil)
+
+			options := ssh.NewSSHOptions(streams)
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			suggestions, directive := cmd.ValidArgsFunction(cmd, nil, "mon")
+			Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
+			Expect(suggestions).To(HaveLen(1))
+			Expect(suggestions).To(Equal([]string{"monitoring1"}))
+		})
+
+		It("should only return the names of the machines matching the node label filter", func() {
+			manager.EXPECT().SeedClient(ctx, gomock.Any()).Return(seedClient, nil)
+
+			options := ssh.NewSSHOptions(streams)
+			options.NodeLabelFilter = corev1.LabelTopologyZone + "=eu-west-1b"
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			suggestions, directive := cmd.ValidArgsFunction(cmd, nil, "")
+			Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
+			Expect(suggestions).To(Equal([]string{"monitoring1"}))
+		})
+
+		It("should only return the names of the nodes matching the node label filter", func() {
+			errForbidden := &apierrors.StatusError{ErrStatus: metav1.Status{Reason: metav1.StatusReasonForbidden}}
+			manager.EXPECT().SeedClient(ctx, gomock.Any()).Return(nil, errForbidden)
+			manager.EXPECT().ShootClient(ctx, currentTarget).Return(shootClient, nil)
+
+			options := ssh.NewSSHOptions(streams)
+			options.NodeLabelFilter = corev1.LabelInstanceTypeStable + "!=m5.large"
+			cmd := ssh.NewCmdSSH(factory, options)
+
+			suggestions, directive := cmd.ValidArgsFunction(cmd, nil, "")
+			Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
+			Expect(suggestions).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("SSH Options", func() {
+	var (
+		streams util.IOStreams
+		o       *ssh.SSHOptions
+	)
+
+	BeforeEach(func() {
+		streams, _, _, _ = util.NewTestIOStreams()
+
+		o = ssh.NewSSHOptions(streams)
+	})
+
+	Describe("Complete", func() {
+		var factory *internalfake.Factory
+		BeforeEach(func() {
+			factory = internalfake.NewFakeFactory(nil, nil, nil, nil)
+		})
+
+		AfterEach(func() {
+			Expect(os.Remove(o.SSHPublicKeyFile.String())).To(Succeed())
+			Expect(os.Remove(o.SSHPrivateKeyFile.String())).To(Succeed())
+		})
+
+		It("should complete node name", func() {
+			Expect(o.Complete(factory, nil, []string{"my-node"})).To(Succeed())
+
+			Expect(o.NodeName).To(Equal("my-node"))
+		})
+
+		It("should complete public and private key", func() {
+			Expect(o.Complete(factory, nil, nil)).To(Succeed())
+
+			Expect(o.SSHPublicKeyFile).NotTo(BeEmpty())
+			Expect(o.SSHPrivateKeyFile).NotTo(BeEmpty())
+			Expect(o.GeneratedSSHKeys).To(BeTrue())
+		})
+
+		It("should generate the keypair in the configured temporary directory", func() {
+			o.TempDir = filepath.Join(GinkgoT().TempDir(), "keys")
+
+			Expect(o.Complete(factory, nil, nil)).To(Succeed())
+
+			Expect(o.TempDir).To(BeADirectory())
+			Expect(filepath.Dir(o.SSHPublicKeyFile.String())).To(Equal(o.TempDir))
+			Expect(filepath.Dir(o.SSHPrivateKeyFile.String())).To(Equal(o.TempDir))
+		})
+
+		It("should default the temporary directory to the one of the factory", func() {
+			factory.GardenTempDirectory = GinkgoT().TempDir()
+
+			Expect(o.Complete(factory, nil, nil)).To(Succeed())
+
+			Expect(o.TempDir).To(Equal(factory.GardenTempDirectory))
+			Expect(filepath.Dir(o.SSHPrivateKeyFile.String())).To(Equal(factory.GardenTempDirectory))
+		})
+
+		It("should complete bastion name", func() {
+			Expect(o.Complete(factory, nil, nil)).To(Succeed())
+
+			Expect(o.BastionName).To(Not(BeEmpty()))
+			Expect(o.GeneratedBastionName).To(BeTrue())
+		})
+
+		It("should not touch bastion name if set", func() {
+			o.BastionName = "cli-xxxxxx"
+
+			Expect(o.Complete(factory, nil, nil)).To(Succeed())
+
+			Expect(o.BastionName).To(Equal("cli-xxxxxx"))
+			Expect(o.GeneratedBastionName).To(BeFalse())
+		})
+
+		It("should switch to non-interactive mode if no node name given", func() {
+			o.Interactive = true
+
+			Expect(o.Complete(factory, nil, []string{""})).To(Succeed())
+
+			Expect(o.Interactive).To(BeFalse())
+		})
+
+		Context("public IP detection", func() {
+			var (
+				gardenHomeDir string
+				cacheFile     string
+				server        *httptest.Server
+			)
+
+			writeCache := func(endpoint string, addresses []string, timestamp time.Time) {
+				data, err := json.Marshal(map[string]interface{}{
+					"endpoint":  endpoint,
+					"addresses": addresses,
+					"timestamp": timestamp,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(os.MkdirAll(filepath.Dir(cacheFile), 0o700)).To(Succeed())
+				Expect(os.WriteFile(cacheFile, data, 0o600)).To(Succeed())
+			}
+
+			BeforeEach(func() {
+				var err error
+				gardenHomeDir, err = os.MkdirTemp("", "garden-home-*")
+				Expect(err).NotTo(HaveOccurred())
+				DeferCleanup(os.RemoveAll, gardenHomeDir)
+
+				factory.GardenHomeDirectory = gardenHomeDir
+				cacheFile = filepath.Join(gardenHomeDir, "cache", "public-ips.json")
+
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					fmt.Fprintln(w, "198.51.100.7")
+				}))
+				DeferCleanup(server.Close)
+			})
+
+			It("should use the cached public IPs", func() {
+				writeCache("", []string{"203.0.113.1"}, time.Now())
+
+				Expect(o.Complete(factory, nil, nil)).To(Succeed())
+
+				Expect(o.CIDRs).To(ConsistOf("203.0.113.1/32"))
+				Expect(o.AutoDetected).To(BeTrue())
+			})
+
+			It("should not use an expired cache", func() {
+				writeCache("", []string{"203.0.113.1"}, time.Now().Add(-time.Hour))
+
+				Expect(o.Complete(factory, nil, nil)).To(Succeed())
+
+				Expect(o.CIDRs).To(ConsistOf("192.0.2.42/32", "2001:db8::/64"))
+			})
+
+			It("should not use the cache of another endpoint", func() {
+				writeCache("", []string{"203.0.113.1"}, time.Now())
+				o.IPDetectionURL = server.URL
+
+				Expect(o.Complete(factory, nil, nil)).To(Succeed())
+
+				Expect(o.CIDRs).To(ConsistOf("198.51.100.7/32"))
+			})
+
+			It("should detect the public IP using the configured endpoint and cache it", func() {
+				o.IPDetectionURL = server.URL
+
+				Expect(o.Complete(factory, nil, nil)).To(Succeed())
+
+				Expect(o.CIDRs).To(ConsistOf("198.51.100.7/32"))
+
+				data, err := os.ReadFile(cacheFile)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(ContainSubstring(fmt.Sprintf(`"endpoint":%q`, server.URL)))
+				Expect(string(data)).To(ContainSubstring(`"addresses":["198.51.100.7"]`))
+			})
+
+			It("should use the endpoint of the gardenctl configuration", func() {
+				factory.Config = &config.Config{IPDetectionURL: server.URL}
+
+				Expect(o.Complete(factory, nil, nil)).To(Succeed())
+
+				Expect(o.CIDRs).To(ConsistOf("198.51.100.7/32"))
+			})
+		})
+	})
+
+	Describe("Complete without a generated keypair", func() {
+		var factory *internalfake.Factory
+
+		BeforeEach(func() {
+			factory = internalfake.NewFakeFactory(nil, nil, nil, nil)
+		})
+
+		It("should remove the generated keypair if a later step fails", func() {
+			o.TempDir = GinkgoT().TempDir()
+
+			ssh.SetBastionNameProvider(func() (string, error) {
+				return "", errors.New("no entropy")
+			})
+			DeferCleanup(ssh.SetBastionNameProvider, func() (string, error) {
+				return "cli-xxxxxxxx", nil
+			})
+
+			Expect(o.Complete(factory, nil, nil)).To(MatchError("failed to create bastion name: no entropy"))
+
+			Expect(o.GeneratedSSHKeys).To(BeFalse())
+			Expect(os.ReadDir(o.TempDir)).To(BeEmpty())
+		})
+
+		It("should fail if the IP detection endpoint does not return an IP", func() {
+			invalidServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprintln(w, "not an ip")
+			}))
+			DeferCleanup(invalidServer.Close)
+
+			factory.GardenHomeDirectory = GinkgoT().TempDir()
+			o.IPDetectionURL = invalidServer.URL
+
+			Expect(o.Complete(factory, nil, nil)).To(MatchError(ContainSubstring("API returned an invalid IP (\"not an ip\")")))
+
+			Expect(filepath.Join(factory.GardenHomeDirectory, "cache", "public-ips.json")).NotTo(BeAnExistingFile())
+		})
+
+		It("should fail if the IP detection endpoint is not a valid URL", func() {
+			o.IPDetectionURL = "ftp://example.com"
+
+			Expect(o.Complete(factory, nil, nil)).To(MatchError(ContainSubstring("scheme must be http or https")))
+		})
+
+		Context("with a key of the SSH agent", func() {
+			var agentPublicKey cryptossh.PublicKey
+
+			BeforeEach(func() {
+				_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+				Expect(err).NotTo(HaveOccurred())
+
+				keyring := agent.NewKeyring()
+				Expect(keyring.Add(agent.AddedKey{PrivateKey: privateKey, Comment: "jane@example.org"})).To(Succeed())
+
+				signer, err := cryptossh.NewSignerFromKey(privateKey)
+				Expect(err).NotTo(HaveOccurred())
+				agentPublicKey = signer.PublicKey()
+
+				serveSSHAgent(keyring)
+			})
+
+			DescribeTable("should write the public key of the identity",
+				func(identity func() string) {
+					o.UseAgentKey = identity()
+
+					Expect(o.Complete(factory, nil, nil)).To(Succeed())
+					DeferCleanup(os.Remove, o.SSHPublicKeyFile.String())
+
+					Expect(o.WrittenAgentPublicKey).To(BeTrue())
+					Expect(o.GeneratedSSHKeys).To(BeFalse())
+					Expect(o.SSHPrivateKeyFile).To(BeEmpty())
+
+					content, err := os.ReadFile(o.SSHPublicKeyFile.String())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(content).To(Equal(cryptossh.MarshalAuthorizedKey(agentPublicKey)))
+				},
+				Entry("by comment", func() string { return "jane@example.org" }),
+				Entry("by fingerprint", func() string { return cryptossh.FingerprintSHA256(agentPublicKey) }),
+			)
+
+			It("should fail if the identity is not loaded into the SSH agent", func() {
+				o.UseAgentKey = "john@example.org"
+
+				Expect(o.Complete(factory, nil, nil)).To(MatchError(`no identity "john@example.org" found in the SSH agent`))
+			})
+
+			It("should fail without an SSH agent", func() {
+				GinkgoT().Setenv("SSH_AUTH_SOCK", "")
+				o.UseAgentKey = "jane@example.org"
+
+				Expect(o.Complete(factory, nil, nil)).To(MatchError(ContainSubstring("--use-agent-key requires a running SSH agent")))
+			})
+		})
+	})
+
+	Describe("Validate", func() {
+		var publicSSHKeyFile ssh.PublicKeyFile
+
+		BeforeEach(func() {
+			tmpFile, err := os.CreateTemp("", "")
+			Expect(err).NotTo(HaveOccurred())
+			defer tmpFile.Close()
+
+			// write dummy SSH public key
+			_, err = io.WriteString(tmpFile, "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDouNkxsNuApuKVIfgL6Yz3Ep+DqX84Yde9DArwLBSWgLnl/pH9AbbcDcAmdB2CPVXAATo4qxK7xprvyyZp52SQRCcAZpAy4D6gAWwAG3OfzrRbxRiB5pQDaaWATSzNbLtoy0ecVwFeTJe2w71q+wxbI7tfxbvo9XbXIN4I0cQy2KLICzkYkQmygGnHztv1Mvi338+sgcG7Gwq2tdSyggDaAggwDIuT39S4/L7QpR27tWH79J4Ls8tTHud2eRbkOcF98vXlQAIzb6w8iHBXylOjMM/oODwoA7V4mtRL9o13AoocvZSsD1UvfOjGxDHuLrCfFXN+/rEw0hEiYo0cnj7F")
+			Expect(err).NotTo(HaveOccurred())
+
+			publicSSHKeyFile = ssh.PublicKeyFile(tmpFile.Name())
+
+			o.CIDRs = []string{"8.8.8.8/32"}
+			o.SSHPublicKeyFile = publicSSHKeyFile
+		})
+
+		AfterEach(func() {
+			Expect(os.Remove(publicSSHKeyFile.String())).To(Succeed())
+		})
+
+		It("should validate", func() {
+			Expect(o.Validate()).To(Succeed())
+		})
+
+		It("should require a non-zero wait time", func() {
+			o.WaitTimeout = 0
+
+			Expect(o.Validate()).NotTo(Succeed())
+		})
+
+		It("should remove the generated keypair if the validation fails", func() {
+			tempDir := GinkgoT().TempDir()
+
+			privateKeyFile, publicKeyFile, err := ssh.CreateSSHKeypair(tempDir, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			o.SSHPublicKeyFile = publicKeyFile
+			o.SSHPrivateKeyFile = privateKeyFile
+			o.GeneratedSSHKeys = true
+			o.WaitForCleanup = true
+			o.KeepBastion = true
+
+			Expect(o.Validate()).To(MatchError("--wait-for-cleanup cannot be combined with --keep-bastion"))
+
+			Expect(o.GeneratedSSHKeys).To(BeFalse())
+			Expect(os.ReadDir(tempDir)).To(BeEmpty())
+		})
+
+		It("should not allow to combine the key of the SSH agent with a public key file", func() {
+			o.UseAgentKey = "jane@example.org"
+
+			Expect(o.Validate()).To(MatchError("--use-agent-key cannot be combined with --public-key-file or --private-key-file"))
+		})
+
+		It("should not allow to print a summary of a health check", func() {
+			o.Summary = true
+			o.Health = true
+
+			Expect(o.Validate()).To(MatchError("--summary cannot be combined with --health"))
+		})
+
+		It("should validate the port forward", func() {
+			o.PortForward = 16443
+
+			Expect(o.Validate()).To(Succeed())
+
+			o.PortForward = 70000
+			Expect(o.Validate()).To(MatchError("--port-forward must be a port between 1 and 65535"))
+
+			o.PortForward = 16443
+			o.NodeName = "node1"
+			Expect(o.Validate()).To(MatchError("--port-forward cannot be combined with a node"))
+		})
+
+		It("should require a non-zero condition timeout", func() {
+			o.ConditionTimeout = 0
+
+			Expect(o.Validate()).To(MatchError("the maximum duration to wait for the bastion to become ready must be non-zero"))
+		})
+
+		It("should not allow to wait for cleanup when keeping the bastion", func() {
+			o.WaitForCleanup = true
+			o.KeepBastion = true
+
+			Expect(o.Validate()).To(MatchError("--wait-for-cleanup cannot be combined with --keep-bastion"))
+		})
+
+		Context("identity agent", func() {
+			var socketDir string
+
+			BeforeEach(func() {
+				var err error
+
+				// the path of a unix socket is limited, hence the temporary directory of the test is not used
+				socketDir, err = os.MkdirTemp("", "agent")
+				Expect(err).NotTo(HaveOccurred())
+				DeferCleanup(os.RemoveAll, socketDir)
+			})
+
+			It("should accept a socket", func() {
+				o.IdentityAgent = filepath.Join(socketDir, "agent.sock")
+
+				listener, err := net.Listen("unix", o.IdentityAgent)
+				Expect(err).NotTo(HaveOccurred())
+				DeferCleanup(listener.Close)
+
+				Expect(o.Validate()).To(Succeed())
+			})
+
+			It("should reject a missing socket", func() {
+				o.IdentityAgent = filepath.Join(socketDir, "missing.sock")
+
+				Expect(o.Validate()).To(MatchError(ContainSubstring("invalid --identity-agent")))
+			})
+
+			It("should reject a regular file", func() {
+				o.IdentityAgent = filepath.Join(socketDir, "agent.sock")
+				Expect(os.WriteFile(o.IdentityAgent, nil, 0o600)).To(Succeed())
+
+				Expect(o.Validate()).To(MatchError(fmt.Sprintf("invalid --identity-agent: %q is not a socket", o.IdentityAgent)))
+			})
+		})
+
+		It("should require --keep-bastion for a TTL", func() {
+			o.KeepBastionTTL = time.Hour
+
+			Expect(o.Validate()).To(MatchError("set --keep-bastion when using --keep-bastion-ttl"))
+
+			o.KeepBastion = true
+			Expect(o.Validate()).To(Succeed())
+		})
+
+		It("should require non-interactive mode to refresh the node keys", func() {
+			o.Interactive = true
+			o.RefreshNodeKeys = true
+
+			Expect(o.Validate()).To(MatchError("set --interactive=false when using --refresh-node-keys"))
+
+			o.Interactive = false
+			Expect(o.Validate()).To(Succeed())
+		})
+
+		It("should reject a negative TTL", func() {
+			o.KeepBastion = true
+			o.KeepBastionTTL = -time.Hour
+
+			Expect(o.Validate()).To(MatchError("--keep-bastion-ttl must not be negative"))
+		})
+
+		It("should require interactive mode to reconnect", func() {
+			o.Interactive = false
+			o.Reconnect = true
+
+			Expect(o.Validate()).To(MatchError("--reconnect is only supported in interactive mode"))
+		})
+
+		It("should require at least one reconnect attempt", func() {
+			o.Interactive = true
+			o.Reconnect = true
+			o.ReconnectMax = 0
+
+			Expect(o.Validate()).To(MatchError("--reconnect-max must be at least 1"))
+		})
+
+		It("should require non-interactive mode for wide output", func() {
+			o.Interactive = true
+			o.Wide = true
+
+			Expect(o.Validate()).To(MatchError("set --interactive=false when using the wide flag"))
+		})
+
+		Context("no-keepalive", func() {
+			BeforeEach(func() {
+				o.NoKeepalive = true
+
 				o.KeepBastion = true
 				o.Interactive = false
 			})
@@ -952,63 +2716,718 @@ var _ = Describe("SSH Options", func() {
 			It("should require non-interactive mode", func() {
 				o.Interactive = true
 
-				Expect(o.Validate()).NotTo(Succeed())
-			})
+				Expect(o.Validate()).NotTo(Succeed())
+			})
+
+			It("should require keep bastion", func() {
+				o.KeepBastion = false
+
+				Expect(o.Validate()).NotTo(Succeed())
+			})
+		})
+
+		Describe("output flag not empty", func() {
+			BeforeEach(func() {
+				o.Output = "yaml" // or json - does not matter
+
+				o.Interactive = false
+			})
+
+			It("should validate", func() {
+				Expect(o.Validate()).Should(Succeed())
+			})
+
+			It("should require non-interactive mode", func() {
+				o.Interactive = true
+
+				Expect(o.Validate()).NotTo(Succeed())
+			})
+		})
+		It("should require a public SSH key file", func() {
+			o := ssh.NewSSHOptions(streams)
+			o.CIDRs = []string{"8.8.8.8/32"}
+
+			Expect(o.Validate()).NotTo(Succeed())
+		})
+
+		It("should require a valid public SSH key file", func() {
+			Expect(os.WriteFile(publicSSHKeyFile.String(), []byte("not a key"), 0o644)).To(Succeed())
+
+			o := ssh.NewSSHOptions(streams)
+			o.CIDRs = []string{"8.8.8.8/32"}
+			o.SSHPublicKeyFile = publicSSHKeyFile
+
+			Expect(o.Validate()).NotTo(Succeed())
+		})
+
+		Context("private key file provided", func() {
+			var tempDir string
+
+			BeforeEach(func() {
+				var err error
+
+				tempDir, err = os.MkdirTemp("", "gctlv2-keys-*")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				Expect(os.RemoveAll(tempDir)).To(Succeed())
+			})
+
+			It("should validate a matching key pair", func() {
+				privateKeyFile, publicKeyFile, err := ssh.CreateSSHKeypair(tempDir, "id_rsa")
+				Expect(err).NotTo(HaveOccurred())
+
+				o.SSHPublicKeyFile = publicKeyFile
+				o.SSHPrivateKeyFile = privateKeyFile
+
+				Expect(o.Validate()).To(Succeed())
+			})
+
+			It("should reject a mismatching key pair", func() {
+				privateKeyFile, _, err := ssh.CreateSSHKeypair(tempDir, "id_rsa")
+				Expect(err).NotTo(HaveOccurred())
+
+				o.SSHPrivateKeyFile = privateKeyFile
+
+				Expect(o.Validate()).To(MatchError("provided public and private keys do not match"))
+			})
+
+			It("should reject an invalid private key file", func() {
+				privateKeyFile := filepath.Join(tempDir, "id_rsa")
+				Expect(os.WriteFile(privateKeyFile, []byte("not a key"), 0o600)).To(Succeed())
+
+				o.SSHPrivateKeyFile = ssh.PrivateKeyFile(privateKeyFile)
+
+				Expect(o.Validate()).To(MatchError(ContainSubstring("invalid SSH private key file")))
+			})
+		})
+
+		It("should require at least one CIDR", func() {
+			o := ssh.NewSSHOptions(streams)
+			o.SSHPublicKeyFile = publicSSHKeyFile
+
+			Expect(o.Validate()).NotTo(Succeed())
+		})
+
+		It("should reject invalid CIDRs", func() {
+			o := ssh.NewSSHOptions(streams)
+			o.CIDRs = []string{"8.8.8.8"}
+			o.SSHPublicKeyFile = publicSSHKeyFile
+
+			Expect(o.Validate()).NotTo(Succeed())
+		})
+
+		It("should reject impersonated groups without an impersonated user", func() {
+			o := ssh.NewSSHOptions(streams)
+			o.CIDRs = []string{"8.8.8.8/32"}
+			o.SSHPublicKeyFile = publicSSHKeyFile
+			o.ImpersonateGroups = []string{"operators"}
+
+			Expect(o.Validate()).To(MatchError("--as-group requires --as to be set"))
+		})
+
+		It("should accept an impersonated user and groups", func() {
+			o := ssh.NewSSHOptions(streams)
+			o.CIDRs = []string{"8.8.8.8/32"}
+			o.SSHPublicKeyFile = publicSSHKeyFile
+			o.Impersonate = "jane"
+			o.ImpersonateGroups = []string{"operators"}
+
+			Expect(o.Validate()).To(Succeed())
+		})
+
+		It("should accept a custom node address preference", func() {
+			o := ssh.NewSSHOptions(streams)
+			o.CIDRs = []string{"8.8.8.8/32"}
+			o.SSHPublicKeyFile = publicSSHKeyFile
+			o.NodeAddressPreference = []string{"ExternalIP", "InternalIP"}
+
+			Expect(o.Validate()).To(Succeed())
+		})
 
-			It("should require keep bastion", func() {
-				o.KeepBastion = false
+		It("should reject an unknown node address type", func() {
+			o := ssh.NewSSHOptions(streams)
+			o.CIDRs = []string{"8.8.8.8/32"}
+			o.SSHPublicKeyFile = publicSSHKeyFile
+			o.NodeAddressPreference = []string{"ExternalIP", "PublicIP"}
 
-				Expect(o.Validate()).NotTo(Succeed())
-			})
+			Expect(o.Validate()).To(MatchError(ContainSubstring(`invalid node address type "PublicIP"`)))
 		})
 
-		Describe("output flag not empty", func() {
-			BeforeEach(func() {
-				o.Output = "yaml" // or json - does not matter
+		It("should reject a duplicate node address type", func() {
+			o := ssh.NewSSHOptions(streams)
+			o.CIDRs = []string{"8.8.8.8/32"}
+			o.SSHPublicKeyFile = publicSSHKeyFile
+			o.NodeAddressPreference = []string{"ExternalIP", "ExternalIP"}
 
-				o.Interactive = false
-			})
+			Expect(o.Validate()).To(MatchError(`duplicate node address type "ExternalIP"`))
+		})
 
-			It("should validate", func() {
-				Expect(o.Validate()).Should(Succeed())
-			})
+		It("should reject an unknown node IP family", func() {
+			o := ssh.NewSSHOptions(streams)
+			o.CIDRs = []string{"8.8.8.8/32"}
+			o.SSHPublicKeyFile = publicSSHKeyFile
+			o.NodeIPFamily = "IPv6"
 
-			It("should require non-interactive mode", func() {
-				o.Interactive = true
+			Expect(o.Validate()).To(MatchError(`invalid node IP family "IPv6", must be one of "ipv4" or "ipv6"`))
+		})
 
-				Expect(o.Validate()).NotTo(Succeed())
-			})
+		It("should reject an invalid exec template", func() {
+			o := ssh.NewSSHOptions(streams)
+			o.CIDRs = []string{"8.8.8.8/32"}
+			o.SSHPublicKeyFile = publicSSHKeyFile
+			o.Interactive = true
+			o.ExecTemplate = "ssh {{ .User"
+
+			Expect(o.Validate()).To(MatchError(ContainSubstring("invalid exec template:")))
 		})
-		It("should require a public SSH key file", func() {
+
+		It("should reject an exec template in non-interactive mode", func() {
 			o := ssh.NewSSHOptions(streams)
 			o.CIDRs = []string{"8.8.8.8/32"}
+			o.SSHPublicKeyFile = publicSSHKeyFile
+			o.Interactive = false
+			o.ExecTemplate = "ssh"
 
-			Expect(o.Validate()).NotTo(Succeed())
+			Expect(o.Validate()).To(MatchError("--exec-template is only supported in interactive mode"))
 		})
 
-		It("should require a valid public SSH key file", func() {
-			Expect(os.WriteFile(publicSSHKeyFile.String(), []byte("not a key"), 0o644)).To(Succeed())
+		It("should reject a provider ID together with a node name", func() {
+			o := ssh.NewSSHOptions(streams)
+			o.CIDRs = []string{"8.8.8.8/32"}
+			o.SSHPublicKeyFile = publicSSHKeyFile
+			o.NodeName = "node1"
+			o.ProviderID = "aws:///eu-west-1a/i-0123456789abcdef0"
+
+			Expect(o.Validate()).To(MatchError("--provider-id cannot be combined with a node name"))
+		})
 
+		It("should validate the bastion labels", func() {
 			o := ssh.NewSSHOptions(streams)
 			o.CIDRs = []string{"8.8.8.8/32"}
 			o.SSHPublicKeyFile = publicSSHKeyFile
+			o.BastionLabels = map[string]string{"cost-center": "12345"}
 
-			Expect(o.Validate()).NotTo(Succeed())
+			Expect(o.Validate()).To(Succeed())
+
+			o.BastionLabels = map[string]string{"cost center": "12345"}
+			Expect(o.Validate()).To(MatchError(HavePrefix(`invalid bastion label key "cost center":`)))
+
+			o.BastionLabels = map[string]string{"cost-center": "not valid"}
+			Expect(o.Validate()).To(MatchError(HavePrefix(`invalid value of bastion label "cost-center":`)))
 		})
 
-		It("should require at least one CIDR", func() {
+		It("should validate the pod reference", func() {
 			o := ssh.NewSSHOptions(streams)
+			o.CIDRs = []string{"8.8.8.8/32"}
 			o.SSHPublicKeyFile = publicSSHKeyFile
+			o.Pod = "kube-system/coredns"
 
-			Expect(o.Validate()).NotTo(Succeed())
+			Expect(o.Validate()).To(Succeed())
+
+			o.Pod = "coredns"
+			Expect(o.Validate()).To(MatchError(`invalid pod "coredns", must be in the format namespace/name`))
+
+			o.Pod = "kube-system/coredns"
+			o.NodeName = "node1"
+			Expect(o.Validate()).To(MatchError("--pod cannot be combined with a node name or --provider-id"))
 		})
 
-		It("should reject invalid CIDRs", func() {
+		It("should not require CIDRs or a public key file without a bastion", func() {
 			o := ssh.NewSSHOptions(streams)
-			o.CIDRs = []string{"8.8.8.8"}
+			o.NoBastion = true
+			o.NodeName = "node1"
+
+			Expect(o.Validate()).To(Succeed())
+		})
+
+		It("should require a node name without a bastion", func() {
+			o := ssh.NewSSHOptions(streams)
+			o.NoBastion = true
+
+			Expect(o.Validate()).To(MatchError("--no-bastion requires a node name, hostname or IP address"))
+		})
+
+		It("should reject the flags that require a bastion without a bastion", func() {
+			cmd := ssh.NewCmdSSH(internalfake.NewFakeFactory(nil, nil, nil, nil), ssh.NewSSHOptions(streams))
+			cmd.SetArgs([]string{"node1", "--no-bastion", "--health"})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			Expect(cmd.Execute()).To(MatchError(ContainSubstring("if any flags in the group [no-bastion health] are set none of the others can be")))
+		})
+
+		It("should reject non-interactive mode without a bastion", func() {
+			o := ssh.NewSSHOptions(streams)
+			o.NoBastion = true
+			o.NodeName = "node1"
+			o.Interactive = false
+
+			Expect(o.Validate()).To(MatchError("--no-bastion is only supported in interactive mode"))
+		})
+
+		It("should reject an invalid https proxy", func() {
+			o := ssh.NewSSHOptions(streams)
+			o.CIDRs = []string{"8.8.8.8/32"}
 			o.SSHPublicKeyFile = publicSSHKeyFile
+			o.HTTPSProxy = "socks5://proxy.example.com:1080"
 
-			Expect(o.Validate()).NotTo(Succeed())
+			Expect(o.Validate()).To(MatchError(ContainSubstring("scheme must be http")))
 		})
 	})
 })
+
+var _ = Describe("Bastion ingress policies", func() {
+	var (
+		o     *ssh.SSHOptions
+		shoot *gardencorev1beta1.Shoot
+	)
+
+	BeforeEach(func() {
+		streams, _, _, _ := util.NewTestIOStreams()
+		o = ssh.NewSSHOptions(streams)
+		o.CIDRs = []string{"8.8.8.8/32", "2001:db8::/64"}
+		o.AutoDetected = true
+
+		shoot = &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-shoot"},
+			Spec: gardencorev1beta1.ShootSpec{
+				Provider: gardencorev1beta1.Provider{Type: "aws"},
+				Networking: &gardencorev1beta1.Networking{
+					Nodes: ptr.To("10.250.0.0/16"),
+				},
+			},
+		}
+	})
+
+	cidrsOf := func(policies []operationsv1alpha1.BastionIngressPolicy) []string {
+		var cidrs []string
+		for _, policy := range policies {
+			cidrs = append(cidrs, policy.IPBlock.CIDR)
+		}
+
+		return cidrs
+	}
+
+	It("should not include the node CIDR by default", func() {
+		policies, err := o.BastionIngressPolicies(klog.Background(), shoot)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cidrsOf(policies)).To(Equal([]string{"8.8.8.8/32", "2001:db8::/64"}))
+	})
+
+	It("should include the node CIDR", func() {
+		o.AllowNodeCIDR = true
+
+		policies, err := o.BastionIngressPolicies(klog.Background(), shoot)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cidrsOf(policies)).To(Equal([]string{"8.8.8.8/32", "2001:db8::/64", "10.250.0.0/16"}))
+	})
+
+	It("should skip an IPv6 node CIDR for GCP", func() {
+		o.AllowNodeCIDR = true
+		shoot.Spec.Provider.Type = "gcp"
+		shoot.Spec.Networking.Nodes = ptr.To("2001:db8:1::/64")
+
+		policies, err := o.BastionIngressPolicies(klog.Background(), shoot)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cidrsOf(policies)).To(Equal([]string{"8.8.8.8/32"}))
+	})
+
+	It("should fail if the shoot has no node CIDR", func() {
+		o.AllowNodeCIDR = true
+		shoot.Spec.Networking = nil
+
+		_, err := o.BastionIngressPolicies(klog.Background(), shoot)
+		Expect(err).To(MatchError("shoot test-shoot has no node network CIDR"))
+	})
+})
+
+// exitCodeError is an error with an exit code, like the error returned for a failed ssh command.
+type exitCodeError int
+
+func (e exitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", int(e))
+}
+
+func (e exitCodeError) ExitCode() int {
+	return int(e)
+}
+
+var _ = Describe("getNodeHostname", func() {
+	var dualStackNode *corev1.Node
+
+	BeforeEach(func() {
+		dualStackNode = &corev1.Node{
+			Status: corev1.NodeStatus{
+				Addresses: []corev1.NodeAddress{
+					{Type: corev1.NodeInternalDNS, Address: "node1.internal"},
+					{Type: corev1.NodeInternalIP, Address: "10.250.0.5"},
+					{Type: corev1.NodeInternalIP, Address: "2001:db8::5"},
+					{Type: corev1.NodeExternalIP, Address: "203.0.113.5"},
+				},
+			},
+		}
+	})
+
+	DescribeTable("selecting the node address",
+		func(preference []corev1.NodeAddressType, ipFamily string, expected string) {
+			hostname, err := ssh.GetNodeHostname(dualStackNode, preference, ipFamily)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hostname).To(Equal(expected))
+		},
+		Entry("first address of the preferred type", []corev1.NodeAddressType{corev1.NodeInternalIP}, "", "10.250.0.5"),
+		Entry("IPv4 address of the preferred type", []corev1.NodeAddressType{corev1.NodeInternalIP}, "ipv4", "10.250.0.5"),
+		Entry("IPv6 address of the preferred type", []corev1.NodeAddressType{corev1.NodeInternalIP}, "ipv6", "2001:db8::5"),
+		Entry("DNS name without IP family", []corev1.NodeAddressType{corev1.NodeInternalDNS, corev1.NodeInternalIP}, "", "node1.internal"),
+		Entry("skip DNS names with IP family", []corev1.NodeAddressType{corev1.NodeInternalDNS, corev1.NodeInternalIP}, "ipv6", "2001:db8::5"),
+		Entry("fall back to the next type with the IP family", []corev1.NodeAddressType{corev1.NodeExternalIP, corev1.NodeInternalIP}, "ipv6", "2001:db8::5"),
+	)
+
+	It("should fail if the node has no address of the requested family", func() {
+		_, err := ssh.GetNodeHostname(dualStackNode, []corev1.NodeAddressType{corev1.NodeExternalIP}, "ipv6")
+		Expect(err).To(MatchError("node has no ipv6 address of type [ExternalIP]"))
+	})
+})
+
+var _ = Describe("getShootNodeByProviderID", func() {
+	const providerID = "aws:///eu-west-1a/i-0123456789abcdef0"
+
+	newNode := func(name, providerID string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.NodeSpec{ProviderID: providerID},
+		}
+	}
+
+	It("should return the node with the matching provider ID", func() {
+		c := internalfake.NewClientWithObjects(newNode("node1", "aws:///eu-west-1a/i-other"), newNode("node2", providerID))
+
+		node, err := ssh.GetShootNodeByProviderID(context.Background(), c, providerID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(node.Name).To(Equal("node2"))
+	})
+
+	It("should fail if the provider ID is ambiguous", func() {
+		c := internalfake.NewClientWithObjects(newNode("node1", providerID), newNode("node2", providerID))
+
+		_, err := ssh.GetShootNodeByProviderID(context.Background(), c, providerID)
+		Expect(err).To(MatchError(fmt.Sprintf("provider ID %q is ambiguous, it matches the nodes node1, node2", providerID)))
+	})
+})
+
+var _ = Describe("keepBastionAlive", func() {
+	It("should retry the keepalive patch on conflict", func() {
+		bastion := &operationsv1alpha1.Bastion{
+			ObjectMeta: metav1.ObjectMeta{Name: "cli-xxxxxx", Namespace: "garden-prod1"},
+		}
+
+		var (
+			patches  atomic.Int32
+			conflict atomic.Bool
+		)
+
+		c := fakeclient.NewClientBuilder().
+			WithObjects(bastion.DeepCopy()).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if patches.Add(1) == 1 {
+						// modify the bastion concurrently, so that the patch is based on an outdated resourceVersion
+						current := &operationsv1alpha1.Bastion{}
+						if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+							return err
+						}
+
+						current.Labels = map[string]string{"concurrent": "change"}
+						if err := c.Update(ctx, current); err != nil {
+							return err
+						}
+
+						err := c.Patch(ctx, obj, patch, opts...)
+						conflict.Store(apierrors.IsConflict(err))
+
+						return err
+					}
+
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}).
+			Build()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ssh.SetKeepAliveInterval(10 * time.Millisecond)
+
+		go ssh.KeepBastionAlive(ctx, cancel, c, bastion.DeepCopy())
+
+		Eventually(func(g Gomega) {
+			current := &operationsv1alpha1.Bastion{}
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(bastion), current)).To(Succeed())
+			g.Expect(current.Annotations).To(HaveKeyWithValue(corev1beta1constants.GardenerOperation, corev1beta1constants.GardenerOperationKeepalive))
+			g.Expect(current.Labels).To(HaveKeyWithValue("concurrent", "change"))
+		}).Should(Succeed())
+
+		Expect(conflict.Load()).To(BeTrue())
+		Expect(patches.Load()).To(BeNumerically(">=", 2))
+	})
+})
+
+var _ = Describe("getShootNodeByPod", func() {
+	var node *corev1.Node
+
+	newPod := func(name, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+		}
+	}
+
+	BeforeEach(func() {
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+	})
+
+	It("should return the node the pod is scheduled on", func() {
+		c := internalfake.NewClientWithObjects(node, newPod("pod1", "node1"))
+
+		n, err := ssh.GetShootNodeByPod(context.Background(), c, "default/pod1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n.Name).To(Equal("node1"))
+	})
+
+	It("should fail if the pod is not scheduled", func() {
+		c := internalfake.NewClientWithObjects(node, newPod("pod1", ""))
+
+		_, err := ssh.GetShootNodeByPod(context.Background(), c, "default/pod1")
+		Expect(err).To(MatchError(`pod "default/pod1" is not scheduled on a node`))
+	})
+
+	It("should fail if the node of the pod does not exist", func() {
+		c := internalfake.NewClientWithObjects(newPod("pod1", "node2"))
+
+		_, err := ssh.GetShootNodeByPod(context.Background(), c, "default/pod1")
+		Expect(err).To(MatchError(HavePrefix(`failed to get node "node2" of pod "default/pod1":`)))
+	})
+})
+
+var _ = Describe("resolveControlPlaneTarget", func() {
+	var (
+		ctx           context.Context
+		ctrl          *gomock.Controller
+		manager       *targetmocks.MockManager
+		gardenClient  *gardenclientmocks.MockClient
+		currentTarget target.Target
+		shoot         *gardencorev1beta1.Shoot
+	)
+
+	newAPIServerPod := func(name, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "shoot--prod1--test-shoot",
+				Labels:    map[string]string{"app": "kubernetes", "role": "apiserver"},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl = gomock.NewController(GinkgoT())
+		manager = targetmocks.NewMockManager(ctrl)
+		gardenClient = gardenclientmocks.NewMockClient(ctrl)
+
+		currentTarget = target.NewTarget("test", "prod1", "", "test-shoot").WithControlPlane(true)
+		shoot = &gardencorev1beta1.Shoot{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-shoot", Namespace: "garden-prod1"},
+			Spec:       gardencorev1beta1.ShootSpec{SeedName: ptr.To("test-seed")},
+			Status:     gardencorev1beta1.ShootStatus{TechnicalID: "shoot--prod1--test-shoot"},
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should return the seed target and the node of the kube-apiserver", func() {
+		seedClient := internalfake.NewClientWithObjects(
+			newAPIServerPod("kube-apiserver-pending", ""),
+			newAPIServerPod("kube-apiserver-0", "seed-node-1"),
+		)
+		gardenClient.EXPECT().FindShoot(ctx, currentTarget.AsListOption()).Return(shoot, nil)
+		manager.EXPECT().SeedClient(ctx, target.NewTarget("test", "", "test-seed", "")).Return(seedClient, nil)
+
+		seedTarget, nodeName, err := ssh.ResolveControlPlaneTarget(ctx, manager, gardenClient, currentTarget)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(seedTarget.GardenName()).To(Equal("test"))
+		Expect(seedTarget.SeedName()).To(Equal("test-seed"))
+		Expect(seedTarget.ShootName()).To(BeEmpty())
+		Expect(nodeName).To(Equal("seed-node-1"))
+	})
+
+	It("should fail if the shoot is not scheduled to a seed", func() {
+		shoot.Spec.SeedName = nil
+		gardenClient.EXPECT().FindShoot(ctx, currentTarget.AsListOption()).Return(shoot, nil)
+
+		_, _, err := ssh.ResolveControlPlaneTarget(ctx, manager, gardenClient, currentTarget)
+		Expect(err).To(MatchError(`shoot "test-shoot" has not yet been scheduled to a seed`))
+	})
+
+	It("should fail if no kube-apiserver pod is running", func() {
+		seedClient := internalfake.NewClientWithObjects()
+		gardenClient.EXPECT().FindShoot(ctx, currentTarget.AsListOption()).Return(shoot, nil)
+		manager.EXPECT().SeedClient(ctx, gomock.Any()).Return(seedClient, nil)
+
+		_, _, err := ssh.ResolveControlPlaneTarget(ctx, manager, gardenClient, currentTarget)
+		Expect(err).To(MatchError(`no kube-apiserver pod of shoot "test-shoot" is scheduled on a node of seed "test-seed"`))
+	})
+
+	It("should fail if no shoot is targeted", func() {
+		_, _, err := ssh.ResolveControlPlaneTarget(ctx, manager, gardenClient, target.NewTarget("test", "prod1", "", ""))
+		Expect(err).To(MatchError(target.ErrNoShootTargeted))
+	})
+})
+
+var _ = Describe("renderExecTemplate", func() {
+	var data *ssh.ExecTemplateData
+
+	BeforeEach(func() {
+		data = &ssh.ExecTemplateData{
+			BastionHost:         "bastion.example.com",
+			BastionPort:         "22",
+			BastionUser:         ssh.SSHBastionUsername,
+			NodeHostname:        "10.250.0.5",
+			NodePrivateKeyFiles: []string{"/tmp/node key 1", "/tmp/node-key-2"},
+			User:                "gardener",
+		}
+	})
+
+	It("should render one argument per line", func() {
+		command, args, err := ssh.RenderExecTemplate(`ssh
+-J{{ .BastionUser }}@{{ .BastionHost }}:{{ .BastionPort }}
+{{ range .NodePrivateKeyFiles }}-i{{ . }}
+{{ end }}
+{{ .User }}@{{ .NodeHostname }}`, data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(command).To(Equal("ssh"))
+		Expect(args).To(Equal([]string{
+			"-Jgardener@bastion.example.com:22",
+			"-i/tmp/node key 1",
+			"-i/tmp/node-key-2",
+			"gardener@10.250.0.5",
+		}))
+	})
+
+	It("should not interpret shell syntax", func() {
+		data.User = "gardener; rm -rf /"
+
+		command, args, err := ssh.RenderExecTemplate("ssh\n{{ .User }}@{{ .NodeHostname }}", data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(command).To(Equal("ssh"))
+		Expect(args).To(Equal([]string{"gardener; rm -rf /@10.250.0.5"}))
+	})
+
+	It("should fail for unknown fields", func() {
+		_, _, err := ssh.RenderExecTemplate("ssh\n{{ .Unknown }}", data)
+		Expect(err).To(MatchError(ContainSubstring("failed to render exec template")))
+	})
+
+	It("should fail if the rendered template is empty", func() {
+		_, _, err := ssh.RenderExecTemplate("{{ if false }}ssh{{ end }}", data)
+		Expect(err).To(MatchError("the rendered exec template is empty"))
+	})
+})
+
+var _ = Describe("checkAccessRestrictions", func() {
+	var (
+		streams util.IOStreams
+		errOut  *util.SafeBytesBuffer
+		cfg     *config.Config
+		tf      target.TargetFlags
+		shoot   *gardencorev1beta1.Shoot
+	)
+
+	BeforeEach(func() {
+		streams, _, _, errOut = util.NewTestIOStreams()
+
+		cfg = &config.Config{
+			Gardens: []config.Garden{{
+				Name: "test",
+				AccessRestrictions: []ac.AccessRestriction{
+					{
+						Key: "eu-access-only",
+						Msg: "Do not access from outside the EU",
+						Options: []ac.AccessRestrictionOption{{
+							Key:      "support.gardener.cloud/eu-access-for-cluster-nodes",
+							NotifyIf: false,
+							Msg:      "Nodes are not restricted",
+						}},
+					},
+					{
+						Key: "other",
+						Msg: "Other restriction",
+					},
+				},
+			}},
+		}
+		tf = target.NewTargetFlags("", "", "", "", false)
+
+		shoot = &gardencorev1beta1.Shoot{
+			Spec: gardencorev1beta1.ShootSpec{
+				AccessRestrictions: []gardencorev1beta1.AccessRestrictionWithOptions{{
+					AccessRestriction: gardencorev1beta1.AccessRestriction{Name: "eu-access-only"},
+					Options: map[string]string{
+						"support.gardener.cloud/eu-access-for-cluster-nodes": "false",
+					},
+				}},
+			},
+		}
+	})
+
+	It("should explain the matching access restriction on stderr", func() {
+		Expect(ssh.CheckAccessRestrictions(streams, false, true, cfg, "test", tf, shoot)).To(BeTrue())
+
+		Expect(errOut.String()).To(HavePrefix(`Access restriction "eu-access-only": Do not access from outside the EU
+  triggered by spec.accessRestrictions[].name=eu-access-only
+  Option "support.gardener.cloud/eu-access-for-cluster-nodes": Nodes are not restricted
+    triggered by spec.accessRestrictions[name=eu-access-only].options[support.gardener.cloud/eu-access-for-cluster-nodes]=false
+`))
+		Expect(errOut.String()).NotTo(ContainSubstring("Other restriction"))
+	})
+
+	It("should not explain the access restrictions unless requested", func() {
+		Expect(ssh.CheckAccessRestrictions(streams, false, false, cfg, "test", tf, shoot)).To(BeTrue())
+
+		Expect(errOut.String()).NotTo(ContainSubstring("triggered by"))
+		Expect(errOut.String()).To(ContainSubstring("Do not access from outside the EU"))
+	})
+})
+
+// serveSSHAgent serves the given keyring as SSH agent and points SSH_AUTH_SOCK to it for the current spec.
+func serveSSHAgent(keyring agent.Agent) {
+	// unix socket paths are limited in length, hence the short directory name
+	dir, err := os.MkdirTemp("", "agent")
+	Expect(err).NotTo(HaveOccurred())
+	DeferCleanup(os.RemoveAll, dir)
+
+	socket := filepath.Join(dir, "agent.sock")
+	listener, err := net.Listen("unix", socket)
+	Expect(err).NotTo(HaveOccurred())
+	DeferCleanup(listener.Close)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				_ = agent.ServeAgent(keyring, conn)
+			}()
+		}
+	}()
+
+	GinkgoT().Setenv("SSH_AUTH_SOCK", socket)
+}
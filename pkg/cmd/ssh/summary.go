@@ -0,0 +1,82 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ssh
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SessionSummary summarizes what an ssh session created and used. It is printed after the session ended.
+type SessionSummary struct {
+	// Bastion is the name of the Bastion resource.
+	Bastion string `json:"bastion"`
+	// Namespace is the namespace of the Bastion resource.
+	Namespace string `json:"namespace"`
+	// Duration is the time the bastion was alive during the session.
+	Duration string `json:"duration"`
+	// Kept is true if the bastion is kept after gardenctl exits.
+	Kept bool `json:"kept"`
+	// Node is the name, hostname or IP address of the node that was connected to.
+	Node string `json:"node,omitempty"`
+	// BastionPrivateKeyFile is the path of the private key file used for the bastion.
+	// It is empty if the private key is provided by the SSH agent.
+	BastionPrivateKeyFile string `json:"bastionPrivateKeyFile,omitempty"`
+	// NodePrivateKeyFiles are the paths of the private key files used for the node.
+	NodePrivateKeyFiles []string `json:"nodePrivateKeyFiles,omitempty"`
+}
+
+var _ fmt.Stringer = &SessionSummary{}
+
+// NewSessionSummary returns a new SessionSummary for the given bastion.
+func NewSessionSummary(name, namespace string, duration time.Duration, kept bool, node string, sshPrivateKeyFile PrivateKeyFile, nodePrivateKeyFiles []PrivateKeyFile) *SessionSummary {
+	summary := &SessionSummary{
+		Bastion:               name,
+		Namespace:             namespace,
+		Duration:              duration.Round(time.Second).String(),
+		Kept:                  kept,
+		Node:                  node,
+		BastionPrivateKeyFile: sshPrivateKeyFile.String(),
+	}
+
+	for _, file := range nodePrivateKeyFiles {
+		summary.NodePrivateKeyFiles = append(summary.NodePrivateKeyFiles, file.String())
+	}
+
+	return summary
+}
+
+func (s *SessionSummary) String() string {
+	var sb strings.Builder
+
+	fmt.Fprintln(&sb, "> Session summary")
+	fmt.Fprintf(&sb, "> Bastion: %s/%s\n", s.Namespace, s.Bastion)
+	fmt.Fprintf(&sb, "> Alive for: %s\n", s.Duration)
+
+	if s.Kept {
+		fmt.Fprintln(&sb, "> Kept: yes, delete the bastion when you no longer need it")
+	} else {
+		fmt.Fprintln(&sb, "> Kept: no")
+	}
+
+	if s.Node != "" {
+		fmt.Fprintf(&sb, "> Node: %s\n", s.Node)
+	}
+
+	if s.BastionPrivateKeyFile != "" {
+		fmt.Fprintf(&sb, "> Bastion private key file: %s\n", s.BastionPrivateKeyFile)
+	} else {
+		fmt.Fprintln(&sb, "> Bastion private key: provided by SSH agent")
+	}
+
+	for _, file := range s.NodePrivateKeyFiles {
+		fmt.Fprintf(&sb, "> Node private key file: %s\n", file)
+	}
+
+	return sb.String()
+}
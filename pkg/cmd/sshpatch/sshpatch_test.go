@@ -27,6 +27,7 @@ import (
 	"github.com/gardener/gardenctl-v2/internal/util"
 	utilmocks "github.com/gardener/gardenctl-v2/internal/util/mocks"
 	"github.com/gardener/gardenctl-v2/pkg/cmd/sshpatch"
+	"github.com/gardener/gardenctl-v2/pkg/config"
 	"github.com/gardener/gardenctl-v2/pkg/target"
 	targetmocks "github.com/gardener/gardenctl-v2/pkg/target/mocks"
 )
@@ -151,6 +152,7 @@ var _ = Describe("SSH Patch Command", func() {
 		manager = targetmocks.NewMockManager(ctrl)
 		manager.EXPECT().CurrentTarget().Return(currentTarget, nil).AnyTimes()
 		manager.EXPECT().GardenClient(gomock.Eq(gardenName)).Return(gardenClient, nil).AnyTimes()
+		manager.EXPECT().Configuration().Return(&config.Config{}).AnyTimes()
 
 		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
 		clock = utilmocks.NewMockClock(ctrl)
@@ -160,6 +162,7 @@ var _ = Describe("SSH Patch Command", func() {
 		factory.EXPECT().TargetFlags().Return(targetFlags).AnyTimes()
 		factory.EXPECT().Context().Return(ctx).AnyTimes()
 		factory.EXPECT().Clock().Return(clock).AnyTimes()
+		factory.EXPECT().GardenHomeDir().Return("").AnyTimes()
 		fakeIPs := []string{"192.0.2.42", "2001:db8::8a2e:370:7334"}
 		factory.EXPECT().PublicIPs(isCtx).Return(fakeIPs, nil).AnyTimes()
 	})
@@ -0,0 +1,101 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package target
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/cmd/base"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+// NewCmdBack returns a new (target) back command.
+func NewCmdBack(f util.Factory, ioStreams util.IOStreams) *cobra.Command {
+	o := &BackOptions{
+		Options: base.Options{
+			IOStreams: ioStreams,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:   "back",
+		Short: "Restore the previous target",
+		Long: `Restore the previous target from the target history.
+The history keeps the last targets of the current session. The current target is added to the history, so that running the command
+again switches back to it, like "cd -".`,
+		Example: `# switch from shoot "my-shoot" to garden "my-other-garden" and back to the shoot again
+gardenctl target shoot my-shoot
+gardenctl target garden my-other-garden
+gardenctl target back
+
+# switch to garden "my-other-garden" again
+gardenctl target back`,
+		Args: cobra.NoArgs,
+		RunE: base.WrapRunE(o, f),
+	}
+
+	o.AddFlags(cmd.Flags())
+	o.RegisterCompletionsForOutputFlag(cmd)
+
+	return cmd
+}
+
+// BackOptions is a struct to support back command.
+type BackOptions struct {
+	base.Options
+}
+
+// Run executes the command.
+func (o *BackOptions) Run(f util.Factory) error {
+	manager, err := f.Manager()
+	if err != nil {
+		return err
+	}
+
+	t, err := manager.TargetBack(f.Context())
+	if err != nil {
+		return fmt.Errorf("failed to restore previous target: %w", err)
+	}
+
+	if o.Output != "" {
+		return o.PrintObject(t)
+	}
+
+	fmt.Fprintf(o.IOStreams.Out, "Successfully restored previous target %s\n", describeTarget(t))
+
+	return nil
+}
+
+// describeTarget returns a short human readable description of the given target.
+func describeTarget(t target.Target) string {
+	var parts []string
+
+	if t.GardenName() != "" {
+		parts = append(parts, fmt.Sprintf("garden %q", t.GardenName()))
+	}
+
+	if t.ProjectName() != "" {
+		parts = append(parts, fmt.Sprintf("project %q", t.ProjectName()))
+	}
+
+	if t.SeedName() != "" {
+		parts = append(parts, fmt.Sprintf("seed %q", t.SeedName()))
+	}
+
+	if t.ShootName() != "" {
+		parts = append(parts, fmt.Sprintf("shoot %q", t.ShootName()))
+	}
+
+	if t.ControlPlane() {
+		parts = append(parts, "control plane")
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,72 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package target_test
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	utilmocks "github.com/gardener/gardenctl-v2/internal/util/mocks"
+	cmdtarget "github.com/gardener/gardenctl-v2/pkg/cmd/target"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+	targetmocks "github.com/gardener/gardenctl-v2/pkg/target/mocks"
+)
+
+var _ = Describe("Target Back Command", func() {
+	var (
+		streams util.IOStreams
+		out     *util.SafeBytesBuffer
+		ctrl    *gomock.Controller
+		factory *utilmocks.MockFactory
+		manager *targetmocks.MockManager
+		ctx     context.Context
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		factory = utilmocks.NewMockFactory(ctrl)
+		manager = targetmocks.NewMockManager(ctrl)
+		ctx = context.Background()
+
+		factory.EXPECT().Manager().Return(manager, nil)
+		factory.EXPECT().Context().Return(ctx)
+
+		streams, _, out, _ = util.NewTestIOStreams()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should restore the previous target", func() {
+		manager.EXPECT().TargetBack(ctx).Return(target.NewTarget("mygarden", "myproject", "", "myshoot"), nil)
+
+		cmd := cmdtarget.NewCmdBack(factory, streams)
+		Expect(cmd.RunE(cmd, nil)).To(Succeed())
+		Expect(out.String()).To(Equal("Successfully restored previous target garden \"mygarden\", project \"myproject\", shoot \"myshoot\"\n"))
+	})
+
+	It("should print the previous target in yaml format", func() {
+		manager.EXPECT().TargetBack(ctx).Return(target.NewTarget("mygarden", "", "myseed", ""), nil)
+
+		cmd := cmdtarget.NewCmdBack(factory, streams)
+		Expect(cmd.Flags().Set("output", "yaml")).To(Succeed())
+		Expect(cmd.RunE(cmd, nil)).To(Succeed())
+		Expect(out.String()).To(Equal("garden: mygarden\nseed: myseed\n"))
+	})
+
+	It("should fail if there is no previous target", func() {
+		manager.EXPECT().TargetBack(ctx).Return(nil, target.ErrNoPreviousTarget)
+
+		cmd := cmdtarget.NewCmdBack(factory, streams)
+		Expect(cmd.RunE(cmd, nil)).To(MatchError("failed to restore previous target: no previous target"))
+	})
+})
@@ -6,4 +6,12 @@ SPDX-License-Identifier: Apache-2.0
 
 package target
 
+import "io"
+
 var ValidTargetArgsFunction = validTargetArgsFunction
+
+var IsTerminalReader = isTerminalReader
+
+func SetIsTerminalReader(f func(in io.Reader) bool) {
+	isTerminalReader = f
+}
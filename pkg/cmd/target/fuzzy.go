@@ -0,0 +1,110 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package target
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+// isTerminalReader checks if the io.Reader is connected to a terminal.
+var isTerminalReader = func(in io.Reader) bool {
+	return util.IsTerminal(in)
+}
+
+// resolveFuzzyShootName returns the name of the shoot of the current target that contains the given
+// partial name, ignoring the case. A shoot with exactly the given name is always preferred. If several
+// shoots match, the user selects one of them by number, unless the input is not a terminal.
+func (o *TargetOptions) resolveFuzzyShootName(ctx context.Context, manager target.Manager) (string, error) {
+	names, err := manager.ShootNames(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var matches []string
+
+	for _, name := range names {
+		if name == o.TargetName {
+			return name, nil
+		}
+
+		if strings.Contains(strings.ToLower(name), strings.ToLower(o.TargetName)) {
+			matches = append(matches, name)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no shoot matches %q", o.TargetName)
+	case 1:
+		return matches[0], nil
+	}
+
+	if !isTerminalReader(o.IOStreams.In) {
+		return "", fmt.Errorf("%q matches several shoots, must be one of %s", o.TargetName, strings.Join(matches, ", "))
+	}
+
+	return selectShootName(o.IOStreams.In, o.IOStreams.Out, matches)
+}
+
+// selectShootName prints the given names as a numbered list and asks for the number of one of them.
+// An empty answer aborts the selection with target.ErrAborted.
+func selectShootName(in io.Reader, out io.Writer, names []string) (string, error) {
+	fmt.Fprintln(out, "Several shoots match:")
+
+	for i, name := range names {
+		fmt.Fprintf(out, "%3d) %s\n", i+1, name)
+	}
+
+	for {
+		fmt.Fprintf(out, "Select a shoot [1-%d]: ", len(names))
+
+		answer, err := readLine(in)
+		answer = strings.TrimSpace(answer)
+
+		if answer == "" {
+			return "", target.ErrAborted
+		}
+
+		if i, convErr := strconv.Atoi(answer); convErr == nil && i >= 1 && i <= len(names) {
+			return names[i-1], nil
+		}
+
+		if err != nil {
+			return "", target.ErrAborted
+		}
+	}
+}
+
+// readLine reads a single line from in. It reads byte by byte, so that the remaining input is left
+// for later prompts, e.g. the confirmation of access restrictions.
+func readLine(in io.Reader) (string, error) {
+	var line strings.Builder
+
+	buf := make([]byte, 1)
+
+	for {
+		n, err := in.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				return line.String(), nil
+			}
+
+			line.WriteByte(buf[0])
+		}
+
+		if err != nil {
+			return line.String(), err
+		}
+	}
+}
@@ -31,5 +31,7 @@ gardenctl target garden my-garden`,
 		RunE:              base.WrapRunE(o, f),
 	}
 
+	o.addOutputFlag(cmd)
+
 	return cmd
 }
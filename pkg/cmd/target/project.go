@@ -35,6 +35,8 @@ gardenctl target project my-project --garden my-garden`,
 		RunE:              base.WrapRunE(o, f),
 	}
 
+	o.addOutputFlag(cmd)
+
 	f.TargetFlags().AddGardenFlag(cmd.Flags())
 	flags.RegisterCompletionFuncsForTargetFlags(cmd, f, ioStreams, cmd.Flags())
 
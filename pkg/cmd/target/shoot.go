@@ -30,11 +30,20 @@ func NewCmdTargetShoot(f util.Factory, ioStreams util.IOStreams) *cobra.Command
 gardenctl target shoot my-shoot
 
 # target shoot with name my-shoot of project my-project
-gardenctl target shoot my-shoot --garden my-garden --project my-project`,
+gardenctl target shoot my-shoot --garden my-garden --project my-project
+
+# list the names of all shoots of the currently selected project
+gardenctl target shoot --output name
+
+# target the shoot of the currently selected project whose name contains "shoot", e.g. my-shoot
+gardenctl target shoot shoot --fuzzy`,
 		ValidArgsFunction: validTargetFunctionWrapper(f, ioStreams, TargetKindShoot),
 		RunE:              base.WrapRunE(o, f),
 	}
 
+	o.addOutputFlag(cmd)
+	cmd.Flags().BoolVar(&o.Fuzzy, "fuzzy", o.Fuzzy, "Target the shoot whose name contains the given name, ignoring the case. If several shoots match, a numbered selection is prompted for, or an error is returned if the input is not a terminal.")
+
 	f.TargetFlags().AddGardenFlag(cmd.Flags())
 	f.TargetFlags().AddProjectFlag(cmd.Flags())
 	f.TargetFlags().AddSeedFlag(cmd.Flags())
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package target
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -15,6 +16,8 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/gardener/gardenctl-v2/internal/util"
 	"github.com/gardener/gardenctl-v2/pkg/ac"
@@ -36,7 +39,10 @@ gardenctl target --garden my-garden --project my-project
 gardenctl target shoot my-shoot
 
 # Target shoot control-plane using values that match a pattern defined for a specific garden
-gardenctl target value/that/matches/pattern --control-plane`,
+gardenctl target value/that/matches/pattern --control-plane
+
+# Target the shoot of the current context of the kubeconfig the KUBECONFIG environment variable points to
+gardenctl target --from-kubeconfig-current-context`,
 		RunE: base.WrapRunE(o, f),
 	}
 
@@ -48,10 +54,13 @@ gardenctl target value/that/matches/pattern --control-plane`,
 
 	cmd.AddCommand(NewCmdUnset(f, ioStreams))
 	cmd.AddCommand(NewCmdView(f, ioStreams))
+	cmd.AddCommand(NewCmdBack(f, ioStreams))
 
 	f.TargetFlags().AddFlags(cmd.Flags())
 	flags.RegisterCompletionFuncsForTargetFlags(cmd, f, ioStreams, cmd.Flags())
 
+	cmd.Flags().BoolVar(&o.FromKubeconfigCurrentContext, "from-kubeconfig-current-context", o.FromKubeconfigCurrentContext, "Target the shoot of the current context of the kubeconfig the KUBECONFIG environment variable points to. The context name must have the format <namespace>--<shoot>-<address> generated by gardenctl.")
+
 	return cmd
 }
 
@@ -69,6 +78,9 @@ const (
 	TargetKindControlPlane TargetKind = "control-plane"
 )
 
+// OutputName is the output format which prints bare names only, one per line.
+const OutputName = "name"
+
 type cobraValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
 
 func validTargetFunctionWrapper(f util.Factory, ioStreams util.IOStreams, kind TargetKind) cobraValidArgsFunction {
@@ -97,11 +109,17 @@ func validTargetArgsFunction(f util.Factory, kind TargetKind) ([]string, error)
 	case TargetKindGarden:
 		result, err = manager.GardenNames()
 	case TargetKindProject:
-		result, err = manager.ProjectNames(ctx)
+		result, err = flags.WithCompletionCache(f, "project", func(ctx context.Context, manager target.Manager) ([]string, error) {
+			return manager.ProjectNames(ctx)
+		})(ctx, manager)
 	case TargetKindSeed:
-		result, err = manager.SeedNames(ctx)
+		result, err = flags.WithCompletionCache(f, "seed", func(ctx context.Context, manager target.Manager) ([]string, error) {
+			return manager.SeedNames(ctx)
+		})(ctx, manager)
 	case TargetKindShoot:
-		result, err = manager.ShootNames(ctx)
+		result, err = flags.WithCompletionCache(f, "shoot", func(ctx context.Context, manager target.Manager) ([]string, error) {
+			return manager.ShootNames(ctx)
+		})(ctx, manager)
 	}
 
 	return result, err
@@ -115,6 +133,14 @@ type TargetOptions struct {
 	Kind TargetKind
 	// TargetName is the object name of the targeted kind
 	TargetName string
+	// FromKubeconfigCurrentContext determines the target from the current context of the kubeconfig
+	FromKubeconfigCurrentContext bool
+	// GardenName is the name of the garden of the shoot determined from the current kubeconfig context
+	GardenName string
+	// Namespace is the namespace of the shoot determined from the current kubeconfig context
+	Namespace string
+	// Fuzzy treats the shoot name as part of the name of the shoot to target
+	Fuzzy bool
 }
 
 // NewTargetOptions returns initialized TargetOptions.
@@ -126,6 +152,28 @@ func NewTargetOptions(ioStreams util.IOStreams) *TargetOptions {
 	}
 }
 
+// addOutputFlag adds the output flag to a target subcommand. Only the name format is supported.
+func (o *TargetOptions) addOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.Output, "output", "o", o.Output, "One of 'name'. Print only the name of the targeted object. If no name argument is given, the names of all available objects are listed, one per line.")
+	utilruntime.Must(cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{OutputName}, cobra.ShellCompDirectiveNoFileComp
+	}))
+}
+
+// listNames returns true if the available names should be listed instead of targeting an object.
+func (o *TargetOptions) listNames() bool {
+	if o.Output != OutputName || o.TargetName != "" {
+		return false
+	}
+
+	switch o.Kind {
+	case TargetKindGarden, TargetKindProject, TargetKindSeed, TargetKindShoot:
+		return true
+	default:
+		return false
+	}
+}
+
 // Complete adapts from the command line args to the data required.
 func (o *TargetOptions) Complete(f util.Factory, _ *cobra.Command, args []string) error {
 	if len(args) > 0 {
@@ -138,6 +186,10 @@ func (o *TargetOptions) Complete(f util.Factory, _ *cobra.Command, args []string
 
 	tf := f.TargetFlags()
 
+	if o.FromKubeconfigCurrentContext {
+		return o.completeFromKubeconfigCurrentContext(tf)
+	}
+
 	if o.Kind == "" {
 		switch {
 		case tf.ControlPlane():
@@ -169,8 +221,52 @@ func (o *TargetOptions) Complete(f util.Factory, _ *cobra.Command, args []string
 	return nil
 }
 
+// completeFromKubeconfigCurrentContext determines the shoot to target from the name of the current context
+// of the kubeconfig the KUBECONFIG environment variable points to.
+func (o *TargetOptions) completeFromKubeconfigCurrentContext(tf target.TargetFlags) error {
+	if o.TargetName != "" || tf.ProjectName() != "" || tf.SeedName() != "" || tf.ShootName() != "" || tf.ControlPlane() {
+		return errors.New("--from-kubeconfig-current-context cannot be combined with a name argument or target flags other than --garden")
+	}
+
+	rawConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientcmd.NewDefaultClientConfigLoadingRules(),
+		&clientcmd.ConfigOverrides{},
+	).RawConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	if rawConfig.CurrentContext == "" {
+		return errors.New("the kubeconfig has no current context")
+	}
+
+	namespace, shootName, err := target.ParseContextName(rawConfig.CurrentContext)
+	if err != nil {
+		return err
+	}
+
+	o.Kind = TargetKindShoot
+	o.TargetName = shootName
+	o.Namespace = namespace
+
+	o.GardenName = tf.GardenName()
+	if o.GardenName == "" {
+		o.GardenName = target.GardenClusterIdentity(&rawConfig)
+	}
+
+	return nil
+}
+
 // Validate validates the provided options.
 func (o *TargetOptions) Validate() error {
+	if o.Output != "" && o.Output != OutputName {
+		return fmt.Errorf("--output must be %q", OutputName)
+	}
+
+	if o.listNames() {
+		return nil
+	}
+
 	switch o.Kind {
 	case TargetKindControlPlane:
 		// valid
@@ -185,6 +281,19 @@ func (o *TargetOptions) Validate() error {
 
 // Run executes the command.
 func (o *TargetOptions) Run(f util.Factory) error {
+	if o.listNames() {
+		names, err := validTargetArgsFunction(f, o.Kind)
+		if err != nil {
+			return err
+		}
+
+		for _, name := range names {
+			fmt.Fprintln(o.IOStreams.Out, name)
+		}
+
+		return nil
+	}
+
 	manager, err := f.Manager()
 	if err != nil {
 		return err
@@ -194,18 +303,31 @@ func (o *TargetOptions) Run(f util.Factory) error {
 	handler := ac.NewAccessRestrictionHandler(o.IOStreams.In, o.IOStreams.Out, askForConfirmation)
 	ctx := ac.WithAccessRestrictionHandler(f.Context(), handler)
 
-	switch o.Kind {
-	case TargetKindGarden:
+	if o.Fuzzy && o.Kind == TargetKindShoot {
+		o.TargetName, err = o.resolveFuzzyShootName(ctx, manager)
+		if err != nil {
+			if errors.Is(err, target.ErrAborted) {
+				return nil
+			}
+
+			return err
+		}
+	}
+
+	switch {
+	case o.Namespace != "":
+		err = manager.TargetNamespacedShoot(ctx, o.GardenName, o.Namespace, o.TargetName)
+	case o.Kind == TargetKindGarden:
 		err = manager.TargetGarden(ctx, o.TargetName)
-	case TargetKindProject:
+	case o.Kind == TargetKindProject:
 		err = manager.TargetProject(ctx, o.TargetName)
-	case TargetKindSeed:
+	case o.Kind == TargetKindSeed:
 		err = manager.TargetSeed(ctx, o.TargetName)
-	case TargetKindShoot:
+	case o.Kind == TargetKindShoot:
 		err = manager.TargetShoot(ctx, o.TargetName)
-	case TargetKindPattern:
+	case o.Kind == TargetKindPattern:
 		err = manager.TargetMatchPattern(ctx, f.TargetFlags(), o.TargetName)
-	case TargetKindControlPlane:
+	case o.Kind == TargetKindControlPlane:
 		err = manager.TargetControlPlane(ctx)
 	}
 
@@ -222,6 +344,12 @@ func (o *TargetOptions) Run(f util.Factory) error {
 		return fmt.Errorf("failed to get current target: %w", err)
 	}
 
+	if o.Output == OutputName {
+		fmt.Fprintln(o.IOStreams.Out, targetedName(currentTarget, o.Kind))
+
+		return nil
+	}
+
 	if o.Output == "" {
 		if o.Kind == TargetKindControlPlane {
 			fmt.Fprintf(o.IOStreams.Out, "Successfully targeted control plane of shoot %q\n", currentTarget.ShootName())
@@ -238,3 +366,17 @@ func (o *TargetOptions) Run(f util.Factory) error {
 
 	return nil
 }
+
+// targetedName returns the name of the object of the given kind from the target.
+func targetedName(t target.Target, kind TargetKind) string {
+	switch kind {
+	case TargetKindGarden:
+		return t.GardenName()
+	case TargetKindProject:
+		return t.ProjectName()
+	case TargetKindSeed:
+		return t.SeedName()
+	default:
+		return t.ShootName()
+	}
+}
@@ -8,11 +8,15 @@ package target_test
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -101,6 +105,7 @@ var _ = Describe("Target Command", func() {
 		clientProvider = clientmocks.NewMockProvider(ctrl)
 		targetProvider = internalfake.NewFakeTargetProvider(target.NewTarget("", "", "", ""))
 		factory = internalfake.NewFakeFactory(cfg, nil, clientProvider, targetProvider)
+		factory.SessionDirectory = GinkgoT().TempDir()
 	})
 
 	JustBeforeEach(func() {
@@ -182,6 +187,123 @@ var _ = Describe("Target Command", func() {
 			Expect(currentTarget.ShootName()).To(Equal(shootName))
 		})
 
+		Context("when the output is name", func() {
+			BeforeEach(func() {
+				anotherShoot := shoot.DeepCopy()
+				anotherShoot.Name = "another-shoot"
+				gardenClient = internalfake.NewClientWithObjects(project, seed, shoot, anotherShoot)
+
+				// user has already targeted a garden and project
+				targetProvider.Target = target.NewTarget(gardenName, projectName, "", "")
+			})
+
+			It("should list the shoot names", func() {
+				cmd := cmdtarget.NewCmdTargetShoot(factory, streams)
+				Expect(cmd.Flags().Set("output", "name")).To(Succeed())
+
+				Expect(cmd.RunE(cmd, nil)).To(Succeed())
+				Expect(out.String()).To(Equal("another-shoot\n" + shootName + "\n"))
+
+				currentTarget, err := targetProvider.Read()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(currentTarget.ShootName()).To(BeEmpty())
+			})
+
+			It("should print only the name of the targeted shoot", func() {
+				cmd := cmdtarget.NewCmdTargetShoot(factory, streams)
+				Expect(cmd.Flags().Set("output", "name")).To(Succeed())
+
+				Expect(cmd.RunE(cmd, []string{shootName})).To(Succeed())
+				Expect(out.String()).To(Equal(shootName + "\n"))
+
+				currentTarget, err := targetProvider.Read()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(currentTarget.ShootName()).To(Equal(shootName))
+			})
+
+			It("should reject other output formats", func() {
+				cmd := cmdtarget.NewCmdTargetShoot(factory, streams)
+				Expect(cmd.Flags().Set("output", "json")).To(Succeed())
+
+				Expect(cmd.RunE(cmd, nil)).To(MatchError(`--output must be "name"`))
+			})
+		})
+
+		Context("when the shoot name is fuzzy", func() {
+			BeforeEach(func() {
+				anotherShoot := shoot.DeepCopy()
+				anotherShoot.Name = "another-shoot"
+				gardenClient = internalfake.NewClientWithObjects(project, seed, shoot, anotherShoot)
+
+				// user has already targeted a garden and project
+				targetProvider.Target = target.NewTarget(gardenName, projectName, "", "")
+			})
+
+			It("should target the only matching shoot", func() {
+				cmd := cmdtarget.NewCmdTargetShoot(factory, streams)
+				Expect(cmd.Flags().Set("fuzzy", "true")).To(Succeed())
+
+				Expect(cmd.RunE(cmd, []string{"MY"})).To(Succeed())
+				Expect(out.String()).To(ContainSubstring("Successfully targeted shoot %q\n", shootName))
+
+				currentTarget, err := targetProvider.Read()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(currentTarget.ShootName()).To(Equal(shootName))
+			})
+
+			It("should fail if no shoot matches", func() {
+				cmd := cmdtarget.NewCmdTargetShoot(factory, streams)
+				Expect(cmd.Flags().Set("fuzzy", "true")).To(Succeed())
+
+				Expect(cmd.RunE(cmd, []string{"foo"})).To(MatchError(`no shoot matches "foo"`))
+			})
+
+			It("should fail for several matches if the input is not a terminal", func() {
+				cmd := cmdtarget.NewCmdTargetShoot(factory, streams)
+				Expect(cmd.Flags().Set("fuzzy", "true")).To(Succeed())
+
+				Expect(cmd.RunE(cmd, []string{"shoot"})).To(MatchError(`"shoot" matches several shoots, must be one of another-shoot, myshoot`))
+
+				currentTarget, err := targetProvider.Read()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(currentTarget.ShootName()).To(BeEmpty())
+			})
+
+			Context("when the input is a terminal", func() {
+				BeforeEach(func() {
+					cmdtarget.SetIsTerminalReader(func(io.Reader) bool { return true })
+					DeferCleanup(cmdtarget.SetIsTerminalReader, cmdtarget.IsTerminalReader)
+				})
+
+				It("should target the selected shoot of several matches", func() {
+					cmd := cmdtarget.NewCmdTargetShoot(factory, streams)
+					Expect(cmd.Flags().Set("fuzzy", "true")).To(Succeed())
+					in.Write([]byte("3\n2\n"))
+
+					Expect(cmd.RunE(cmd, []string{"shoot"})).To(Succeed())
+					Expect(out.String()).To(ContainSubstring("  1) another-shoot\n  2) myshoot\n"))
+					Expect(out.String()).To(ContainSubstring("Successfully targeted shoot %q\n", shootName))
+
+					currentTarget, err := targetProvider.Read()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(currentTarget.ShootName()).To(Equal(shootName))
+				})
+
+				It("should abort without a selection", func() {
+					cmd := cmdtarget.NewCmdTargetShoot(factory, streams)
+					Expect(cmd.Flags().Set("fuzzy", "true")).To(Succeed())
+					in.Write([]byte("\n"))
+
+					Expect(cmd.RunE(cmd, []string{"shoot"})).To(Succeed())
+					Expect(out.String()).NotTo(ContainSubstring("Successfully targeted"))
+
+					currentTarget, err := targetProvider.Read()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(currentTarget.ShootName()).To(BeEmpty())
+				})
+			})
+		})
+
 		It("should be able to target a control plane", func() {
 			// user has already targeted a garden, project and shoot
 			targetProvider.Target = target.NewTarget(gardenName, projectName, "", shootName)
@@ -215,6 +337,78 @@ var _ = Describe("Target Command", func() {
 			Expect(currentTarget.ShootName()).To(Equal(shootName))
 		})
 
+		Context("when targeting the shoot of the current kubeconfig context", func() {
+			BeforeEach(func() {
+				projectNamespace := &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   namespace,
+						Labels: map[string]string{"project.gardener.cloud/name": projectName},
+					},
+				}
+				gardenClient = internalfake.NewClientWithObjects(project, seed, shoot, projectNamespace)
+			})
+
+			writeKubeconfig := func(contextName string) {
+				kubeconfig := filepath.Join(GinkgoT().TempDir(), "kubeconfig.yaml")
+				Expect(os.WriteFile(kubeconfig, []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: %[1]s
+  cluster:
+    server: https://api.myshoot.example.com
+    extensions:
+    - name: client.authentication.k8s.io/exec
+      extension:
+        gardenClusterIdentity: %[2]s
+        shootRef:
+          namespace: %[3]s
+          name: %[4]s
+contexts:
+- name: %[1]s
+  context:
+    cluster: %[1]s
+    user: %[3]s--%[4]s
+current-context: %[1]s
+users:
+- name: %[3]s--%[4]s
+  user: {}
+`, contextName, gardenName, namespace, shootName)), 0o600)).To(Succeed())
+				GinkgoT().Setenv("KUBECONFIG", kubeconfig)
+			}
+
+			It("should target the shoot of the generated context", func() {
+				writeKubeconfig(fmt.Sprintf("%s--%s-external", namespace, shootName))
+				cmd := cmdtarget.NewCmdTarget(factory, streams)
+				Expect(cmd.Flags().Set("from-kubeconfig-current-context", "true")).To(Succeed())
+
+				Expect(cmd.RunE(cmd, nil)).To(Succeed())
+				Expect(out.String()).To(ContainSubstring("Successfully targeted shoot %q\n", shootName))
+
+				currentTarget, err := targetProvider.Read()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(currentTarget.GardenName()).To(Equal(gardenName))
+				Expect(currentTarget.ProjectName()).To(Equal(projectName))
+				Expect(currentTarget.SeedName()).To(BeEmpty())
+				Expect(currentTarget.ShootName()).To(Equal(shootName))
+			})
+
+			It("should fail if the context was not generated by gardenctl", func() {
+				writeKubeconfig("admin@myshoot")
+				cmd := cmdtarget.NewCmdTarget(factory, streams)
+				Expect(cmd.Flags().Set("from-kubeconfig-current-context", "true")).To(Succeed())
+
+				Expect(cmd.RunE(cmd, nil)).To(MatchError(ContainSubstring(`context "admin@myshoot" was not generated by gardenctl`)))
+			})
+
+			It("should fail if combined with a name argument", func() {
+				writeKubeconfig(fmt.Sprintf("%s--%s-external", namespace, shootName))
+				cmd := cmdtarget.NewCmdTarget(factory, streams)
+				Expect(cmd.Flags().Set("from-kubeconfig-current-context", "true")).To(Succeed())
+
+				Expect(cmd.RunE(cmd, []string{shootName})).To(MatchError(ContainSubstring("cannot be combined with a name argument")))
+			})
+		})
+
 		Context("when the shoot has access restrictions", func() {
 			BeforeEach(func() {
 				shoot.Spec.AccessRestrictions = []gardencorev1beta1.AccessRestrictionWithOptions{
@@ -364,4 +558,22 @@ var _ = Describe("Target Options", func() {
 
 		Expect(o.Validate()).To(Succeed())
 	})
+
+	It("should not require a name when listing names", func() {
+		streams, _, _, _ := util.NewTestIOStreams()
+		o := cmdtarget.NewTargetOptions(streams)
+		o.Kind = cmdtarget.TargetKindShoot
+		o.Output = cmdtarget.OutputName
+
+		Expect(o.Validate()).To(Succeed())
+	})
+
+	It("should still require a name for a pattern", func() {
+		streams, _, _, _ := util.NewTestIOStreams()
+		o := cmdtarget.NewTargetOptions(streams)
+		o.Kind = cmdtarget.TargetKindPattern
+		o.Output = cmdtarget.OutputName
+
+		Expect(o.Validate()).To(MatchError(`target kind "pattern" requires a name argument`))
+	})
 })
@@ -9,17 +9,23 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/mitchellh/go-homedir"
+	"golang.org/x/net/idna"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/yaml"
 
+	"github.com/gardener/gardenctl-v2/internal/debug"
 	"github.com/gardener/gardenctl-v2/pkg/ac"
 )
 
@@ -31,6 +37,17 @@ type Config struct {
 	LinkKubeconfig *bool `json:"linkKubeconfig,omitempty"`
 	// Gardens is a list of known Garden clusters
 	Gardens []Garden `json:"gardens"`
+	// IPDetectionURL is the URL of a service that responds with the public IP address of the caller as plain text.
+	// It is used to auto-detect the CIDR that is allowed to access a bastion.
+	// +optional
+	IPDetectionURL string `json:"ipDetectionURL,omitempty"`
+	// SSH holds the default values for the options of the ssh command.
+	// They can be overridden per shoot, see ShootSSHConfigFilename.
+	// +optional
+	SSH *SSHConfig `json:"ssh,omitempty"`
+	// DefaultShell is the shell used by the kubectl-env and provider-env commands if no shell sub-command is given.
+	// +optional
+	DefaultShell string `json:"defaultShell,omitempty"`
 }
 
 // Garden represents one garden cluster.
@@ -53,6 +70,9 @@ type Garden struct {
 	// AccessRestrictions is a list of access restriction definitions
 	// +optional
 	AccessRestrictions []ac.AccessRestriction `json:"accessRestrictions,omitempty"`
+	// DashboardURL is the base URL of the Gardener dashboard of this Garden, e.g. https://dashboard.gardener.cloud
+	// +optional
+	DashboardURL string `json:"dashboardURL,omitempty"`
 }
 
 // LoadFromFile parses a gardenctl config file and returns a Config struct.
@@ -118,6 +138,18 @@ func (config *Config) validate() {
 	for i := range config.Gardens {
 		garden := config.Gardens[i]
 
+		seenPatterns := make(map[string]bool, len(garden.Patterns))
+
+		for _, p := range garden.Patterns {
+			if logged, ok := seenPatterns[p]; ok && !logged {
+				klog.Warningf("pattern %q was found multiple times for garden %q in gardenctl configuration", p, garden.Name)
+
+				seenPatterns[p] = true
+			} else if !ok {
+				seenPatterns[p] = false
+			}
+		}
+
 		if logged, ok := seen[garden.Name]; ok && !logged {
 			klog.Warningf("identity and alias should be unique but %q was found multiple times in gardenctl configuration", garden.Name)
 
@@ -335,15 +367,46 @@ func (config *Config) MatchPattern(preferredGardenName string, value string) (*P
 	}
 
 	if patternMatch == nil {
+		if debug.UnsafeEnabled() {
+			return nil, fmt.Errorf("the provided value does not match any pattern, tried patterns: %s", strings.Join(config.triedPatterns(preferredGardenName), ", "))
+		}
+
 		return nil, errors.New("the provided value does not match any pattern")
 	}
 
 	return patternMatch, nil
 }
 
+// triedPatterns returns the patterns MatchPattern considered, in the order they were tried and without duplicates.
+func (config *Config) triedPatterns(preferredGardenName string) []string {
+	var patterns []string
+
+	seen := map[string]bool{}
+	add := func(g Garden) {
+		for _, p := range g.Patterns {
+			if !seen[p] {
+				seen[p] = true
+				patterns = append(patterns, p)
+			}
+		}
+	}
+
+	if g, err := config.Garden(preferredGardenName); err == nil {
+		add(*g)
+	}
+
+	for _, g := range config.Gardens {
+		add(g)
+	}
+
+	return patterns
+}
+
 // matchPattern matches pattern with provided list of patterns.
 // If none of the provided patterns matches the given value no error is returned.
 func matchPattern(patterns []string, value string) (*PatternMatch, error) {
+	values := hostForms(value)
+
 	for _, p := range patterns {
 		r, err := regexp.Compile(p)
 		if err != nil {
@@ -351,7 +414,14 @@ func matchPattern(patterns []string, value string) (*PatternMatch, error) {
 		}
 
 		names := r.SubexpNames()
-		matches := r.FindStringSubmatch(value)
+
+		var matches []string
+
+		for _, v := range values {
+			if matches = r.FindStringSubmatch(v); matches != nil {
+				break
+			}
+		}
 
 		if matches == nil {
 			continue
@@ -375,3 +445,43 @@ func matchPattern(patterns []string, value string) (*PatternMatch, error) {
 
 	return nil, nil
 }
+
+// hostForms returns the given value followed by its forms with an internationalized URL host converted to ASCII
+// (punycode) and to unicode, so that patterns match regardless of the form their host is written in.
+// Values that are not URLs are returned unchanged.
+func hostForms(value string) []string {
+	forms := []string{value}
+
+	if !strings.Contains(value, "://") || (isASCII(value) && !strings.Contains(value, "xn--")) {
+		return forms
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Hostname() == "" {
+		return forms
+	}
+
+	for _, convert := range []func(string) (string, error){idna.Lookup.ToASCII, idna.Lookup.ToUnicode} {
+		host, err := convert(u.Hostname())
+		if err != nil || host == u.Hostname() {
+			continue
+		}
+
+		form := strings.Replace(value, u.Hostname(), host, 1)
+		if !slices.Contains(forms, form) {
+			forms = append(forms, form)
+		}
+	}
+
+	return forms
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
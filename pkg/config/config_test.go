@@ -13,8 +13,11 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 
+	"github.com/gardener/gardenctl-v2/internal/debug"
+	"github.com/gardener/gardenctl-v2/internal/util"
 	"github.com/gardener/gardenctl-v2/pkg/config"
 )
 
@@ -106,6 +109,35 @@ var _ = Describe("Config", func() {
 			clusterIdentity2),
 	)
 
+	DescribeTable("MatchPattern matches internationalized hosts in their ASCII form",
+		func(value string) {
+			cfg.Gardens[0].Patterns = []string{
+				`^https://api\.(?P<shoot>[^.]+)\.(?P<project>[^.]+)\.xn--bcher-kva\.example(:\d+)?$`,
+			}
+
+			match, err := cfg.MatchPattern("", value)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(match).To(Equal(&config.PatternMatch{Garden: clusterIdentity1, Project: "myproject", Shoot: "myshoot"}))
+		},
+		Entry("when the host is given in unicode", "https://api.myshoot.myproject.bücher.example"),
+		Entry("when the host is given in punycode", "https://api.myshoot.myproject.xn--bcher-kva.example"),
+		Entry("when the host with port is given in unicode", "https://api.myshoot.myproject.bücher.example:443"),
+	)
+
+	DescribeTable("MatchPattern matches patterns with an internationalized host in unicode form",
+		func(value string) {
+			cfg.Gardens[0].Patterns = []string{
+				`^https://dashboard\.bücher\.example/namespace/(?P<namespace>[^/]+)/shoots/(?P<shoot>[^/]+)$`,
+			}
+
+			match, err := cfg.MatchPattern("", value)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(match).To(Equal(&config.PatternMatch{Garden: clusterIdentity1, Namespace: "garden-myproject", Shoot: "myshoot"}))
+		},
+		Entry("when the host is given in unicode", "https://dashboard.bücher.example/namespace/garden-myproject/shoots/myshoot"),
+		Entry("when the host is given in punycode", "https://dashboard.xn--bcher-kva.example/namespace/garden-myproject/shoots/myshoot"),
+	)
+
 	DescribeTable("MatchPattern returns an error",
 		func(currentGardenName string, patternPrefix string, expectedErrorString string) {
 			value := patternValue(patternPrefix)
@@ -134,6 +166,27 @@ var _ = Describe("Config", func() {
 			fmt.Sprintf("garden %q is not defined in gardenctl configuration", fooIdentity)),
 	)
 
+	Describe("MatchPattern with unsafe debug output", func() {
+		It("should list the tried patterns if the value does not match", func() {
+			Expect(os.Setenv(debug.EnvUnsafe, "true")).To(Succeed())
+			DeferCleanup(os.Unsetenv, debug.EnvUnsafe)
+
+			_, err := cfg.MatchPattern(clusterIdentity2, patternValue("invalidPrefix"))
+			Expect(err).To(MatchError(fmt.Sprintf(
+				"the provided value does not match any pattern, tried patterns: %s, %s, %s",
+				cfg.Gardens[1].Patterns[0],
+				cfg.Gardens[0].Patterns[0],
+				cfg.Gardens[0].Patterns[1],
+			)))
+		})
+
+		It("should not list the tried patterns if unsafe debug output is disabled", func() {
+			_, err := cfg.MatchPattern(clusterIdentity2, patternValue("invalidPrefix"))
+			Expect(err).To(MatchError("the provided value does not match any pattern"))
+			Expect(err.Error()).NotTo(ContainSubstring(cfg.Gardens[1].Patterns[0]))
+		})
+	})
+
 	DescribeTable("Should find garden by identity and alias", func(name, identityOrAlias string) {
 		garden, err := cfg.Garden(name)
 		Expect(err).NotTo(HaveOccurred())
@@ -218,5 +271,33 @@ var _ = Describe("Config", func() {
 			Expect(cfg.Filename).To(Equal(filename))
 			Expect(cfg.Gardens).To(BeNil())
 		})
+
+		It("should warn about patterns defined multiple times", func() {
+			logs := &util.SafeBytesBuffer{}
+			klog.SetOutput(logs)
+			klog.LogToStderr(false)
+			DeferCleanup(func() {
+				klog.SetOutput(os.Stderr)
+				klog.LogToStderr(true)
+			})
+
+			pattern := "^shoot--(?P<project>.+)--(?P<shoot>.+)$"
+			filename := filepath.Join(gardenHomeDir, "gardenctl-v2.yaml")
+			Expect(os.WriteFile(filename, []byte(fmt.Sprintf(`gardens:
+- identity: foo
+  kubeconfig: /path/to/kubeconfig
+  patterns:
+  - %[1]q
+  - %[1]q
+  - %[1]q
+`, pattern)), 0o600)).To(Succeed())
+
+			cfg, err := config.LoadFromFile(filename)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Gardens[0].Patterns).To(HaveLen(3))
+
+			klog.Flush()
+			Expect(logs.String()).To(ContainSubstring(fmt.Sprintf("pattern %q was found multiple times for garden %q", pattern, "foo")))
+		})
 	})
 })
@@ -0,0 +1,205 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+)
+
+// legacyGardensKey is the key of the list of garden clusters in the configuration of gardenctl v1.
+const legacyGardensKey = "gardenClusters"
+
+// Migrate upgrades the given gardenctl configuration to the current schema.
+// Legacy fields are renamed, unknown fields are dropped and the resulting configuration is validated.
+// The normalized configuration is returned as YAML, together with the paths of the dropped fields,
+// e.g. gardens[0].foo. Comments are not preserved.
+func Migrate(data []byte) ([]byte, []string, error) {
+	raw := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode as YAML: %w", err)
+	}
+
+	if legacyGardens, ok := raw[legacyGardensKey]; ok {
+		if _, ok := raw["gardens"]; ok {
+			return nil, nil, fmt.Errorf("configuration must not contain both %q and %q", legacyGardensKey, "gardens")
+		}
+
+		gardens, err := gardenMaps(legacyGardens)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, garden := range gardens {
+			// gardenctl v1 identified a garden cluster by its name
+			renameField(garden, "name", "identity")
+			renameField(garden, "dashboardUrl", "dashboardURL")
+		}
+
+		raw["gardens"] = legacyGardens
+		delete(raw, legacyGardensKey)
+	}
+
+	if gardens, ok := raw["gardens"]; ok {
+		gardens, err := gardenMaps(gardens)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, garden := range gardens {
+			renameField(garden, "kubeConfig", "kubeconfig")
+		}
+	}
+
+	buf, err := yaml.Marshal(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode as YAML: %w", err)
+	}
+
+	config := &Config{}
+	if err := yaml.Unmarshal(buf, config); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode as YAML: %w", err)
+	}
+
+	if err := config.validateGardens(); err != nil {
+		return nil, nil, err
+	}
+
+	migrated, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode as YAML: %w", err)
+	}
+
+	normalized := map[string]interface{}{}
+	if err := yaml.Unmarshal(migrated, &normalized); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode as YAML: %w", err)
+	}
+
+	return migrated, droppedFields("", raw, normalized), nil
+}
+
+// validateGardens checks that all required fields of the configured gardens are set.
+func (config *Config) validateGardens() error {
+	for i, g := range config.Gardens {
+		if g.Name == "" {
+			return fmt.Errorf("gardens[%d]: identity is required", i)
+		}
+
+		if g.Kubeconfig == "" {
+			return fmt.Errorf("garden %q: kubeconfig is required", g.Name)
+		}
+	}
+
+	return nil
+}
+
+// gardenMaps returns the entries of the given list of gardens.
+func gardenMaps(value interface{}) ([]map[string]interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("gardens must be a list, got %T", value)
+	}
+
+	gardens := make([]map[string]interface{}, 0, len(list))
+
+	for i, item := range list {
+		garden, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("gardens[%d] must be an object, got %T", i, item)
+		}
+
+		gardens = append(gardens, garden)
+	}
+
+	return gardens, nil
+}
+
+// renameField renames the key from to the key to, unless the key to is already set.
+func renameField(m map[string]interface{}, from, to string) {
+	value, ok := m[from]
+	if !ok {
+		return
+	}
+
+	delete(m, from)
+
+	if _, ok := m[to]; !ok {
+		m[to] = value
+	}
+}
+
+// droppedFields returns the paths of the fields of raw that are missing in normalized. Fields without a value
+// are not returned, as no information is lost if they are dropped.
+func droppedFields(path string, raw, normalized interface{}) []string {
+	var dropped []string
+
+	switch raw := raw.(type) {
+	case map[string]interface{}:
+		normalized, _ := normalized.(map[string]interface{})
+
+		keys := make([]string, 0, len(raw))
+		for key := range raw {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+
+			value, ok := normalized[key]
+			if !ok {
+				if !isEmptyValue(raw[key]) {
+					dropped = append(dropped, fieldPath)
+				}
+
+				continue
+			}
+
+			dropped = append(dropped, droppedFields(fieldPath, raw[key], value)...)
+		}
+	case []interface{}:
+		normalized, _ := normalized.([]interface{})
+
+		for i, item := range raw {
+			if i < len(normalized) {
+				dropped = append(dropped, droppedFields(fmt.Sprintf("%s[%d]", path, i), item, normalized[i])...)
+			}
+		}
+	}
+
+	return dropped
+}
+
+// isEmptyValue returns true if the given decoded YAML value is null, false, zero or empty.
+func isEmptyValue(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !value
+	case float64:
+		return value == 0
+	case string:
+		return value == ""
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	}
+
+	return false
+}
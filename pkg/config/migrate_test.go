@@ -0,0 +1,92 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/gardenctl-v2/pkg/config"
+)
+
+var _ = Describe("Migrate", func() {
+	It("should migrate a gardenctl v1 configuration", func() {
+		migrated, dropped, err := config.Migrate([]byte(`gardenClusters:
+- name: landscape-dev
+  kubeConfig: ~/.garden/landscape-dev.yaml
+  dashboardUrl: https://dashboard.example.com
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(migrated)).To(Equal(`gardens:
+- dashboardURL: https://dashboard.example.com
+  identity: landscape-dev
+  kubeconfig: ~/.garden/landscape-dev.yaml
+`))
+		Expect(dropped).To(BeEmpty())
+	})
+
+	It("should normalize a current configuration", func() {
+		migrated, dropped, err := config.Migrate([]byte(`linkKubeconfig: false
+gardens:
+- name: dev
+  identity: landscape-dev
+  kubeConfig: /path/to/kubeconfig
+  patterns:
+  - ^shoot--(?P<project>.+)--(?P<shoot>.+)$
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(migrated)).To(Equal(`gardens:
+- identity: landscape-dev
+  kubeconfig: /path/to/kubeconfig
+  name: dev
+  patterns:
+  - ^shoot--(?P<project>.+)--(?P<shoot>.+)$
+linkKubeconfig: false
+`))
+		Expect(dropped).To(BeEmpty())
+	})
+
+	It("should not change a migrated configuration", func() {
+		data := []byte(`gardens:
+- identity: landscape-dev
+  kubeconfig: /path/to/kubeconfig
+`)
+		migrated, dropped, err := config.Migrate(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(migrated).To(Equal(data))
+		Expect(dropped).To(BeEmpty())
+	})
+
+	It("should return the dropped unknown fields", func() {
+		migrated, dropped, err := config.Migrate([]byte(`# my gardens
+gardens:
+- identity: landscape-dev
+  kubeconfig: /path/to/kubeconfig # the dev landscape
+  foo: bar
+  empty: ""
+unknown:
+  nested: true
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(migrated)).To(Equal(`gardens:
+- identity: landscape-dev
+  kubeconfig: /path/to/kubeconfig
+`))
+		Expect(dropped).To(Equal([]string{"gardens[0].foo", "unknown"}))
+	})
+
+	DescribeTable("should fail for invalid configurations",
+		func(data string, expectedError string) {
+			_, _, err := config.Migrate([]byte(data))
+			Expect(err).To(MatchError(ContainSubstring(expectedError)))
+		},
+		Entry("when the identity is missing", "gardens:\n- kubeconfig: /path\n", "gardens[0]: identity is required"),
+		Entry("when the kubeconfig is missing", "gardens:\n- identity: dev\n", `garden "dev": kubeconfig is required`),
+		Entry("when both formats are used", "gardens: []\ngardenClusters: []\n", `must not contain both "gardenClusters" and "gardens"`),
+		Entry("when the gardens are not a list", "gardens: foo\n", "gardens must be a list"),
+	)
+})
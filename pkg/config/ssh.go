@@ -0,0 +1,115 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// SSHConfig holds default values for the options of the ssh command.
+// Empty values are not applied.
+type SSHConfig struct {
+	// User is the name of the Shoot cluster node ssh login username
+	// +optional
+	User string `json:"user,omitempty"`
+	// BastionPort is the SSH port of the bastion used for the SSH client command
+	// +optional
+	BastionPort string `json:"bastionPort,omitempty"`
+	// BastionStrictHostKeyChecking specifies how the SSH client performs host key checking for the bastion
+	// +optional
+	BastionStrictHostKeyChecking string `json:"bastionStrictHostKeyChecking,omitempty"`
+	// NodeStrictHostKeyChecking specifies how the SSH client performs host key checking for the shoot node
+	// +optional
+	NodeStrictHostKeyChecking string `json:"nodeStrictHostKeyChecking,omitempty"`
+	// WaitTimeout is the maximum duration to wait for the ready bastion to accept SSH connections, e.g. 2m
+	// +optional
+	WaitTimeout *metav1.Duration `json:"waitTimeout,omitempty"`
+}
+
+// WithDefaults returns a copy of the SSHConfig where the empty values are taken from the given defaults.
+func (c *SSHConfig) WithDefaults(defaults *SSHConfig) *SSHConfig {
+	merged := &SSHConfig{}
+	if c != nil {
+		*merged = *c
+	}
+
+	if defaults == nil {
+		return merged
+	}
+
+	if merged.User == "" {
+		merged.User = defaults.User
+	}
+
+	if merged.BastionPort == "" {
+		merged.BastionPort = defaults.BastionPort
+	}
+
+	if merged.BastionStrictHostKeyChecking == "" {
+		merged.BastionStrictHostKeyChecking = defaults.BastionStrictHostKeyChecking
+	}
+
+	if merged.NodeStrictHostKeyChecking == "" {
+		merged.NodeStrictHostKeyChecking = defaults.NodeStrictHostKeyChecking
+	}
+
+	if merged.WaitTimeout == nil {
+		merged.WaitTimeout = defaults.WaitTimeout
+	}
+
+	return merged
+}
+
+// ShootSSHConfigFilename returns the name of the file in the given garden home directory
+// that holds the SSH configuration of a shoot.
+func ShootSSHConfigFilename(gardenHomeDir, gardenName, projectName, shootName string) string {
+	return filepath.Join(gardenHomeDir, "ssh", gardenName, projectName, shootName+".yaml")
+}
+
+// LoadSSHConfigFromFile reads the SSH configuration of a shoot from the given file.
+// An empty configuration is returned if the file does not exist.
+func LoadSSHConfigFromFile(filename string) (*SSHConfig, error) {
+	sshConfig := &SSHConfig{}
+
+	data, err := os.ReadFile(filename) // #nosec G304 -- The file is located in the garden home directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return sshConfig, nil
+		}
+
+		return nil, fmt.Errorf("failed to read SSH configuration file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, sshConfig); err != nil {
+		return nil, fmt.Errorf("failed to decode SSH configuration file %q as YAML: %w", filename, err)
+	}
+
+	return sshConfig, nil
+}
+
+// SaveToFile writes the SSH configuration to the given file. The parent directories are created if required.
+func (c *SSHConfig) SaveToFile(filename string) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0o700); err != nil {
+		return fmt.Errorf("failed to create directory for SSH configuration file: %w", err)
+	}
+
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode SSH configuration: %w", err)
+	}
+
+	if err := os.WriteFile(filename, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write SSH configuration file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/gardenctl-v2/pkg/config"
+)
+
+var _ = Describe("SSHConfig", func() {
+	It("should return the shoot SSH configuration filename", func() {
+		Expect(config.ShootSSHConfigFilename("/home/.garden", "garden", "project", "shoot")).To(Equal(filepath.Join("/home/.garden", "ssh", "garden", "project", "shoot.yaml")))
+	})
+
+	It("should return an empty configuration if the file does not exist", func() {
+		sshConfig, err := config.LoadSSHConfigFromFile(filepath.Join(GinkgoT().TempDir(), "shoot.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sshConfig).To(Equal(&config.SSHConfig{}))
+	})
+
+	It("should save and load the configuration", func() {
+		filename := config.ShootSSHConfigFilename(GinkgoT().TempDir(), "garden", "project", "shoot")
+		sshConfig := &config.SSHConfig{User: "core", BastionPort: "2222"}
+
+		Expect(sshConfig.SaveToFile(filename)).To(Succeed())
+
+		info, err := os.Stat(filename)
+		Expect(err).NotTo(HaveOccurred())
+		if os.PathSeparator == '/' {
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+		}
+
+		loaded, err := config.LoadSSHConfigFromFile(filename)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(Equal(sshConfig))
+	})
+
+	It("should fail to load an invalid file", func() {
+		filename := filepath.Join(GinkgoT().TempDir(), "shoot.yaml")
+		Expect(os.WriteFile(filename, []byte("user: [core"), 0o600)).To(Succeed())
+
+		_, err := config.LoadSSHConfigFromFile(filename)
+		Expect(err).To(MatchError(ContainSubstring("failed to decode SSH configuration file")))
+	})
+
+	It("should take the empty values from the defaults", func() {
+		sshConfig := &config.SSHConfig{User: "core"}
+
+		Expect(sshConfig.WithDefaults(&config.SSHConfig{User: "gardener", BastionPort: "2222"})).To(Equal(&config.SSHConfig{User: "core", BastionPort: "2222"}))
+		Expect(sshConfig.WithDefaults(nil)).To(Equal(sshConfig))
+		Expect((*config.SSHConfig)(nil).WithDefaults(sshConfig)).To(Equal(sshConfig))
+	})
+
+	It("should take the wait timeout from the defaults", func() {
+		waitTimeout := &metav1.Duration{Duration: 2 * time.Minute}
+
+		Expect((&config.SSHConfig{}).WithDefaults(&config.SSHConfig{WaitTimeout: waitTimeout})).To(Equal(&config.SSHConfig{WaitTimeout: waitTimeout}))
+		Expect((&config.SSHConfig{WaitTimeout: &metav1.Duration{Duration: time.Minute}}).WithDefaults(&config.SSHConfig{WaitTimeout: waitTimeout}).WaitTimeout.Duration).To(Equal(time.Minute))
+	})
+
+	It("should decode the wait timeout as duration", func() {
+		filename := filepath.Join(GinkgoT().TempDir(), "shoot.yaml")
+		Expect(os.WriteFile(filename, []byte("waitTimeout: 90s\n"), 0o600)).To(Succeed())
+
+		sshConfig, err := config.LoadSSHConfigFromFile(filename)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sshConfig.WaitTimeout).To(Equal(&metav1.Duration{Duration: 90 * time.Second}))
+	})
+})
@@ -7,7 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package env
 
 import (
+	"bytes"
 	"embed"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -19,6 +21,7 @@ import (
 
 	sprigv3 "github.com/Masterminds/sprig/v3"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/util/jsonpath"
 
 	"github.com/gardener/gardenctl-v2/internal/util"
 )
@@ -53,7 +56,10 @@ func newTemplateImpl(filenames ...string) *templateImpl {
 		delegate: template.
 			New("base").
 			Funcs(sprigv3.TxtFuncMap()).
-			Funcs(template.FuncMap{"shellEscape": util.ShellEscape}),
+			Funcs(template.FuncMap{
+				"shellEscape": util.ShellEscape,
+				"jsonPath":    jsonPath,
+			}),
 	}
 
 	if len(filenames) > 0 {
@@ -85,6 +91,38 @@ func (t *templateImpl) Delegate() *template.Template {
 	return t.delegate
 }
 
+// jsonPath returns the result of the given JSONPath template, e.g. "{.private_key.id}", applied to data.
+// If data is a string or a byte slice, it is decoded as JSON first. The function can be used in pipelines,
+// e.g. {{.serviceaccountJSON | jsonPath "{.client_email}"}}.
+func jsonPath(path string, data interface{}) (string, error) {
+	var raw []byte
+
+	switch v := data.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	}
+
+	if raw != nil {
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return "", fmt.Errorf("failed to decode JSON for JSONPath %q: %w", path, err)
+		}
+	}
+
+	j := jsonpath.New("jsonPath")
+	if err := j.Parse(path); err != nil {
+		return "", fmt.Errorf("failed to parse JSONPath %q: %w", path, err)
+	}
+
+	var buf bytes.Buffer
+	if err := j.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute JSONPath %q: %w", path, err)
+	}
+
+	return buf.String(), nil
+}
+
 func parseFile(fsys fs.FS, t *template.Template, filename string) error {
 	name := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
 	// For embed.FS the path separator in is a forward slash, even on Windows systems.
@@ -181,6 +181,88 @@ unset CLOUDSDK_CONFIG;
 		)
 	})
 
+	Describe("parsing the aws template", func() {
+		const exportFormat = `export AWS_ACCESS_KEY_ID='%[3]s';
+export AWS_SECRET_ACCESS_KEY='%[4]s';
+export AWS_DEFAULT_REGION='%[2]s';
+
+# Run this command to configure aws for your shell:
+# eval $(gardenctl provider-env %[1]s)
+`
+
+		const assumeRoleFormat = `export AWS_ACCESS_KEY_ID='%[3]s';
+export AWS_SECRET_ACCESS_KEY='%[4]s';
+export AWS_DEFAULT_REGION='%[2]s';
+unset AWS_SESSION_TOKEN;
+eval "$(aws sts assume-role --role-arn '%[5]s' --role-session-name 'gardenctl' --external-id '%[6]s' --query 'Credentials.[AccessKeyId,SecretAccessKey,SessionToken]' --output text | awk '{ print "export AWS_ACCESS_KEY_ID=" $1 "; export AWS_SECRET_ACCESS_KEY=" $2 "; export AWS_SESSION_TOKEN=" $3 ";" }')";
+
+# Run this command to configure aws for your shell:
+# eval $(gardenctl provider-env %[1]s)
+`
+
+		const unsetFormat = `unset AWS_ACCESS_KEY_ID;
+unset AWS_SECRET_ACCESS_KEY;
+unset AWS_SESSION_TOKEN;
+unset AWS_DEFAULT_REGION;
+
+# Run this command to reset the aws configuration for your shell:
+# eval $(gardenctl provider-env -u %[1]s)
+`
+		var (
+			accessKeyID     = "access-key-id"
+			secretAccessKey = "secret-access-key"
+			externalID      = "external-id"
+		)
+
+		BeforeEach(func() {
+			providerType = "aws"
+			cli = "aws"
+			commandPath = "gardenctl provider-env"
+			filenames = append(filenames, "helpers", providerType)
+		})
+
+		JustBeforeEach(func() {
+			data["accessKeyID"] = accessKeyID
+			data["secretAccessKey"] = secretAccessKey
+			data["externalID"] = externalID
+			data["roleSessionName"] = "gardenctl"
+		})
+
+		DescribeTable("executing the bash template",
+			func(unset bool, roleARN string, format string) {
+				data["roleARN"] = roleARN
+				metadata["shell"] = shell
+				metadata["unset"] = unset
+				Expect(t.ExecuteTemplate(out, shell, data)).To(Succeed())
+				Expect(out.String()).To(Equal(fmt.Sprintf(format, shell, region, accessKeyID, secretAccessKey, roleARN, externalID)))
+			},
+			Entry("export environment variables", false, "", exportFormat),
+			Entry("export environment variables and assume a role", false, "arn:aws:iam::123456789012:role/admin", assumeRoleFormat),
+			Entry("unset environment variables", true, "arn:aws:iam::123456789012:role/admin", unsetFormat),
+		)
+
+		It("should assume the role in fish", func() {
+			data["roleARN"] = "arn:aws:iam::123456789012:role/admin"
+			data["stsEndpoint"] = "https://sts.example.org"
+			metadata["shell"] = "fish"
+			Expect(t.ExecuteTemplate(out, "fish", data)).To(Succeed())
+			Expect(out.String()).To(ContainSubstring("set -gx AWS_DEFAULT_REGION 'region';\nset -e AWS_SESSION_TOKEN;\n" +
+				"set -l credentials (aws sts assume-role --role-arn 'arn:aws:iam::123456789012:role/admin' --role-session-name 'gardenctl' " +
+				"--external-id 'external-id' --endpoint-url 'https://sts.example.org' --query 'Credentials.[AccessKeyId,SecretAccessKey,SessionToken]' --output text | string split \\t); " +
+				"and set -gx AWS_ACCESS_KEY_ID $credentials[1]; and set -gx AWS_SECRET_ACCESS_KEY $credentials[2]; and set -gx AWS_SESSION_TOKEN $credentials[3];\n"))
+		})
+
+		It("should assume the role in powershell", func() {
+			data["roleARN"] = "arn:aws:iam::123456789012:role/admin"
+			metadata["shell"] = "powershell"
+			Expect(t.ExecuteTemplate(out, "powershell", data)).To(Succeed())
+			Expect(out.String()).To(ContainSubstring("Remove-Item -ErrorAction SilentlyContinue Env:\\AWS_SESSION_TOKEN;\n" +
+				"$credentials = aws sts assume-role --role-arn 'arn:aws:iam::123456789012:role/admin' --role-session-name 'gardenctl' " +
+				"--external-id 'external-id' --query Credentials --output json | ConvertFrom-Json; " +
+				"if ($credentials) { $Env:AWS_ACCESS_KEY_ID = $credentials.AccessKeyId; $Env:AWS_SECRET_ACCESS_KEY = $credentials.SecretAccessKey; $Env:AWS_SESSION_TOKEN = $credentials.SessionToken };\n"))
+		})
+	})
+
 	Describe("parsing the azure template", func() {
 		const exportFormat = `$Env:AZURE_CLIENT_ID = '%[2]s';
 $Env:AZURE_CLIENT_SECRET = '%[3]s';
@@ -296,6 +378,37 @@ Remove-Item -ErrorAction SilentlyContinue Env:\AZURE_CONFIG_DIR;
 		})
 	})
 
+	Describe("executing custom templates with helper functions", func() {
+		var filename string
+
+		BeforeEach(func() {
+			filenames = append(filenames, "helpers")
+			cli = "functions"
+			filename = filepath.Join("templates", "functions.tmpl")
+			writeTempFile(filename, readTestFile("templates/functions.tmpl"))
+			data["testToken"] = "token"
+		})
+
+		AfterEach(func() {
+			removeTempFile(filename)
+		})
+
+		It("should base64 encode a value and extract a nested JSON value", func() {
+			data["serviceaccountJSON"] = `{"account":{"email":"test@example.org"}}`
+
+			Expect(t.ParseFiles(filepath.Join(gardenHomeDir, filename))).To(Succeed())
+			Expect(t.ExecuteTemplate(out, shell, data)).To(Succeed())
+			Expect(out.String()).To(HavePrefix("export TEST_TOKEN='dG9rZW4=';\nexport TEST_ACCOUNT='test@example.org';\n"))
+		})
+
+		It("should fail if the value is not valid JSON", func() {
+			data["serviceaccountJSON"] = "invalid"
+
+			Expect(t.ParseFiles(filepath.Join(gardenHomeDir, filename))).To(Succeed())
+			Expect(t.ExecuteTemplate(out, shell, data)).To(MatchError(ContainSubstring(`failed to decode JSON for JSONPath "{.account.email}"`)))
+		})
+	})
+
 	Describe("when parsing embedded templates", func() {
 		var (
 			fsys         = testdata.FS
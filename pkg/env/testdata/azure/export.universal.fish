@@ -0,0 +1,11 @@
+set -Ux AZURE_CLIENT_ID 'client-id';
+set -Ux AZURE_CLIENT_SECRET 'client-secret';
+set -Ux AZURE_TENANT_ID 'tenant-id';
+set -Ux AZURE_SUBSCRIPTION_ID 'subscription-id';
+set -Ux AZURE_CONFIG_DIR '%[1]s';
+az login --service-principal --username "$AZURE_CLIENT_ID" --password "$AZURE_CLIENT_SECRET" --tenant "$AZURE_TENANT_ID";
+az account set --subscription "$AZURE_SUBSCRIPTION_ID";
+printf 'Run the following command to log out and remove access to Azure subscriptions:\n$ eval (gardenctl provider-env --fish-universal --garden test --project project --shoot shoot -u fish)\n';
+
+# Run this command to configure az for your shell:
+# eval (gardenctl provider-env --fish-universal fish)
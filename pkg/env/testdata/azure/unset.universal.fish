@@ -0,0 +1,9 @@
+az logout --username "$AZURE_CLIENT_ID";
+set -Ue AZURE_CLIENT_ID;
+set -Ue AZURE_CLIENT_SECRET;
+set -Ue AZURE_TENANT_ID;
+set -Ue AZURE_SUBSCRIPTION_ID;
+set -Ue AZURE_CONFIG_DIR;
+
+# Run this command to reset the az configuration for your shell:
+# eval (gardenctl provider-env --fish-universal -u fish)
@@ -0,0 +1,5 @@
+export METAL_AUTH_TOKEN='0123456789abcdefABCDEF0123456789';
+export METAL_PROJECT_ID='0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0';
+
+# Run this command to configure metal for your shell:
+# eval $(gardenctl provider-env bash)
@@ -0,0 +1,4 @@
+export KUBECONFIG='/path/to/kube/config';
+
+# Run this command to configure kubectl for your shell:
+# eval $(gardenctl kubectl-env bash)
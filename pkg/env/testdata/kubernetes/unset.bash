@@ -0,0 +1,4 @@
+unset KUBECONFIG;
+
+# Run this command to reset the kubectl configuration for your shell:
+# eval $(gardenctl kubectl-env -u bash)
@@ -10,6 +10,7 @@ export OS_AUTH_TYPE='';
 export OS_APPLICATION_CREDENTIAL_ID='';
 export OS_APPLICATION_CREDENTIAL_NAME='';
 export OS_APPLICATION_CREDENTIAL_SECRET='';
+unset OS_CACERT;
 
 # Run this command to configure openstack for your shell:
 # eval $(gardenctl provider-env bash)
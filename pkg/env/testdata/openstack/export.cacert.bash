@@ -0,0 +1,16 @@
+export OS_AUTH_URL='keyStoneURL';
+export OS_PROJECT_DOMAIN_NAME='domain';
+export OS_USER_DOMAIN_NAME='domain';
+export OS_REGION_NAME='europe';
+export OS_AUTH_STRATEGY='keystone';
+export OS_TENANT_NAME='tenant';
+export OS_USERNAME='user';
+export OS_PASSWORD='secret';
+export OS_AUTH_TYPE='';
+export OS_APPLICATION_CREDENTIAL_ID='';
+export OS_APPLICATION_CREDENTIAL_NAME='';
+export OS_APPLICATION_CREDENTIAL_SECRET='';
+export OS_CACERT='%s';
+
+# Run this command to configure openstack for your shell:
+# eval $(gardenctl provider-env bash)
@@ -0,0 +1,7 @@
+export GOVC_URL='https://vcenter.example.org';
+export GOVC_USERNAME='admin@vsphere.local';
+export GOVC_PASSWORD='secret';
+export GOVC_INSECURE='false';
+
+# Run this command to configure govc for your shell:
+# eval $(gardenctl provider-env bash)
@@ -0,0 +1,7 @@
+set -gx GOVC_URL 'https://vcenter.example.org';
+set -gx GOVC_USERNAME 'admin@vsphere.local';
+set -gx GOVC_PASSWORD 'secret';
+set -gx GOVC_INSECURE 'true';
+
+# Run this command to configure govc for your shell:
+# eval (gardenctl provider-env fish)
@@ -0,0 +1,92 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flags
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/gardener/gardenctl-v2/internal/util"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+// completionCacheTTL is the duration for which the completion values fetched from the garden are reused.
+// Each completion request is a separate gardenctl process, hence the values are cached in the session directory.
+var completionCacheTTL = 30 * time.Second
+
+// completionCacheEntry holds the cached completion values of a flag or argument.
+type completionCacheEntry struct {
+	// Key identifies the target the values have been fetched for
+	Key string `json:"key"`
+	// Timestamp is the time the values have been fetched
+	Timestamp time.Time `json:"timestamp"`
+	// Values are the completion values
+	Values []string `json:"values"`
+}
+
+// WithCompletionCache returns a completion function that reuses the values of the given completion function
+// for a short time, so that repeatedly pressing tab does not query the garden every time. The values are cached
+// per name and per targeted garden, project and seed in the session directory. Errors are never cached.
+func WithCompletionCache(factory util.Factory, name string, completionFunc func(ctx context.Context, manager target.Manager) ([]string, error)) func(ctx context.Context, manager target.Manager) ([]string, error) {
+	return func(ctx context.Context, manager target.Manager) ([]string, error) {
+		t, err := manager.CurrentTarget()
+		if err != nil {
+			return completionFunc(ctx, manager)
+		}
+
+		key := t.GardenName() + "/" + t.ProjectName() + "/" + t.SeedName()
+		filename := filepath.Join(manager.SessionDir(), "cache", "completion-"+name+".json")
+		now := factory.Clock().Now()
+
+		if entry, err := readCompletionCache(filename); err == nil && entry.Key == key && now.Sub(entry.Timestamp) < completionCacheTTL {
+			return entry.Values, nil
+		}
+
+		values, err := completionFunc(ctx, manager)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := writeCompletionCache(filename, &completionCacheEntry{Key: key, Timestamp: now, Values: values}); err != nil {
+			klog.V(1).Infof("failed to write completion cache: %v", err)
+		}
+
+		return values, nil
+	}
+}
+
+func readCompletionCache(filename string) (*completionCacheEntry, error) {
+	data, err := os.ReadFile(filename) // #nosec G304 -- The file is located in the session directory
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &completionCacheEntry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func writeCompletionCache(filename string, entry *completionCacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, data, 0o600)
+}
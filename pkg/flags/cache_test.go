@@ -0,0 +1,124 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flags_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clientmocks "github.com/gardener/gardenctl-v2/internal/client/mocks"
+	"github.com/gardener/gardenctl-v2/internal/fake"
+	utilmocks "github.com/gardener/gardenctl-v2/internal/util/mocks"
+	"github.com/gardener/gardenctl-v2/pkg/flags"
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+var _ = Describe("Completion cache", func() {
+	var (
+		ctrl           *gomock.Controller
+		gardenClient   client.Client
+		targetProvider *fake.TargetProvider
+		factory        *fake.Factory
+		now            time.Time
+		completionFunc func(ctx context.Context, manager target.Manager) ([]string, error)
+	)
+
+	newProject := func(name string) *gardencorev1beta1.Project {
+		return &gardencorev1beta1.Project{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: gardencorev1beta1.ProjectSpec{
+				Namespace: ptr.To("garden-" + name),
+			},
+		}
+	}
+
+	complete := func() ([]string, error) {
+		manager, err := factory.Manager()
+		Expect(err).NotTo(HaveOccurred())
+
+		return completionFunc(factory.Context(), manager)
+	}
+
+	BeforeEach(func() {
+		gardenClient = fake.NewClientWithObjects(newProject("prod1"), newProject("prod2"))
+
+		ctrl = gomock.NewController(GinkgoT())
+		clientProvider := clientmocks.NewMockProvider(ctrl)
+		clientProvider.EXPECT().FromClientConfig(gomock.Any()).Return(gardenClient, nil).AnyTimes()
+
+		now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock := utilmocks.NewMockClock(ctrl)
+		clock.EXPECT().Now().DoAndReturn(func() time.Time { return now }).AnyTimes()
+
+		targetProvider = fake.NewFakeTargetProvider(target.NewTarget(cfg.Gardens[0].Name, "", "", ""))
+		factory = fake.NewFakeFactory(cfg, clock, clientProvider, targetProvider)
+		factory.SessionDirectory = GinkgoT().TempDir()
+
+		completionFunc = flags.WithCompletionCache(factory, "project", flags.ProjectFlagCompletionFunc)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should return the project names of the garden", func() {
+		Expect(complete()).To(Equal([]string{"prod1", "prod2"}))
+	})
+
+	It("should return the cached project names within the TTL", func() {
+		Expect(complete()).To(Equal([]string{"prod1", "prod2"}))
+		Expect(gardenClient.Create(context.Background(), newProject("prod3"))).To(Succeed())
+
+		now = now.Add(10 * time.Second)
+		Expect(complete()).To(Equal([]string{"prod1", "prod2"}))
+	})
+
+	It("should fetch the project names again after the TTL", func() {
+		Expect(complete()).To(Equal([]string{"prod1", "prod2"}))
+		Expect(gardenClient.Create(context.Background(), newProject("prod3"))).To(Succeed())
+
+		now = now.Add(time.Minute)
+		Expect(complete()).To(Equal([]string{"prod1", "prod2", "prod3"}))
+	})
+
+	It("should fetch the project names again if the target has changed", func() {
+		Expect(complete()).To(Equal([]string{"prod1", "prod2"}))
+		Expect(gardenClient.Create(context.Background(), newProject("prod3"))).To(Succeed())
+
+		targetProvider.Target = target.NewTarget(cfg.Gardens[0].Name, "prod1", "", "")
+		Expect(complete()).To(Equal([]string{"prod1", "prod2", "prod3"}))
+	})
+
+	It("should not cache errors", func() {
+		calls := 0
+		completionFunc = flags.WithCompletionCache(factory, "project", func(_ context.Context, _ target.Manager) ([]string, error) {
+			calls++
+			if calls == 1 {
+				return nil, errors.New("garden not reachable")
+			}
+
+			return []string{"prod1"}, nil
+		})
+
+		_, err := complete()
+		Expect(err).To(MatchError("garden not reachable"))
+		Expect(complete()).To(Equal([]string{"prod1"}))
+		Expect(complete()).To(Equal([]string{"prod1"}))
+		Expect(calls).To(Equal(2))
+	})
+})
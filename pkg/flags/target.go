@@ -20,22 +20,23 @@ import (
 
 // RegisterCompletionFuncsForTargetFlags registers the completion functions to a given cobra command
 // for the target flags (--garden, --project, --seed and --shoot). Each completion function is only
-// registered if the flag has been previously added to the provided flag set.
+// registered if the flag has been previously added to the provided flag set. The project, seed and
+// shoot names are fetched from the garden and cached for a short time.
 func RegisterCompletionFuncsForTargetFlags(cmd *cobra.Command, factory util.Factory, ioStreams util.IOStreams, _ *pflag.FlagSet) {
 	if cmd.Flag("garden") != nil {
 		utilruntime.Must(cmd.RegisterFlagCompletionFunc("garden", completionWrapper(factory, ioStreams, gardenFlagCompletionFunc)))
 	}
 
 	if cmd.Flag("project") != nil {
-		utilruntime.Must(cmd.RegisterFlagCompletionFunc("project", completionWrapper(factory, ioStreams, projectFlagCompletionFunc)))
+		utilruntime.Must(cmd.RegisterFlagCompletionFunc("project", completionWrapper(factory, ioStreams, WithCompletionCache(factory, "project", projectFlagCompletionFunc))))
 	}
 
 	if cmd.Flag("seed") != nil {
-		utilruntime.Must(cmd.RegisterFlagCompletionFunc("seed", completionWrapper(factory, ioStreams, seedFlagCompletionFunc)))
+		utilruntime.Must(cmd.RegisterFlagCompletionFunc("seed", completionWrapper(factory, ioStreams, WithCompletionCache(factory, "seed", seedFlagCompletionFunc))))
 	}
 
 	if cmd.Flag("shoot") != nil {
-		utilruntime.Must(cmd.RegisterFlagCompletionFunc("shoot", completionWrapper(factory, ioStreams, shootFlagCompletionFunc)))
+		utilruntime.Must(cmd.RegisterFlagCompletionFunc("shoot", completionWrapper(factory, ioStreams, WithCompletionCache(factory, "shoot", shootFlagCompletionFunc))))
 	}
 }
 
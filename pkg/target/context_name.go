@@ -0,0 +1,108 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package target
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	clientgarden "github.com/gardener/gardenctl-v2/internal/client/garden"
+)
+
+// ValidateContextName returns an error if the given name cannot be used as the name of a kubeconfig context.
+func ValidateContextName(name string) error {
+	if name == "" {
+		return errors.New("the context name must not be empty")
+	}
+
+	for _, r := range name {
+		if unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return fmt.Errorf("invalid context name %q: must not contain whitespace or control characters", name)
+		}
+	}
+
+	return nil
+}
+
+// RenameCurrentContext renames the current context of the given kubeconfig. The clusters and users
+// referenced by the context are not changed.
+func RenameCurrentContext(rawConfig *clientcmdapi.Config, name string) error {
+	if rawConfig.CurrentContext == name {
+		return nil
+	}
+
+	context, ok := rawConfig.Contexts[rawConfig.CurrentContext]
+	if !ok {
+		return fmt.Errorf("no context found for current context %q", rawConfig.CurrentContext)
+	}
+
+	if _, ok := rawConfig.Contexts[name]; ok {
+		return fmt.Errorf("cannot rename the current context to %q: a context with this name already exists", name)
+	}
+
+	delete(rawConfig.Contexts, rawConfig.CurrentContext)
+	rawConfig.Contexts[name] = context
+	rawConfig.CurrentContext = name
+
+	return nil
+}
+
+// ParseContextName parses the name of a context generated for a shoot kubeconfig, which has the format
+// <namespace>--<shoot>-<address>, and returns the namespace and the name of the shoot.
+func ParseContextName(name string) (string, string, error) {
+	namespace, rest, found := strings.Cut(name, "--")
+	if found && namespace != "" {
+		for _, address := range []string{
+			clientgarden.AdvertisedAddressExternal,
+			clientgarden.AdvertisedAddressInternal,
+			clientgarden.AdvertisedAddressUnmanaged,
+		} {
+			if shootName, ok := strings.CutSuffix(rest, "-"+address); ok && shootName != "" {
+				return namespace, shootName, nil
+			}
+		}
+	}
+
+	return "", "", fmt.Errorf("context %q was not generated by gardenctl, must be in the format <namespace>--<shoot>-<address>", name)
+}
+
+// GardenClusterIdentity returns the identity of the garden cluster referenced by the current context of
+// a shoot kubeconfig generated by gardenctl. An empty string is returned if the identity cannot be determined.
+func GardenClusterIdentity(rawConfig *clientcmdapi.Config) string {
+	context, ok := rawConfig.Contexts[rawConfig.CurrentContext]
+	if !ok {
+		return ""
+	}
+
+	if cluster, ok := rawConfig.Clusters[context.Cluster]; ok {
+		if extension, ok := cluster.Extensions["client.authentication.k8s.io/exec"].(*runtime.Unknown); ok {
+			execPluginConfig := struct {
+				GardenClusterIdentity string `json:"gardenClusterIdentity"`
+			}{}
+			if err := json.Unmarshal(extension.Raw, &execPluginConfig); err == nil && execPluginConfig.GardenClusterIdentity != "" {
+				return execPluginConfig.GardenClusterIdentity
+			}
+		}
+	}
+
+	// legacy kubeconfigs pass the identity as argument to the exec plugin
+	if authInfo, ok := rawConfig.AuthInfos[context.AuthInfo]; ok && authInfo.Exec != nil {
+		for _, arg := range authInfo.Exec.Args {
+			if identity, ok := strings.CutPrefix(arg, "--garden-cluster-identity="); ok {
+				return identity
+			}
+		}
+	}
+
+	return ""
+}
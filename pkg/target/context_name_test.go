@@ -0,0 +1,116 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package target_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/gardener/gardenctl-v2/pkg/target"
+)
+
+var _ = Describe("Context Name", func() {
+	DescribeTable("validating the context name",
+		func(name string, valid bool) {
+			err := target.ValidateContextName(name)
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("generated name", "garden-project--shoot-external", true),
+		Entry("name with user", "admin@my-shoot", true),
+		Entry("empty name", "", false),
+		Entry("name with space", "my shoot", false),
+		Entry("name with newline", "my-shoot\n", false),
+	)
+
+	DescribeTable("parsing the context name",
+		func(name, namespace, shootName string) {
+			actualNamespace, actualShootName, err := target.ParseContextName(name)
+			if namespace == "" {
+				Expect(err).To(MatchError(ContainSubstring("was not generated by gardenctl")))
+				return
+			}
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualNamespace).To(Equal(namespace))
+			Expect(actualShootName).To(Equal(shootName))
+		},
+		Entry("external address", "garden-project--shoot-external", "garden-project", "shoot"),
+		Entry("internal address", "garden-project--shoot-internal", "garden-project", "shoot"),
+		Entry("unmanaged address", "garden--my-shoot-unmanaged", "garden", "my-shoot"),
+		Entry("unknown address", "garden-project--shoot-other", "", ""),
+		Entry("missing namespace", "--shoot-external", "", ""),
+		Entry("missing shoot", "garden-project---external", "", ""),
+		Entry("custom name", "admin@my-shoot", "", ""),
+	)
+
+	Describe("determining the garden cluster identity", func() {
+		var config *clientcmdapi.Config
+
+		BeforeEach(func() {
+			config = clientcmdapi.NewConfig()
+			config.Clusters["garden-project--shoot-external"] = clientcmdapi.NewCluster()
+			config.AuthInfos["garden-project--shoot"] = clientcmdapi.NewAuthInfo()
+			config.Contexts["garden-project--shoot-external"] = &clientcmdapi.Context{Cluster: "garden-project--shoot-external", AuthInfo: "garden-project--shoot"}
+			config.CurrentContext = "garden-project--shoot-external"
+		})
+
+		It("should return the identity of the exec plugin config", func() {
+			config.Clusters["garden-project--shoot-external"].Extensions["client.authentication.k8s.io/exec"] = &runtime.Unknown{
+				Raw: []byte(`{"gardenClusterIdentity":"landscape-dev","shootRef":{"namespace":"garden-project","name":"shoot"}}`),
+			}
+
+			Expect(target.GardenClusterIdentity(config)).To(Equal("landscape-dev"))
+		})
+
+		It("should return the identity of the legacy exec plugin arguments", func() {
+			config.AuthInfos["garden-project--shoot"].Exec = &clientcmdapi.ExecConfig{
+				Args: []string{"get-client-certificate", "--name=shoot", "--garden-cluster-identity=landscape-dev"},
+			}
+
+			Expect(target.GardenClusterIdentity(config)).To(Equal("landscape-dev"))
+		})
+
+		It("should return an empty identity if it cannot be determined", func() {
+			Expect(target.GardenClusterIdentity(config)).To(BeEmpty())
+		})
+	})
+
+	Describe("renaming the current context", func() {
+		var config *clientcmdapi.Config
+
+		BeforeEach(func() {
+			config = clientcmdapi.NewConfig()
+			config.Contexts["shoot-external"] = &clientcmdapi.Context{Cluster: "external", AuthInfo: "user"}
+			config.Contexts["shoot-internal"] = &clientcmdapi.Context{Cluster: "internal", AuthInfo: "user"}
+			config.CurrentContext = "shoot-external"
+		})
+
+		It("should rename the current context only", func() {
+			Expect(target.RenameCurrentContext(config, "my-shoot")).To(Succeed())
+			Expect(config.CurrentContext).To(Equal("my-shoot"))
+			Expect(config.Contexts).To(HaveLen(2))
+			Expect(config.Contexts["my-shoot"].Cluster).To(Equal("external"))
+			Expect(config.Contexts).To(HaveKey("shoot-internal"))
+		})
+
+		It("should fail if a context with the name already exists", func() {
+			Expect(target.RenameCurrentContext(config, "shoot-internal")).To(MatchError(ContainSubstring("already exists")))
+			Expect(config.CurrentContext).To(Equal("shoot-external"))
+		})
+
+		It("should fail if the current context does not exist", func() {
+			config.CurrentContext = "unknown"
+			Expect(target.RenameCurrentContext(config, "my-shoot")).To(MatchError(`no context found for current context "unknown"`))
+		})
+	})
+})
@@ -6,4 +6,18 @@ SPDX-License-Identifier: Apache-2.0
 
 package target
 
+import "path/filepath"
+
 var Merge = merge
+
+const MaxHistorySize = maxHistorySize
+
+// HistoryLen returns the number of targets in the target history of the given session directory.
+func HistoryLen(sessionDir string) (int, error) {
+	history, err := (&fsHistory{historyFile: filepath.Join(sessionDir, "target-history.yaml")}).read()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(history.Targets), nil
+}
@@ -0,0 +1,110 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package target
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// maxHistorySize is the maximum number of previous targets that are kept in the history.
+const maxHistorySize = 10
+
+// ErrNoPreviousTarget is returned if the target history is empty.
+var ErrNoPreviousTarget = errors.New("no previous target")
+
+// targetHistory is the list of previous targets, the most recent one is the last element.
+type targetHistory struct {
+	Targets []targetImpl `json:"targets,omitempty"`
+}
+
+// fsHistory reads and writes the target history from the local filesystem.
+type fsHistory struct {
+	historyFile string
+}
+
+func (h *fsHistory) read() (*targetHistory, error) {
+	history := &targetHistory{}
+
+	buf, err := os.ReadFile(h.historyFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return history, nil
+		}
+
+		return nil, fmt.Errorf("failed to read target history file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(buf, history); err != nil {
+		return nil, fmt.Errorf("failed to decode target history as YAML: %w", err)
+	}
+
+	return history, nil
+}
+
+func (h *fsHistory) write(history *targetHistory) error {
+	buf, err := yaml.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("failed to encode target history as YAML: %w", err)
+	}
+
+	if err := os.WriteFile(h.historyFile, buf, 0o600); err != nil {
+		return fmt.Errorf("failed to write target history file: %w", err)
+	}
+
+	return nil
+}
+
+// push appends the given target to the history. Empty targets and targets that are
+// identical to the most recent entry are skipped. The oldest entries are dropped
+// once the history exceeds maxHistorySize.
+func (h *fsHistory) push(t targetImpl) error {
+	if t.IsEmpty() {
+		return nil
+	}
+
+	history, err := h.read()
+	if err != nil {
+		return err
+	}
+
+	if n := len(history.Targets); n > 0 && history.Targets[n-1] == t {
+		return nil
+	}
+
+	history.Targets = append(history.Targets, t)
+	if n := len(history.Targets); n > maxHistorySize {
+		history.Targets = history.Targets[n-maxHistorySize:]
+	}
+
+	return h.write(history)
+}
+
+// pop removes and returns the most recent target of the history.
+func (h *fsHistory) pop() (*targetImpl, error) {
+	history, err := h.read()
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(history.Targets)
+	if n == 0 {
+		return nil, ErrNoPreviousTarget
+	}
+
+	previous := history.Targets[n-1]
+	history.Targets = history.Targets[:n-1]
+
+	if err := h.write(history); err != nil {
+		return nil, err
+	}
+
+	return &previous, nil
+}
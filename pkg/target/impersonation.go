@@ -0,0 +1,57 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package target
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// Impersonation holds the user and groups to impersonate when the manager
+// creates clients for seed and shoot clusters.
+type Impersonation struct {
+	// User is the name of the user to impersonate.
+	User string
+	// Groups are the groups to impersonate.
+	Groups []string
+}
+
+type impersonationContextKey struct{}
+
+// WithImpersonation returns a copy of parent context to which the given Impersonation has been added.
+func WithImpersonation(ctx context.Context, impersonation Impersonation) context.Context {
+	return context.WithValue(ctx, impersonationContextKey{}, impersonation)
+}
+
+// ImpersonationFromContext extracts an Impersonation from the context.
+func ImpersonationFromContext(ctx context.Context) (Impersonation, bool) {
+	impersonation, ok := ctx.Value(impersonationContextKey{}).(Impersonation)
+	if !ok || impersonation.User == "" {
+		return Impersonation{}, false
+	}
+
+	return impersonation, true
+}
+
+func clientConfigWithImpersonation(clientConfig clientcmd.ClientConfig, impersonation Impersonation) (clientcmd.ClientConfig, error) {
+	rawConfig, err := clientConfig.RawConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raw client configuration: %w", err)
+	}
+
+	overrides := &clientcmd.ConfigOverrides{
+		AuthInfo: clientcmdapi.AuthInfo{
+			Impersonate:       impersonation.User,
+			ImpersonateGroups: impersonation.Groups,
+		},
+	}
+
+	return clientcmd.NewDefaultClientConfig(rawConfig, overrides), nil
+}
@@ -90,6 +90,14 @@ type Manager interface {
 	// against patterns defined in gardenctl configuration. Some values may only match a subset
 	// of a pattern
 	TargetMatchPattern(ctx context.Context, tf TargetFlags, value string) error
+	// TargetNamespacedShoot replaces the whole target
+	// The project is determined by the given namespace of the shoot. If the garden name is empty,
+	// the currently targeted garden is used
+	TargetNamespacedShoot(ctx context.Context, gardenName, namespace, shootName string) error
+	// TargetBack restores the previous target from the target history and pushes the current target,
+	// so that two consecutive calls toggle between two targets
+	// It returns ErrNoPreviousTarget if the history is empty
+	TargetBack(ctx context.Context) (Target, error)
 
 	// ClientConfig returns the client config for a target
 	ClientConfig(ctx context.Context, t Target) (clientcmd.ClientConfig, error)
@@ -417,6 +425,67 @@ func (m *managerImpl) TargetMatchPattern(ctx context.Context, tf TargetFlags, va
 	return m.updateTarget(ctx, target)
 }
 
+func (m *managerImpl) TargetNamespacedShoot(ctx context.Context, gardenName, namespace, shootName string) error {
+	currentTarget, err := m.CurrentTarget()
+	if err != nil {
+		return fmt.Errorf("failed to get current target: %w", err)
+	}
+
+	tb, err := NewTargetBuilder(m.config, m.clientProvider)
+	if err != nil {
+		return fmt.Errorf("failed to create new target builder: %w", err)
+	}
+
+	tb.Init(currentTarget)
+
+	if gardenName != "" {
+		tb.SetGarden(gardenName)
+	}
+
+	tb.SetNamespace(ctx, namespace)
+	tb.SetShoot(ctx, shootName)
+
+	target, err := tb.Build()
+	if err != nil {
+		return err
+	}
+
+	return m.updateTarget(ctx, target)
+}
+
+func (m *managerImpl) TargetBack(ctx context.Context) (Target, error) {
+	current, err := m.targetProvider.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	impl, ok := current.(*targetImpl)
+	if !ok {
+		return nil, errors.New("target must be using targetImpl as its underlying type")
+	}
+
+	previous, err := m.history().pop()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.writeTarget(ctx, previous); err != nil {
+		return nil, err
+	}
+
+	// the current target is pushed to the history, so that a subsequent
+	// call switches back to it, like "cd -"
+	if err := m.history().push(*impl); err != nil {
+		return nil, err
+	}
+
+	return previous, nil
+}
+
+func (m *managerImpl) history() *fsHistory {
+	return &fsHistory{historyFile: filepath.Join(m.sessionDirectory, "target-history.yaml")}
+}
+
 func (m *managerImpl) updateTarget(ctx context.Context, target Target) error {
 	return m.patchTarget(ctx, func(t *targetImpl) error {
 		t.Garden = target.GardenName()
@@ -546,7 +615,7 @@ func (m *managerImpl) SeedClient(ctx context.Context, t Target) (client.Client,
 		return nil, err
 	}
 
-	return m.clientProvider.FromClientConfig(config)
+	return m.newClient(ctx, config)
 }
 
 func (m *managerImpl) ShootClient(ctx context.Context, t Target) (client.Client, error) {
@@ -568,6 +637,21 @@ func (m *managerImpl) ShootClient(ctx context.Context, t Target) (client.Client,
 		return nil, err
 	}
 
+	return m.newClient(ctx, config)
+}
+
+// newClient returns a client for the given client config, impersonating the user
+// and groups from the context if present.
+func (m *managerImpl) newClient(ctx context.Context, config clientcmd.ClientConfig) (client.Client, error) {
+	if impersonation, ok := ImpersonationFromContext(ctx); ok {
+		var err error
+
+		config, err = clientConfigWithImpersonation(config, impersonation)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return m.clientProvider.FromClientConfig(config)
 }
 
@@ -592,12 +676,25 @@ func (m *managerImpl) patchTarget(ctx context.Context, patch func(t *targetImpl)
 		return errors.New("target must be using targetImpl as its underlying type")
 	}
 
+	previous := *impl
+
 	if err := patch(impl); err != nil {
 		return err
 	}
 
-	err = m.targetProvider.Write(impl)
-	if err != nil {
+	if err := m.writeTarget(ctx, impl); err != nil {
+		return err
+	}
+
+	if previous == *impl {
+		return nil
+	}
+
+	return m.history().push(previous)
+}
+
+func (m *managerImpl) writeTarget(ctx context.Context, target *targetImpl) error {
+	if err := m.targetProvider.Write(target); err != nil {
 		return err
 	}
 
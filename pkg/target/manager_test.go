@@ -8,6 +8,8 @@ package target_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
@@ -334,6 +336,15 @@ var _ = Describe("Target Manager", func() {
 		assertTargetProvider(targetProvider, t)
 	})
 
+	It("should error with the namespaces when multiple shoots match on the targeted seed", func() {
+		t := target.NewTarget(gardenName, "", seed.Name, "")
+		manager, targetProvider := createTestManager(t, cfg, clientProvider)
+
+		Expect(manager.TargetShoot(ctx, prod1AmbiguousShoot.Name)).To(MatchError(ContainSubstring(
+			"in the namespaces garden-prod1, garden-prod2, please target a project or seed to make your choice unambiguous")))
+		assertTargetProvider(targetProvider, t)
+	})
+
 	Describe("#TargetMatchPattern", func() {
 		var tf target.TargetFlags
 		BeforeEach(func() {
@@ -428,6 +439,46 @@ var _ = Describe("Target Manager", func() {
 		Expect(newClient).NotTo(BeNil())
 	})
 
+	It("should provide a seed client impersonating the user from the context", func() {
+		t := target.NewTarget(gardenName, "", seed.Name, "")
+		manager, _ := createTestManager(t, cfg, clientProvider)
+
+		seedClient := fake.NewClientWithObjects()
+		clientProvider.EXPECT().FromClientConfig(gomock.Any()).DoAndReturn(func(clientConfig clientcmd.ClientConfig) (client.Client, error) {
+			restConfig, err := clientConfig.ClientConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restConfig.Impersonate.UserName).To(Equal("jane"))
+			Expect(restConfig.Impersonate.Groups).To(Equal([]string{"operators"}))
+
+			return seedClient, nil
+		})
+
+		impersonationCtx := target.WithImpersonation(ctx, target.Impersonation{User: "jane", Groups: []string{"operators"}})
+		newClient, err := manager.SeedClient(impersonationCtx, t)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(newClient).To(BeIdenticalTo(seedClient))
+	})
+
+	It("should provide a shoot client impersonating the user from the context", func() {
+		t := target.NewTarget(gardenName, prod1Project.Name, "", prod1GoldenShoot.Name)
+		manager, _ := createTestManager(t, cfg, clientProvider)
+
+		shootClient := fake.NewClientWithObjects()
+		clientProvider.EXPECT().FromClientConfig(gomock.Any()).DoAndReturn(func(clientConfig clientcmd.ClientConfig) (client.Client, error) {
+			restConfig, err := clientConfig.ClientConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restConfig.Impersonate.UserName).To(Equal("jane"))
+			Expect(restConfig.Impersonate.Groups).To(BeEmpty())
+
+			return shootClient, nil
+		})
+
+		impersonationCtx := target.WithImpersonation(ctx, target.Impersonation{User: "jane"})
+		newClient, err := manager.ShootClient(impersonationCtx, t)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(newClient).To(BeIdenticalTo(shootClient))
+	})
+
 	It("should provide a shoot client", func() {
 		t := target.NewTarget(gardenName, prod1Project.Name, "", prod1GoldenShoot.Name)
 		manager, _ := createTestManager(t, cfg, clientProvider)
@@ -551,6 +602,93 @@ var _ = Describe("Target Manager", func() {
 		assertTargetProvider(targetProvider, t)
 	})
 
+	Describe("#TargetBack", func() {
+		BeforeEach(func() {
+			Expect(os.RemoveAll(filepath.Join(sessionDir, "target-history.yaml"))).To(Succeed())
+		})
+
+		It("should restore the previous targets", func() {
+			t := target.NewTarget(gardenName, "", "", "")
+			manager, targetProvider := createTestManager(t, cfg, clientProvider)
+
+			Expect(manager.TargetProject(ctx, prod1Project.Name)).To(Succeed())
+			Expect(manager.TargetShoot(ctx, prod1GoldenShoot.Name)).To(Succeed())
+
+			previous, err := manager.TargetBack(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(previous).To(Equal(target.NewTarget(gardenName, prod1Project.Name, "", "")))
+			assertTargetProvider(targetProvider, previous)
+		})
+
+		It("should toggle between two targets like cd -", func() {
+			t := target.NewTarget(gardenName, "", "", "")
+			manager, targetProvider := createTestManager(t, cfg, clientProvider)
+
+			Expect(manager.TargetProject(ctx, prod1Project.Name)).To(Succeed())
+			Expect(manager.TargetShoot(ctx, prod1GoldenShoot.Name)).To(Succeed())
+
+			projectTarget := target.NewTarget(gardenName, prod1Project.Name, "", "")
+			shootTarget := target.NewTarget(gardenName, prod1Project.Name, "", prod1GoldenShoot.Name)
+
+			for i := 0; i < 2; i++ {
+				previous, err := manager.TargetBack(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(previous).To(Equal(projectTarget))
+				assertTargetProvider(targetProvider, projectTarget)
+
+				previous, err = manager.TargetBack(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(previous).To(Equal(shootTarget))
+				assertTargetProvider(targetProvider, shootTarget)
+			}
+		})
+
+		It("should fail if there is no previous target", func() {
+			t := target.NewTarget(gardenName, "", "", "")
+			manager, targetProvider := createTestManager(t, cfg, clientProvider)
+
+			_, err := manager.TargetBack(ctx)
+			Expect(err).To(MatchError(target.ErrNoPreviousTarget))
+			assertTargetProvider(targetProvider, t)
+		})
+
+		It("should not push unchanged targets to the history", func() {
+			t := target.NewTarget(gardenName, "", "", "")
+			manager, _ := createTestManager(t, cfg, clientProvider)
+
+			Expect(manager.TargetGarden(ctx, gardenName)).To(Succeed())
+
+			_, err := manager.TargetBack(ctx)
+			Expect(err).To(MatchError(target.ErrNoPreviousTarget))
+		})
+
+		It("should not push an empty target to the history", func() {
+			t := target.NewTarget("", "", "", "")
+			manager, _ := createTestManager(t, cfg, clientProvider)
+
+			Expect(manager.TargetGarden(ctx, gardenName)).To(Succeed())
+
+			_, err := manager.TargetBack(ctx)
+			Expect(err).To(MatchError(target.ErrNoPreviousTarget))
+		})
+
+		It("should cap the size of the history", func() {
+			t := target.NewTarget(gardenName, "", "", "")
+			manager, _ := createTestManager(t, cfg, clientProvider)
+
+			for i := 0; i < 6; i++ {
+				Expect(manager.TargetProject(ctx, prod1Project.Name)).To(Succeed())
+				Expect(manager.TargetProject(ctx, prod2Project.Name)).To(Succeed())
+			}
+
+			Expect(target.HistoryLen(sessionDir)).To(Equal(target.MaxHistorySize))
+
+			_, err := manager.TargetBack(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target.HistoryLen(sessionDir)).To(BeNumerically("<=", target.MaxHistorySize))
+		})
+	})
+
 	Describe("Getting Client Configurations", func() {
 		var (
 			manager target.Manager
@@ -202,6 +202,21 @@ func (mr *MockManagerMockRecorder) ShootNames(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShootNames", reflect.TypeOf((*MockManager)(nil).ShootNames), arg0)
 }
 
+// TargetBack mocks base method.
+func (m *MockManager) TargetBack(arg0 context.Context) (target.Target, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TargetBack", arg0)
+	ret0, _ := ret[0].(target.Target)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TargetBack indicates an expected call of TargetBack.
+func (mr *MockManagerMockRecorder) TargetBack(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TargetBack", reflect.TypeOf((*MockManager)(nil).TargetBack), arg0)
+}
+
 // TargetControlPlane mocks base method.
 func (m *MockManager) TargetControlPlane(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -244,6 +259,20 @@ func (mr *MockManagerMockRecorder) TargetMatchPattern(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TargetMatchPattern", reflect.TypeOf((*MockManager)(nil).TargetMatchPattern), arg0, arg1, arg2)
 }
 
+// TargetNamespacedShoot mocks base method.
+func (m *MockManager) TargetNamespacedShoot(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TargetNamespacedShoot", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TargetNamespacedShoot indicates an expected call of TargetNamespacedShoot.
+func (mr *MockManagerMockRecorder) TargetNamespacedShoot(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TargetNamespacedShoot", reflect.TypeOf((*MockManager)(nil).TargetNamespacedShoot), arg0, arg1, arg2, arg3)
+}
+
 // TargetProject mocks base method.
 func (m *MockManager) TargetProject(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -14,6 +14,17 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+const (
+	// envTargetGarden is the environment variable that defines the garden of the current target.
+	envTargetGarden = "GCTL_TARGET_GARDEN"
+	// envTargetProject is the environment variable that defines the project of the current target.
+	envTargetProject = "GCTL_TARGET_PROJECT"
+	// envTargetSeed is the environment variable that defines the seed of the current target.
+	envTargetSeed = "GCTL_TARGET_SEED"
+	// envTargetShoot is the environment variable that defines the shoot of the current target.
+	envTargetShoot = "GCTL_TARGET_SHOOT"
+)
+
 // TargetReader can read targets.
 type TargetReader interface {
 	// Read returns the current target. If no target exists yet, a default
@@ -130,7 +141,8 @@ var _ TargetProvider = &dynamicTargetProvider{}
 
 // Read returns the current target from the TargetFile if no CLI
 // flags were given, and tries to construct a meaningful target
-// otherwise.
+// otherwise. A target given by the GCTL_TARGET_* environment variables
+// takes precedence over the TargetFile.
 func (p *dynamicTargetProvider) Read() (Target, error) {
 	// user gave everything we needed
 	if p.targetFlags.IsTargetValid() {
@@ -138,15 +150,47 @@ func (p *dynamicTargetProvider) Read() (Target, error) {
 	}
 
 	// user didn't specify anything at all or _some_ flags;
-	// in both cases we need to read the current target from disk
-	current, err := p.delegate.Read()
+	// in both cases we need to read the current target from
+	// the environment or from disk
+	current, err := targetFromEnvironment()
 	if err != nil {
 		return nil, err
 	}
 
+	if current == nil {
+		current, err = p.delegate.Read()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return merge(current, p.targetFlags)
 }
 
+// targetFromEnvironment returns the target defined by the GCTL_TARGET_* environment variables.
+// If none of them is set, nil is returned.
+func targetFromEnvironment() (Target, error) {
+	gardenName := os.Getenv(envTargetGarden)
+	projectName := os.Getenv(envTargetProject)
+	seedName := os.Getenv(envTargetSeed)
+	shootName := os.Getenv(envTargetShoot)
+
+	if gardenName == "" && projectName == "" && seedName == "" && shootName == "" {
+		return nil, nil
+	}
+
+	if gardenName == "" {
+		return nil, fmt.Errorf("environment variable %s is required if the target is defined by environment variables", envTargetGarden)
+	}
+
+	t := NewTarget(gardenName, projectName, seedName, shootName)
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid target environment variables: %w", err)
+	}
+
+	return t, nil
+}
+
 // Write takes a target and saves it permanently.
 func (p *dynamicTargetProvider) Write(t Target) error {
 	return p.delegate.Write(t)
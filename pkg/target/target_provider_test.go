@@ -221,6 +221,73 @@ var _ = Describe("Dynamic Target Provider", func() {
 		Entry("seed and project", target.NewTargetFlags("", "newproject", "newseed", "", false)),
 	)
 
+	Context("when the target is defined by environment variables", func() {
+		setenv := func(key, value string) {
+			Expect(os.Setenv(key, value)).To(Succeed())
+			DeferCleanup(os.Unsetenv, key)
+		}
+
+		BeforeEach(func() {
+			Expect(provider.Write(target.NewTarget("mygarden", "myproject", "", "myshoot"))).To(Succeed())
+
+			setenv("GCTL_TARGET_GARDEN", "envgarden")
+			setenv("GCTL_TARGET_PROJECT", "envproject")
+			setenv("GCTL_TARGET_SHOOT", "envshoot")
+		})
+
+		It("should prefer the environment variables over the persisted target", func() {
+			dtp := target.NewTargetProvider(tmpFile.Name(), target.NewTargetFlags("", "", "", "", false))
+
+			readBack, err := dtp.Read()
+			Expect(err).NotTo(HaveOccurred())
+			expectEqualTargets(readBack, target.NewTarget("envgarden", "envproject", "", "envshoot"))
+		})
+
+		It("should augment the environment variables with CLI flags", func() {
+			dtp := target.NewTargetProvider(tmpFile.Name(), target.NewTargetFlags("", "", "", "othershoot", false))
+
+			readBack, err := dtp.Read()
+			Expect(err).NotTo(HaveOccurred())
+			expectEqualTargets(readBack, target.NewTarget("envgarden", "envproject", "", "othershoot"))
+		})
+
+		It("should prefer complete CLI flags", func() {
+			tf := target.NewTargetFlags("newgarden", "", "newseed", "", false)
+			dtp := target.NewTargetProvider(tmpFile.Name(), tf)
+
+			readBack, err := dtp.Read()
+			Expect(err).NotTo(HaveOccurred())
+			expectEqualTargets(readBack, tf.ToTarget())
+		})
+
+		It("should not read the persisted target", func() {
+			Expect(os.WriteFile(tmpFile.Name(), []byte("garden: ["), 0o600)).To(Succeed())
+
+			dtp := target.NewTargetProvider(tmpFile.Name(), target.NewTargetFlags("", "", "", "", false))
+
+			_, err := dtp.Read()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should require the garden", func() {
+			Expect(os.Unsetenv("GCTL_TARGET_GARDEN")).To(Succeed())
+
+			dtp := target.NewTargetProvider(tmpFile.Name(), target.NewTargetFlags("", "", "", "", false))
+
+			_, err := dtp.Read()
+			Expect(err).To(MatchError("environment variable GCTL_TARGET_GARDEN is required if the target is defined by environment variables"))
+		})
+
+		It("should fail for a project and a seed", func() {
+			setenv("GCTL_TARGET_SEED", "envseed")
+
+			dtp := target.NewTargetProvider(tmpFile.Name(), target.NewTargetFlags("", "", "", "", false))
+
+			_, err := dtp.Read()
+			Expect(err).To(MatchError("invalid target environment variables: seed and project must not be configured at the same time"))
+		})
+	})
+
 	It("should write changes as expected", func() {
 		// prepare target
 		dummy := target.NewTarget("mygarden", "myproject", "", "myshoot")